@@ -49,8 +49,8 @@ func runController(ctx context.Context, logger *zap.Logger, port int, openTracin
 	controller.Start(ctx, logger, port, false, openTracingEnabled)
 }
 
-func runRouter(ctx context.Context, logger *zap.Logger, port int, executorUrl string, openTracingEnabled bool) {
-	router.Start(ctx, logger, port, executorUrl, openTracingEnabled)
+func runRouter(ctx context.Context, logger *zap.Logger, port int, executorUrl string, asyncRedisUrl string, openTracingEnabled bool) {
+	router.Start(ctx, logger, port, executorUrl, asyncRedisUrl, openTracingEnabled)
 }
 
 func runExecutor(ctx context.Context, logger *zap.Logger, port int, functionNamespace, envBuilderNamespace string, openTracingEnabled bool) error {
@@ -169,7 +169,7 @@ Use it to start one or more of the fission servers:
 
 Usage:
   fission-bundle --controllerPort=<port>
-  fission-bundle --routerPort=<port> [--executorUrl=<url>]
+  fission-bundle --routerPort=<port> [--executorUrl=<url>] [--async-redis-url=<url>]
   fission-bundle --executorPort=<port> [--namespace=<namespace>] [--fission-namespace=<namespace>]
   fission-bundle --kubewatcher [--routerUrl=<url>]
   fission-bundle --storageServicePort=<port> --storageType=<storateType>
@@ -186,6 +186,7 @@ Options:
   --storageServicePort=<port>     Port that the storage service should listen on.
   --executorUrl=<url>             Executor URL. Not required if --executorPort is specified.
   --routerUrl=<url>               Router URL.
+  --async-redis-url=<url>         Redis URL to keep the jobs of asynchronous invocations in, ex: redis://redis:6379/0.
   --etcdUrl=<etcdUrl>             Etcd URL.
   --storageSvcUrl=<url>           StorageService URL.
   --filePath=<filePath>           Directory to store functions in.
@@ -245,7 +246,8 @@ Options:
 
 	if arguments["--routerPort"] != nil {
 		port := getPort(logger, arguments["--routerPort"])
-		runRouter(ctx, logger, port, executorUrl, openTracingEnabled)
+		asyncRedisUrl := getStringArgWithDefault(arguments["--async-redis-url"], "")
+		runRouter(ctx, logger, port, executorUrl, asyncRedisUrl, openTracingEnabled)
 		logger.Error("router exited")
 		return
 	}
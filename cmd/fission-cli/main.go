@@ -17,18 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/fission/fission/cmd/fission-cli/app"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/console"
 )
 
 func main() {
-	cmd := app.App()
-	cmd.SilenceErrors = true // use our own error message printer
+	rootCmd := app.App()
+	rootCmd.SilenceErrors = true // use our own error message printer
 
-	err := cmd.Execute()
+	err := rootCmd.Execute()
 	if err != nil {
+		var exitErr cmd.ExitError
+		if errors.As(err, &exitErr) {
+			if len(exitErr.Message) > 0 {
+				console.Error(exitErr.Message)
+			}
+			os.Exit(exitErr.Code)
+		}
 		// let program exit with non-zero code when error occurs
 		console.Error(err.Error())
 		os.Exit(1)
@@ -7,6 +7,7 @@ require (
 	github.com/Azure/azure-sdk-for-go v59.0.0+incompatible
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
 	github.com/Shopify/sarama v1.30.0
+	github.com/aws/aws-sdk-go v1.38.41
 	github.com/blend/go-sdk v1.20211025.3 // indirect
 	github.com/bsm/sarama-cluster v2.1.15+incompatible
 	github.com/containerd/continuity v0.2.1 // indirect
@@ -23,7 +24,9 @@ require (
 	github.com/go-git/go-git/v5 v5.4.2
 	github.com/go-ini/ini v1.63.2 // indirect
 	github.com/go-openapi/spec v0.20.4
+	github.com/go-redis/redis/v8 v8.11.4
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/gotestyourself/gotestyourself v2.2.0+incompatible // indirect
 	github.com/graymeta/stow v0.2.7
 	github.com/hashicorp/go-multierror v1.1.1
@@ -39,6 +42,7 @@ require (
 	github.com/opencontainers/runc v1.0.3 // indirect
 	github.com/ory/dockertest v3.3.5+incompatible
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/prometheus/client_golang v1.11.0
 	github.com/prometheus/common v0.32.1
 	github.com/robfig/cron v1.2.0
@@ -62,6 +66,8 @@ require (
 	go.opentelemetry.io/otel/trace v1.1.0
 	go.uber.org/zap v1.19.1
 	golang.org/x/net v0.0.0-20211101193420-4a448f8816b3
+	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d
+	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac
 	google.golang.org/grpc v1.42.0
 	gotest.tools v2.2.0+incompatible // indirect
 	k8s.io/api v0.22.3
@@ -26,6 +26,15 @@ const (
 	ResourceVersionCount string = "RESOURCE_VERSION_COUNT"
 )
 
+const (
+	// HeaderInvokeTime is the time 'fission fn test' sent the request at, in RFC 3339 format
+	HeaderInvokeTime = "X-Fission-Invoke-Time"
+	// HeaderRouterTime is HeaderInvokeTime echoed back by the router
+	HeaderRouterTime = "X-Fission-Router-Time"
+	// HeaderColdStartDuration is how long the router waited for a function pod to serve the request
+	HeaderColdStartDuration = "X-Fission-Cold-Start-Duration"
+)
+
 const (
 	ChecksumTypeSHA256 ChecksumType = "sha256"
 )
@@ -62,6 +71,11 @@ const (
 	StrategyTypeExecution = "execution"
 )
 
+const (
+	ConcurrencyModelGoroutine ConcurrencyModel = "goroutine"
+	ConcurrencyModelProcess   ConcurrencyModel = "process"
+)
+
 const (
 	SharedVolumeUserfunc   = "userfunc"
 	SharedVolumePackages   = "packages"
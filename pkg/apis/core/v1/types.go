@@ -353,6 +353,10 @@ type (
 	// StrategyType is the strategy to be used for function execution
 	StrategyType string
 
+	// ConcurrencyModel is how an environment isolates concurrent
+	// invocations of a function
+	ConcurrencyModel string
+
 	// FunctionSpec describes the contents of the function.
 	FunctionSpec struct {
 		// Environment is the build and runtime environment that this function is
@@ -373,6 +377,13 @@ type (
 		// +nullable
 		ConfigMaps []ConfigMapReference `json:"configmaps,omitempty"`
 
+		// RuntimeEnv is a list of environment variables set in the function
+		// container. This is only for newdeploy and container executors, pods
+		// of the poolmgr pool are shared and cannot carry per-function variables.
+		// +optional
+		// +nullable
+		RuntimeEnv []apiv1.EnvVar `json:"runtimeEnv,omitempty"`
+
 		// cpu and memory resources as per K8S standards
 		// This is only for newdeploy to set up resource limitation
 		// when creating deployment for a function.
@@ -410,6 +421,24 @@ type (
 		// +optional
 		OnceOnly bool `json:"onceOnly,omitempty"`
 
+		// RateLimit is the maximum number of requests per second the router forwards to the function,
+		// requests above the limit are rejected with 429 Too Many Requests.
+		// The limit is enforced by each router replica on its own, so with N replicas
+		// up to N times the limit is forwarded.
+		// This is optional. If not specified the requests are not limited.
+		// +optional
+		RateLimit int `json:"rateLimit,omitempty"`
+
+		// ConcurrencyModel selects whether the environment runs invocations in
+		// goroutines of one process or spawns a process per invocation.
+		// Available value:
+		// - goroutine
+		// - process
+		// It must be one of the SupportedConcurrencyModels of the environment.
+		// This is optional. If not specified the environment default is used.
+		// +optional
+		ConcurrencyModel ConcurrencyModel `json:"concurrencyModel,omitempty"`
+
 		// Podspec specifies podspec to use for executor type container based functions
 		// Different arguments mentioned for container based function are populated inside a pod.
 		// +optional
@@ -637,6 +666,19 @@ type (
 		// private registry.
 		// +optional
 		ImagePullSecret string `json:"imagepullsecret"`
+
+		// RetainArchive makes the fetcher keep a copy of the downloaded zip
+		// archive under archive/ in the shared volume of specialized pods
+		// after extracting it, so the deployed code can be inspected.
+		// +optional
+		RetainArchive bool `json:"retainArchive,omitempty"`
+
+		// SupportedConcurrencyModels lists the concurrency models functions
+		// of the environment can choose from.
+		// (Optional) if empty, functions can't choose a concurrency model.
+		// +optional
+		// +nullable
+		SupportedConcurrencyModels []ConcurrencyModel `json:"supportedConcurrencyModels,omitempty"`
 	}
 	// AllowedFunctionsPerContainer defaults to 'single'. Related to Fission Workflows
 	AllowedFunctionsPerContainer string
@@ -681,6 +723,13 @@ type (
 		// FunctionReference is a reference to the target function.
 		FunctionReference FunctionReference `json:"functionref"`
 
+		// FunctionWeightDistribution splits the requests between functions
+		// by hashing the X-Request-ID header, so that retries of a request
+		// reach the same function. It takes precedence over FunctionReference.
+		// The weights must sum to 100, otherwise all requests go to the first function.
+		// +optional
+		FunctionWeightDistribution []FunctionWeight `json:"functionWeightDistribution,omitempty"`
+
 		// If CreateIngress is true, router will create an ingress definition.
 		// +optional
 		CreateIngress bool `json:"createingress"`
@@ -691,6 +740,16 @@ type (
 		IngressConfig IngressConfig `json:"ingressconfig"`
 	}
 
+	// FunctionWeight is the share of the requests of an HTTP trigger
+	// routed to a function.
+	FunctionWeight struct {
+		// Name of the function.
+		Name string `json:"name"`
+
+		// Weight of the function in percent.
+		Weight int `json:"weight"`
+	}
+
 	// IngressConfig is for router to set up Ingress.
 	IngressConfig struct {
 		// Annotations will be added to metadata when creating Ingress.
@@ -714,6 +773,12 @@ type (
 		// key and crt must match the value of Host field.
 		// +optional
 		TLS string `json:"tls"`
+
+		// IngressClass is the name of the IngressClass the Ingress
+		// is created with. If empty, the default class of the
+		// cluster is used.
+		// +optional
+		IngressClass string `json:"ingressClass,omitempty"`
 	}
 
 	// KubernetesWatchTriggerSpec defines spec of KuberenetesWatchTrigger
@@ -794,6 +859,20 @@ type (
 		// +optional
 		MqtKind string `json:"mqtkind,omitempty"`
 
+		// Kafka brokers to connect to, defaults to the brokers the message
+		// queue trigger was configured with
+		// +optional
+		Brokers []string `json:"brokers,omitempty"`
+
+		// Kafka consumer group of the trigger, defaults to the trigger UID
+		// +optional
+		ConsumerGroup string `json:"consumerGroup,omitempty"`
+
+		// Paused stops the trigger from consuming messages until it is
+		// resumed, without deleting the trigger
+		// +optional
+		Paused bool `json:"paused,omitempty"`
+
 		// (Optional) Podspec allows modification of deployed runtime pod with Kubernetes PodSpec
 		// The merging logic is briefly described below and detailed MergePodSpec function
 		// - Volumes mounts and env variables for function and fetcher container are appended
@@ -278,6 +278,14 @@ func (spec FunctionSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "FunctionSpec.PodSpec", "", "executor type container requires a pod spec"))
 	}
 
+	if spec.RateLimit < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.RateLimit", spec.RateLimit, "must not be negative"))
+	}
+
+	if len(spec.ConcurrencyModel) > 0 {
+		result = multierror.Append(result, spec.ConcurrencyModel.Validate())
+	}
+
 	// TODO Add below validation warning
 	/*if spec.FunctionTimeout <= 0 {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionTimeout value", spec.FunctionTimeout, "not a valid value. Should always be more than 0"))
@@ -286,6 +294,26 @@ func (spec FunctionSpec) Validate() error {
 	return result.ErrorOrNil()
 }
 
+func (model ConcurrencyModel) Validate() error {
+	switch model {
+	case ConcurrencyModelGoroutine, ConcurrencyModelProcess:
+		return nil
+	default:
+		return MakeValidationErr(ErrorUnsupportedType, "ConcurrencyModel", model, "not a valid concurrency model")
+	}
+}
+
+// SupportsConcurrencyModel reports whether functions of the environment
+// can use the given concurrency model.
+func (spec EnvironmentSpec) SupportsConcurrencyModel(model ConcurrencyModel) bool {
+	for _, m := range spec.SupportedConcurrencyModels {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
 func (is InvokeStrategy) Validate() error {
 	result := &multierror.Error{}
 
@@ -400,6 +428,10 @@ func (spec EnvironmentSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "EnvironmentSpec.TerminationGracePeriod", spec.TerminationGracePeriod, "must be greater than or equal to 0"))
 	}
 
+	for _, model := range spec.SupportedConcurrencyModels {
+		result = multierror.Append(result, model.Validate())
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -426,6 +458,13 @@ func (spec HTTPTriggerSpec) Validate() error {
 
 	result = multierror.Append(result, spec.FunctionReference.Validate())
 
+	for _, fw := range spec.FunctionWeightDistribution {
+		result = multierror.Append(result, ValidateKubeName("HTTPTriggerSpec.FunctionWeightDistribution.Name", fw.Name))
+		if fw.Weight < 0 || fw.Weight > 100 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.FunctionWeightDistribution.Weight", fw.Weight, "weight must be between 0 and 100"))
+		}
+	}
+
 	if len(spec.Host) > 0 {
 		e := validation.IsDNS1123Subdomain(spec.Host)
 		if len(e) > 0 {
@@ -280,6 +280,11 @@ func (in *EnvironmentSpec) DeepCopyInto(out *EnvironmentSpec) {
 	in.Runtime.DeepCopyInto(&out.Runtime)
 	in.Builder.DeepCopyInto(&out.Builder)
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SupportedConcurrencyModels != nil {
+		in, out := &in.SupportedConcurrencyModels, &out.SupportedConcurrencyModels
+		*out = make([]ConcurrencyModel, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -424,6 +429,13 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = make([]ConfigMapReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.RuntimeEnv != nil {
+		in, out := &in.RuntimeEnv, &out.RuntimeEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.InvokeStrategy = in.InvokeStrategy
 	if in.IdleTimeout != nil {
@@ -449,6 +461,22 @@ func (in *FunctionSpec) DeepCopy() *FunctionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionWeight) DeepCopyInto(out *FunctionWeight) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionWeight.
+func (in *FunctionWeight) DeepCopy() *FunctionWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
 	*out = *in
@@ -523,6 +551,11 @@ func (in *HTTPTriggerSpec) DeepCopyInto(out *HTTPTriggerSpec) {
 		copy(*out, *in)
 	}
 	in.FunctionReference.DeepCopyInto(&out.FunctionReference)
+	if in.FunctionWeightDistribution != nil {
+		in, out := &in.FunctionWeightDistribution, &out.FunctionWeightDistribution
+		*out = make([]FunctionWeight, len(*in))
+		copy(*out, *in)
+	}
 	in.IngressConfig.DeepCopyInto(&out.IngressConfig)
 	return
 }
@@ -752,6 +785,11 @@ func (in *MessageQueueTriggerSpec) DeepCopyInto(out *MessageQueueTriggerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PodSpec != nil {
 		in, out := &in.PodSpec, &out.PodSpec
 		*out = new(corev1.PodSpec)
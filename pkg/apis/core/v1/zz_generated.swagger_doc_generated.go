@@ -124,6 +124,7 @@ var map_EnvironmentSpec = map[string]string{
 	"terminationGracePeriod":       "The grace time for pod to perform connection draining before termination. The unit is in seconds. (Optional) defaults to 360 seconds",
 	"keeparchive":                  "KeepArchive is used by fetcher to determine if the extracted archive or unarchived file should be placed, which is then used by specialize handler. (This is mainly for the JVM environment because .jar is one kind of zip archive.)",
 	"imagepullsecret":              "ImagePullSecret is the secret for Kubernetes to pull an image from a private registry.",
+	"retainArchive":                "RetainArchive makes the fetcher keep a copy of the downloaded zip archive under archive/ in the shared volume of specialized pods after extracting it, so the deployed code can be inspected.",
 }
 
 func (EnvironmentSpec) SwaggerDoc() map[string]string {
@@ -193,6 +194,7 @@ var map_FunctionSpec = map[string]string{
 	"concurrency":     "Maximum number of pods to be specialized which will serve requests This is optional. If not specified default value will be taken as 500",
 	"requestsPerPod":  "RequestsPerPod indicates the maximum number of concurrent requests that can be served by a specialized pod This is optional. If not specified default value will be taken as 1",
 	"onceOnly":        "OnceOnly specifies if specialized pod will serve exactly one request in its lifetime and would be garbage collected after serving that one request This is optional. If not specified default value will be taken as false",
+	"rateLimit":       "RateLimit is the maximum number of requests per second the router forwards to the function, requests above the limit are rejected with 429 Too Many Requests. This is optional. If not specified the requests are not limited.",
 	"podspec":         "Podspec specifies podspec to use for executor type container based functions Different arguments mentioned for container based function are populated inside a pod.",
 }
 
@@ -200,6 +202,16 @@ func (FunctionSpec) SwaggerDoc() map[string]string {
 	return map_FunctionSpec
 }
 
+var map_FunctionWeight = map[string]string{
+	"":       "FunctionWeight is the share of the requests of an HTTP trigger routed to a function.",
+	"name":   "Name of the function.",
+	"weight": "Weight of the function in percent.",
+}
+
+func (FunctionWeight) SwaggerDoc() map[string]string {
+	return map_FunctionWeight
+}
+
 var map_HTTPTrigger = map[string]string{
 	"": "HTTPTrigger is the trigger invokes user functions when receiving HTTP requests.",
 }
@@ -217,16 +229,17 @@ func (HTTPTriggerList) SwaggerDoc() map[string]string {
 }
 
 var map_HTTPTriggerSpec = map[string]string{
-	"":              "HTTPTriggerSpec is for router to expose user functions at the given URL path.",
-	"host":          "Deprecated: the original idea of this field is not for setting Ingress. Since we have IngressConfig now, remove Host after couple releases.",
-	"relativeurl":   "RelativeURL is the exposed URL for external client to access a function with.",
-	"prefix":        "Prefix with which functions are exposed. NOTE: Prefix takes precedence over URL/RelativeURL. Note that it does not treat slashes specially (\"/foobar/\" will be matched by the prefix \"/foobar\").",
-	"keepPrefix":    "When function is exposed with Prefix based path, keepPrefix decides whether to keep or trim prefix in URL while invoking function.",
-	"method":        "Use Methods instead of Method. This field is going to be deprecated in a future release HTTP method to access a function.",
-	"methods":       "HTTP methods to access a function",
-	"functionref":   "FunctionReference is a reference to the target function.",
-	"createingress": "If CreateIngress is true, router will create an ingress definition.",
-	"ingressconfig": "IngressConfig for router to set up Ingress.",
+	"":                           "HTTPTriggerSpec is for router to expose user functions at the given URL path.",
+	"host":                       "Deprecated: the original idea of this field is not for setting Ingress. Since we have IngressConfig now, remove Host after couple releases.",
+	"relativeurl":                "RelativeURL is the exposed URL for external client to access a function with.",
+	"prefix":                     "Prefix with which functions are exposed. NOTE: Prefix takes precedence over URL/RelativeURL. Note that it does not treat slashes specially (\"/foobar/\" will be matched by the prefix \"/foobar\").",
+	"keepPrefix":                 "When function is exposed with Prefix based path, keepPrefix decides whether to keep or trim prefix in URL while invoking function.",
+	"method":                     "Use Methods instead of Method. This field is going to be deprecated in a future release HTTP method to access a function.",
+	"methods":                    "HTTP methods to access a function",
+	"functionref":                "FunctionReference is a reference to the target function.",
+	"functionWeightDistribution": "FunctionWeightDistribution splits the requests between functions by hashing the X-Request-ID header, so that retries of a request reach the same function. It takes precedence over FunctionReference. The weights must sum to 100, otherwise all requests go to the first function.",
+	"createingress":              "If CreateIngress is true, router will create an ingress definition.",
+	"ingressconfig":              "IngressConfig for router to set up Ingress.",
 }
 
 func (HTTPTriggerSpec) SwaggerDoc() map[string]string {
@@ -234,11 +247,12 @@ func (HTTPTriggerSpec) SwaggerDoc() map[string]string {
 }
 
 var map_IngressConfig = map[string]string{
-	"":            "IngressConfig is for router to set up Ingress.",
-	"annotations": "Annotations will be added to metadata when creating Ingress.",
-	"path":        "Path is for path matching. The format of path depends on what ingress controller you used.",
-	"host":        "Host is for ingress controller to apply rules. If host is empty or \"*\", the rule applies to all inbound HTTP traffic.",
-	"tls":         "TLS is for user to specify a Secret that contains TLS key and certificate. The domain name in the key and crt must match the value of Host field.",
+	"":             "IngressConfig is for router to set up Ingress.",
+	"annotations":  "Annotations will be added to metadata when creating Ingress.",
+	"path":         "Path is for path matching. The format of path depends on what ingress controller you used.",
+	"host":         "Host is for ingress controller to apply rules. If host is empty or \"*\", the rule applies to all inbound HTTP traffic.",
+	"tls":          "TLS is for user to specify a Secret that contains TLS key and certificate. The domain name in the key and crt must match the value of Host field.",
+	"ingressClass": "IngressClass is the name of the IngressClass the Ingress is created with. If empty, the default class of the cluster is used.",
 }
 
 func (IngressConfig) SwaggerDoc() map[string]string {
@@ -314,6 +328,9 @@ var map_MessageQueueTriggerSpec = map[string]string{
 	"metadata":         "ScalerTrigger fields",
 	"secret":           "Secret name",
 	"mqtkind":          "Kind of Message Queue Trigger to be created, by default its fission",
+	"brokers":          "Kafka brokers to connect to, defaults to the brokers the message queue trigger was configured with",
+	"consumerGroup":    "Kafka consumer group of the trigger, defaults to the trigger UID",
+	"paused":           "Paused stops the trigger from consuming messages until it is resumed, without deleting the trigger",
 	"podspec":          "(Optional) Podspec allows modification of deployed runtime pod with Kubernetes PodSpec The merging logic is briefly described below and detailed MergePodSpec function - Volumes mounts and env variables for function and fetcher container are appended - All additional containers and init containers are appended - Volume definitions are appended - Lists such as tolerations, ImagePullSecrets, HostAliases are appended - Structs are merged and variables from pod spec take precedence",
 }
 
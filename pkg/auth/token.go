@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auth issues and verifies the short-lived tokens used to
+// invoke functions. Tokens are JWTs signed with HMAC-SHA256.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// TokenRequest is sent to the controller to get a new token.
+	TokenRequest struct {
+		Username  string   `json:"username"`
+		Password  string   `json:"password"`
+		Functions []string `json:"functions,omitempty"`
+		Paths     []string `json:"paths,omitempty"`
+		ExpiresIn string   `json:"expiresIn,omitempty"`
+	}
+
+	// TokenResponse holds a signed token and its expiry time.
+	TokenResponse struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+
+	// Claims of a token. A token without functions and paths may
+	// invoke any function.
+	Claims struct {
+		Subject   string   `json:"sub"`
+		IssuedAt  int64    `json:"iat"`
+		ExpiresAt int64    `json:"exp"`
+		Functions []string `json:"functions,omitempty"`
+		Paths     []string `json:"paths,omitempty"`
+	}
+)
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Sign returns the claims as a JWT signed with key.
+func Sign(claims *Claims, key []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "error encoding token claims")
+	}
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signature(unsigned, key), nil
+}
+
+// Verify checks the signature and expiry of a token and returns its claims.
+func Verify(token string, key []byte) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, errors.New("malformed token")
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(signature(parts[0]+"."+parts[1], key))) {
+		return nil, errors.New("invalid token signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding token claims")
+	}
+	claims := &Claims{}
+	err = json.Unmarshal(payload, claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding token claims")
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	return claims, nil
+}
+
+// Allows returns true if the claims allow invoking a trigger with the
+// given path that routes to the given functions. The path must be one of
+// the token paths, or all functions must be token functions.
+func (c *Claims) Allows(path string, functions []string) bool {
+	if len(c.Functions) == 0 && len(c.Paths) == 0 {
+		return true
+	}
+	for _, p := range c.Paths {
+		if p == path {
+			return true
+		}
+	}
+	if len(functions) == 0 {
+		return false
+	}
+	for _, fn := range functions {
+		if !contains(c.Functions, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func signature(unsigned string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
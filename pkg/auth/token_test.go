@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	key := []byte("secret")
+	now := time.Now()
+
+	valid, err := Sign(&Claims{Subject: "ci", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix(), Functions: []string{"hello"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := Sign(&Claims{Subject: "ci", IssuedAt: now.Add(-2 * time.Hour).Unix(), ExpiresAt: now.Add(-time.Hour).Unix()}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		key     []byte
+		wantErr bool
+	}{
+		{name: "valid", token: valid, key: key},
+		{name: "wrong key", token: valid, key: []byte("other"), wantErr: true},
+		{name: "expired", token: expired, key: key, wantErr: true},
+		{name: "malformed", token: "abc.def", key: key, wantErr: true},
+		{name: "tampered", token: valid[:len(valid)-2] + "xx", key: key, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := Verify(tt.token, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (claims.Subject != "ci" || len(claims.Functions) != 1 || claims.Functions[0] != "hello") {
+				t.Errorf("Verify() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestClaimsAllows(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    Claims
+		path      string
+		functions []string
+		want      bool
+	}{
+		{name: "unscoped", claims: Claims{}, path: "/hello", functions: []string{"hello"}, want: true},
+		{name: "path", claims: Claims{Paths: []string{"/hello"}}, path: "/hello", functions: []string{"hello"}, want: true},
+		{name: "other path", claims: Claims{Paths: []string{"/hello"}}, path: "/bye", functions: []string{"bye"}, want: false},
+		{name: "function", claims: Claims{Functions: []string{"hello"}}, path: "/hello", functions: []string{"hello"}, want: true},
+		{name: "one of the canary functions", claims: Claims{Functions: []string{"hello"}}, path: "/hello", functions: []string{"hello", "hello-v2"}, want: false},
+		{name: "all canary functions", claims: Claims{Functions: []string{"hello", "hello-v2"}}, path: "/hello", functions: []string{"hello", "hello-v2"}, want: true},
+		{name: "no functions", claims: Claims{Functions: []string{"hello"}}, path: "/hello", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.claims.Allows(tt.path, tt.functions); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -256,8 +256,12 @@ func (api *API) GetHandler() http.Handler {
 	r.HandleFunc("/v2/canaryconfigs/{canaryConfig}", api.CanaryConfigApiDelete).Methods("DELETE")
 	r.HandleFunc("/v2/canaryconfigs", api.CanaryConfigApiList).Methods("GET")
 
+	r.HandleFunc("/v2/auth/token", api.AuthTokenCreate).Methods("POST")
+
 	r.HandleFunc("/proxy/{dbType}", api.FunctionLogsApiPost).Methods("POST")
 	r.HandleFunc("/proxy/storage/v1/archive", api.StorageServiceProxy)
+	r.HandleFunc("/proxy/storage/v1/archive/chunk", api.StorageServiceProxy)
+	r.HandleFunc("/proxy/storage/v1/archive/commit", api.StorageServiceProxy)
 	r.HandleFunc("/proxy/logs/{function}", api.FunctionPodLogs).Methods("POST")
 	r.HandleFunc("/proxy/workflows-apiserver/{path:.*}", api.WorkflowApiserverProxy)
 	r.HandleFunc("/proxy/svcname", api.GetSvcName).Queries("application", "").Methods("GET")
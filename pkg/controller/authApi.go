@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/auth"
+	ferror "github.com/fission/fission/pkg/error"
+)
+
+const (
+	defaultTokenTTL = time.Hour
+	maxTokenTTL     = 24 * time.Hour
+)
+
+// AuthTokenCreate issues a signed token for invoking functions in
+// exchange for the service account credentials in AUTH_USERNAME and
+// AUTH_PASSWORD. Tokens are signed with JWT_SIGNING_KEY; without it
+// token authentication is disabled.
+func (a *API) AuthTokenCreate(w http.ResponseWriter, r *http.Request) {
+	signingKey := os.Getenv("JWT_SIGNING_KEY")
+	if len(signingKey) == 0 {
+		a.respondWithError(w, ferror.MakeError(ferror.ErrorNotImplemented, "token authentication is not enabled"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	var req auth.TokenRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		a.logger.Error("failed to unmarshal request body", zap.Error(err))
+		a.respondWithError(w, ferror.MakeError(ferror.ErrorInvalidArgument, err.Error()))
+		return
+	}
+
+	username := os.Getenv("AUTH_USERNAME")
+	password := os.Getenv("AUTH_PASSWORD")
+	if len(username) == 0 ||
+		subtle.ConstantTimeCompare([]byte(req.Username), []byte(username)) != 1 ||
+		subtle.ConstantTimeCompare([]byte(req.Password), []byte(password)) != 1 {
+		a.logger.Info("rejected token request", zap.String("username", req.Username))
+		a.respondWithError(w, ferror.MakeError(ferror.ErrorNotAuthorized, "invalid username or password"))
+		return
+	}
+
+	ttl := defaultTokenTTL
+	if len(req.ExpiresIn) > 0 {
+		ttl, err = time.ParseDuration(req.ExpiresIn)
+		if err != nil || ttl <= 0 || ttl > maxTokenTTL {
+			a.respondWithError(w, ferror.MakeError(ferror.ErrorInvalidArgument,
+				fmt.Sprintf("expiresIn must be a duration between 0 and %v", maxTokenTTL)))
+			return
+		}
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	token, err := auth.Sign(&auth.Claims{
+		Subject:   req.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Functions: req.Functions,
+		Paths:     req.Paths,
+	}, []byte(signingKey))
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(auth.TokenResponse{
+		Token:     token,
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+	})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	a.respondWithSuccess(w, resp)
+}
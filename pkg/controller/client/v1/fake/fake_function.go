@@ -56,6 +56,10 @@ func (c *FakeFunction) List(functionNamespace string) ([]fv1.Function, error) {
 	return nil, nil
 }
 
+func (c *FakeFunction) ListWithLabelSelector(functionNamespace string, labelSelector string) ([]fv1.Function, error) {
+	return nil, nil
+}
+
 func (c *FakeFunction) ListPods(m *metav1.ObjectMeta) ([]apiv1.Pod, error) {
 	return nil, nil
 }
@@ -21,6 +21,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/fission/fission/pkg/auth"
 	v1 "github.com/fission/fission/pkg/controller/client/v1"
 	"github.com/fission/fission/pkg/info"
 )
@@ -53,3 +54,7 @@ func (c *FakeMisc) ServerInfo() (*info.ServerInfo, error) {
 func (c *FakeMisc) PodLogs(m *metav1.ObjectMeta) (io.ReadCloser, int, error) {
 	return nil, 0, nil
 }
+
+func (c *FakeMisc) AuthToken(req *auth.TokenRequest) (*auth.TokenResponse, error) {
+	return &auth.TokenResponse{}, nil
+}
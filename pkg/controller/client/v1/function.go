@@ -40,6 +40,7 @@ type (
 		Update(f *fv1.Function) (*metav1.ObjectMeta, error)
 		Delete(m *metav1.ObjectMeta) error
 		List(functionNamespace string) ([]fv1.Function, error)
+		ListWithLabelSelector(functionNamespace string, labelSelector string) ([]fv1.Function, error)
 		ListPods(m *metav1.ObjectMeta) ([]apiv1.Pod, error)
 	}
 
@@ -159,7 +160,19 @@ func (c *Function) Delete(m *metav1.ObjectMeta) error {
 }
 
 func (c *Function) List(functionNamespace string) ([]fv1.Function, error) {
-	relativeUrl := fmt.Sprintf("functions?namespace=%v", functionNamespace)
+	return c.ListWithLabelSelector(functionNamespace, "")
+}
+
+// ListWithLabelSelector lists the functions in a namespace whose labels
+// match the given selector. The selector is evaluated by the API server.
+func (c *Function) ListWithLabelSelector(functionNamespace string, labelSelector string) ([]fv1.Function, error) {
+	values := url.Values{}
+	values.Add("namespace", functionNamespace)
+	if len(labelSelector) > 0 {
+		values.Add("labelSelector", labelSelector)
+	}
+
+	relativeUrl := fmt.Sprintf("functions?%v", values.Encode())
 	resp, err := c.client.Get(relativeUrl)
 	if err != nil {
 		return nil, err
@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/fission/fission/pkg/auth"
 	"github.com/fission/fission/pkg/controller/client/rest"
 	"github.com/fission/fission/pkg/fission-cli/console"
 	"github.com/fission/fission/pkg/info"
@@ -42,6 +43,7 @@ type (
 		GetSvcURL(label string) (string, error)
 		ServerInfo() (*info.ServerInfo, error)
 		PodLogs(m *metav1.ObjectMeta) (io.ReadCloser, int, error)
+		AuthToken(req *auth.TokenRequest) (*auth.TokenResponse, error)
 	}
 
 	Misc struct {
@@ -127,3 +129,29 @@ func (c *Misc) PodLogs(m *metav1.ObjectMeta) (io.ReadCloser, int, error) {
 	}
 	return resp.Body, resp.StatusCode, nil
 }
+
+func (c *Misc) AuthToken(req *auth.TokenRequest) (*auth.TokenResponse, error) {
+	reqbody, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Create("auth/token", "application/json", reqbody)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing token request")
+	}
+	defer resp.Body.Close()
+
+	body, err := handleCreateResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &auth.TokenResponse{}
+	err = json.Unmarshal(body, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
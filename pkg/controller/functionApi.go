@@ -55,6 +55,7 @@ func RegisterFunctionRoute(ws *restful.WebService) {
 				resp.ResponseWriter.WriteHeader(http.StatusOK)
 			}).
 			Param(ws.QueryParameter("namespace", "Namespace of function").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
+			Param(ws.QueryParameter("labelSelector", "Label selector of functions").DataType("string").DefaultValue("").Required(false)).
 			Produces(restful.MIME_JSON).
 			Writes([]fv1.Function{}).
 			Returns(http.StatusOK, "List of functions", []fv1.Function{}))
@@ -116,7 +117,9 @@ func (a *API) FunctionApiList(w http.ResponseWriter, r *http.Request) {
 		ns = metav1.NamespaceAll
 	}
 
-	funcs, err := a.fissionClient.CoreV1().Functions(ns).List(r.Context(), metav1.ListOptions{})
+	funcs, err := a.fissionClient.CoreV1().Functions(ns).List(r.Context(), metav1.ListOptions{
+		LabelSelector: a.extractQueryParamFromRequest(r, "labelSelector"),
+	})
 	if err != nil {
 		a.respondWithError(w, err)
 		return
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 
 	"github.com/emicklei/go-restful"
 	restfulspec "github.com/emicklei/go-restful-openapi"
@@ -54,6 +55,27 @@ func RegisterStorageServiceProxyRoute(ws *restful.WebService) {
 			To(func(req *restful.Request, resp *restful.Response) {
 				resp.ResponseWriter.WriteHeader(http.StatusOK)
 			}))
+	ws.Route(
+		ws.POST("/proxy/storage/v1/archive/chunk").
+			Doc("Upload archive chunk").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}))
+	ws.Route(
+		ws.GET("/proxy/storage/v1/archive/chunk").
+			Doc("List received archive chunks").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}))
+	ws.Route(
+		ws.POST("/proxy/storage/v1/archive/commit").
+			Doc("Assemble uploaded archive chunks").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}))
 }
 
 func (api *API) StorageServiceProxy(w http.ResponseWriter, r *http.Request) {
@@ -68,7 +90,8 @@ func (api *API) StorageServiceProxy(w http.ResponseWriter, r *http.Request) {
 	director := func(req *http.Request) {
 		req.URL.Scheme = ssUrl.Scheme
 		req.URL.Host = ssUrl.Host
-		req.URL.Path = "/v1/archive"
+		// keep the sub path of chunked upload requests
+		req.URL.Path = strings.TrimPrefix(req.URL.Path, "/proxy/storage")
 		req.Host = ssUrl.Host
 	}
 	proxy := &httputil.ReverseProxy{
@@ -123,6 +123,14 @@ func IsNotFound(err error) bool {
 	return fe.Code == ErrorNotFound
 }
 
+func IsNameExists(err error) bool {
+	fe, ok := err.(Error)
+	if !ok {
+		return false
+	}
+	return fe.Code == ErrorNameExists
+}
+
 const (
 	ErrorInternal = iota
 
@@ -555,6 +555,10 @@ func (caaf *Container) updateFunction(ctx context.Context, oldFn *fv1.Function,
 		}
 	}
 
+	if !reflect.DeepEqual(oldFn.Spec.RuntimeEnv, newFn.Spec.RuntimeEnv) {
+		deployChanged = true
+	}
+
 	if !reflect.DeepEqual(oldFn.Spec.PodSpec, newFn.Spec.PodSpec) {
 		deployChanged = true
 	}
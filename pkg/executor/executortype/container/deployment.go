@@ -229,12 +229,12 @@ func (cn *Container) getDeploymentSpec(ctx context.Context, fn *fv1.Function, ta
 				},
 			},
 		},
-		Env: []apiv1.EnvVar{
+		Env: append([]apiv1.EnvVar{
 			{
 				Name:  fv1.ResourceVersionCount,
 				Value: fmt.Sprintf("%v", rvCount),
 			},
-		},
+		}, fn.Spec.RuntimeEnv...),
 		EnvFrom: envFromSources,
 		// https://istio.io/docs/setup/kubernetes/additional-setup/requirements/
 		Resources: resources,
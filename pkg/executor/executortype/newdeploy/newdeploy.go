@@ -254,12 +254,12 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 				},
 			},
 		},
-		Env: []apiv1.EnvVar{
+		Env: append([]apiv1.EnvVar{
 			{
 				Name:  fv1.ResourceVersionCount,
 				Value: fmt.Sprintf("%v", rvCount),
 			},
-		},
+		}, fn.Spec.RuntimeEnv...),
 		// https://istio.io/docs/setup/kubernetes/additional-setup/requirements/
 		Ports: []apiv1.ContainerPort{
 			{
@@ -311,6 +311,11 @@ func (deploy *NewDeploy) getDeploymentSpec(ctx context.Context, fn *fv1.Function
 		},
 	}
 
+	if len(fn.Spec.ConcurrencyModel) > 0 && !env.Spec.SupportsConcurrencyModel(fn.Spec.ConcurrencyModel) {
+		return nil, errors.Errorf("environment %s does not support the concurrency model %s of function %s",
+			env.ObjectMeta.Name, fn.Spec.ConcurrencyModel, fn.ObjectMeta.Name)
+	}
+
 	// Order of merging is important here - first fetcher, then containers and lastly pod spec
 	err = deploy.fetcherConfig.AddSpecializingFetcherToPodSpec(
 		&deployment.Spec.Template.Spec,
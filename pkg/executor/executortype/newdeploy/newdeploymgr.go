@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -601,6 +602,10 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 		}
 	}
 
+	if !reflect.DeepEqual(oldFn.Spec.RuntimeEnv, newFn.Spec.RuntimeEnv) {
+		deployChanged = true
+	}
+
 	if deployChanged {
 		env, err := deploy.fissionClient.CoreV1().Environments(newFn.Spec.Environment.Namespace).
 			Get(ctx, newFn.Spec.Environment.Name, metav1.GetOptions{})
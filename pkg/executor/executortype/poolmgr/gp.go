@@ -399,6 +399,11 @@ func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv
 		podIP = fmt.Sprintf("%v.%v", svc, gp.namespace)
 	}
 
+	if len(fn.Spec.ConcurrencyModel) > 0 && !gp.env.Spec.SupportsConcurrencyModel(fn.Spec.ConcurrencyModel) {
+		return errors.Errorf("environment %s does not support the concurrency model %s of function %s",
+			gp.env.ObjectMeta.Name, fn.Spec.ConcurrencyModel, fn.ObjectMeta.Name)
+	}
+
 	// tell fetcher to get the function.
 	fetcherURL := gp.getFetcherURL(podIP)
 	logger.Info("calling fetcher to copy function", zap.String("function", fn.ObjectMeta.Name), zap.String("url", fetcherURL))
@@ -26,6 +26,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/executor/util"
@@ -140,6 +141,12 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 		poolsize = 1
 	}
 
+	// Bring up a full set of pods with the new spec before removing the
+	// old ones, so the pool doesn't shrink while an environment update
+	// rolls out.
+	maxSurge := intstr.FromString("100%")
+	maxUnavailable := intstr.FromInt(0)
+
 	deploymentSpec := appsv1.DeploymentSpec{
 		// TODO: fix this hardcoded value
 		Replicas: &poolsize,
@@ -147,6 +154,13 @@ func (gp *GenericPool) genDeploymentSpec(env *fv1.Environment) (*appsv1.Deployme
 			MatchLabels: deployLabels,
 		},
 		Template: pod,
+		Strategy: appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge:       &maxSurge,
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
 	}
 
 	// Order of merging is important here - first fetcher, then containers and lastly pod spec
@@ -130,16 +130,18 @@ func (cfg *Config) NewSpecializeRequest(fn *fv1.Function, env *fv1.Environment)
 				Name:            fn.Spec.Package.PackageRef.Name,
 				ResourceVersion: fn.Spec.Package.PackageRef.ResourceVersion,
 			},
-			Filename:    targetFilename,
-			Secrets:     fn.Spec.Secrets,
-			ConfigMaps:  fn.Spec.ConfigMaps,
-			KeepArchive: env.Spec.KeepArchive,
+			Filename:      targetFilename,
+			Secrets:       fn.Spec.Secrets,
+			ConfigMaps:    fn.Spec.ConfigMaps,
+			KeepArchive:   env.Spec.KeepArchive,
+			RetainArchive: env.Spec.RetainArchive,
 		},
 		LoadReq: fetcher.FunctionLoadRequest{
 			FilePath:         filepath.Join(cfg.sharedMountPath, targetFilename),
 			FunctionName:     fn.Spec.Package.FunctionName,
 			FunctionMetadata: &fn.ObjectMeta,
 			EnvVersion:       env.Spec.Version,
+			ConcurrencyModel: fn.Spec.ConcurrencyModel,
 		},
 	}
 }
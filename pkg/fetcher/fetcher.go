@@ -56,6 +56,10 @@ import (
 	"github.com/fission/fission/pkg/utils/tracing"
 )
 
+// retainedArchiveDir is the directory in the shared volume that downloaded
+// archives are kept in for environments with RetainArchive set.
+const retainedArchiveDir = "archive"
+
 type (
 	Fetcher struct {
 		logger           *zap.Logger
@@ -333,21 +337,21 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 				logger.Error(e, zap.Error(err), zap.String("url", req.Url))
 				return http.StatusBadRequest, errors.Wrapf(err, "%s %s", e, req.Url)
 			}
+		}
 
-			// check file integrity only if checksum is not empty.
-			if len(archive.Checksum.Sum) > 0 {
-				checksum, err := utils.GetFileChecksum(tmpPath)
-				if err != nil {
-					e := "failed to get checksum"
-					logger.Error(e, zap.Error(err))
-					return http.StatusBadRequest, errors.Wrap(err, e)
-				}
-				err = verifyChecksum(checksum, &archive.Checksum)
-				if err != nil {
-					e := "failed to verify checksum"
-					logger.Error(e, zap.Error(err))
-					return http.StatusBadRequest, errors.Wrap(err, e)
-				}
+		// check file integrity only if checksum is not empty.
+		if len(archive.Checksum.Sum) > 0 {
+			checksum, err := utils.GetFileChecksum(tmpPath)
+			if err != nil {
+				e := "failed to get checksum"
+				logger.Error(e, zap.Error(err))
+				return http.StatusBadRequest, errors.Wrap(err, e)
+			}
+			err = verifyChecksum(checksum, &archive.Checksum)
+			if err != nil {
+				e := "failed to verify checksum"
+				logger.Error(e, zap.Error(err))
+				return http.StatusBadRequest, errors.Wrap(err, e)
 			}
 		}
 	}
@@ -372,6 +376,19 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 			return http.StatusInternalServerError, err
 		}
 
+		if req.RetainArchive {
+			archivePath := filepath.Join(fetcher.sharedVolumePath, retainedArchiveDir, req.Filename+".zip")
+			err = fetcher.retainArchive(tmpPath, archivePath)
+			if err != nil {
+				logger.Error("error retaining archive",
+					zap.Error(err),
+					zap.String("archive_location", tmpPath),
+					zap.String("target_location", archivePath))
+				return http.StatusInternalServerError, err
+			}
+			logger.Info("retained archive", zap.String("location", archivePath))
+		}
+
 		tmpPath = tmpUnarchivePath
 	}
 
@@ -604,6 +621,16 @@ func (fetcher *Fetcher) rename(src string, dst string) error {
 	return nil
 }
 
+// retainArchive moves the downloaded archive at src to dst, creating
+// the parent directory of dst if needed.
+func (fetcher *Fetcher) retainArchive(src string, dst string) error {
+	err := os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive directory")
+	}
+	return fetcher.rename(src, dst)
+}
+
 // archive zips the contents of directory at src into a new zip file
 // at dst (note that the contents are zipped, not the directory itself).
 func (fetcher *Fetcher) archive(src string, dst string) error {
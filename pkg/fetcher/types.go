@@ -44,6 +44,7 @@ type (
 		Secrets       []fv1.SecretReference    `json:"secretList"`
 		ConfigMaps    []fv1.ConfigMapReference `json:"configMapList"`
 		KeepArchive   bool                     `json:"keeparchive"`
+		RetainArchive bool                     `json:"retainArchive"`
 	}
 
 	FunctionLoadRequest struct {
@@ -66,6 +67,11 @@ type (
 		FunctionMetadata *metav1.ObjectMeta
 
 		EnvVersion int `json:"envVersion"`
+
+		// ConcurrencyModel tells the environment whether to run
+		// invocations in goroutines or in a process each. Optional;
+		// default is environment-specific.
+		ConcurrencyModel fv1.ConcurrencyModel `json:"concurrencyModel,omitempty"`
 	}
 
 	// ArchiveUploadRequest send from builder manager describes which
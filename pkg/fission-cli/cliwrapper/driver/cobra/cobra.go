@@ -19,6 +19,7 @@ package cobra
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -28,25 +29,49 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra/helptemplate"
 	cmd "github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 var _ wCli.Input = &Cli{}
 
+// namespaceKeys are the object namespace flags which fall back to the
+// global --namespace flag and FISSION_DEFAULT_NAMESPACE when not given.
+var namespaceKeys = map[string]bool{
+	flagkey.NamespaceFunction:    true,
+	flagkey.NamespaceEnvironment: true,
+	flagkey.NamespacePackage:     true,
+	flagkey.NamespaceTrigger:     true,
+	flagkey.NamespaceCanary:      true,
+}
+
 type (
 	Cli struct {
 		c    *cobra.Command
 		args []string
+		ns   *configuredNamespace
+	}
+
+	// configuredNamespace is the namespace of FISSION_DEFAULT_NAMESPACE
+	// or the CLI config, resolved once per command.
+	configuredNamespace struct {
+		once  sync.Once
+		value string
 	}
 )
 
+func newCli(c *cobra.Command, args []string) Cli {
+	return Cli{c: c, args: args, ns: &configuredNamespace{}}
+}
+
 // Parse is only for converting urfave *cli.Context to Input and will be removed in future.
 func Parse(cmd *cobra.Command, args []string) wCli.Input {
-	return Cli{c: cmd, args: args}
+	return newCli(cmd, args)
 }
 
 func Wrapper(action cmd.CommandAction) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
-		return action(Cli{c: c, args: args})
+		return action(newCli(c, args))
 	}
 }
 
@@ -207,8 +232,9 @@ func toCobraFlag(cmd *cobra.Command, f flag.Flag, global bool) {
 
 func WrapperChain(actions ...cmd.CommandAction) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
+		input := newCli(c, args)
 		for _, action := range actions {
-			err := action(Cli{c: c, args: args})
+			err := action(input)
 			if err != nil {
 				return err
 			}
@@ -228,10 +254,40 @@ func (u Cli) Bool(key string) bool {
 }
 
 func (u Cli) String(key string) string {
+	if namespaceKeys[key] && !u.c.Flags().Changed(key) {
+		return u.namespace(key)
+	}
+	v, _ := u.c.Flags().GetString(key)
+	return v
+}
+
+// namespace returns the value of the global --namespace flag, or
+// FISSION_DEFAULT_NAMESPACE or the namespace of the CLI config if set,
+// for an object namespace flag which is not given on the command line.
+//
+// Only the persistent flag of the root command is read, commands like
+// 'watch create' have a local --namespace flag with another meaning.
+func (u Cli) namespace(key string) string {
+	if f := u.c.Root().PersistentFlags().Lookup(flagkey.Namespace); f != nil && f.Changed {
+		return f.Value.String()
+	}
+	if ns := u.configuredNamespace(); len(ns) > 0 {
+		return ns
+	}
 	v, _ := u.c.Flags().GetString(key)
 	return v
 }
 
+func (u Cli) configuredNamespace() string {
+	if u.ns == nil {
+		return util.GetConfiguredNamespace()
+	}
+	u.ns.once.Do(func() {
+		u.ns.value = util.GetConfiguredNamespace()
+	})
+	return u.ns.value
+}
+
 func (u Cli) StringSlice(key string) []string {
 	// difference between StringSlice and StringArray
 	// --ss="one" --ss="two,three"
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cobra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+func TestNamespaceFallback(t *testing.T) {
+	dir, err := os.MkdirTemp("", "fission-cli-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for key, value := range map[string]string{
+		util.ENV_CONFIG:            filepath.Join(dir, "config.yaml"),
+		util.ENV_DEFAULT_NAMESPACE: "",
+	} {
+		old, ok := os.LookupEnv(key)
+		os.Setenv(key, value)
+		if ok {
+			defer os.Setenv(key, old)
+		} else {
+			defer os.Unsetenv(key)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		args      []string
+		fnNs      string
+		watchedNs string
+	}{
+		{"default", []string{"get"}, "default", ""},
+		{"global flag", []string{"get", "-n", "foo"}, "foo", ""},
+		{"object flag", []string{"get", "-n", "foo", "--fns", "bar"}, "bar", ""},
+		// the local --namespace of 'watch create' shadows the global one
+		{"local namespace flag", []string{"create", "--namespace", "watched"}, "default", "watched"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var fnNs, watchedNs string
+			root := &cobra.Command{Use: "fission"}
+			SetFlags(root, flag.FlagSet{Global: []flag.Flag{flag.GlobalNamespace}})
+			get := &cobra.Command{
+				Use: "get",
+				RunE: func(c *cobra.Command, args []string) error {
+					fnNs = newCli(c, args).String(flagkey.NamespaceFunction)
+					return nil
+				},
+			}
+			SetFlags(get, flag.FlagSet{Optional: []flag.Flag{flag.NamespaceFunction}})
+			create := &cobra.Command{
+				Use: "create",
+				RunE: func(c *cobra.Command, args []string) error {
+					input := newCli(c, args)
+					fnNs = input.String(flagkey.NamespaceFunction)
+					watchedNs = input.String(flagkey.KwNamespace)
+					return nil
+				},
+			}
+			SetFlags(create, flag.FlagSet{Optional: []flag.Flag{flag.KwNamespace, flag.NamespaceFunction}})
+			root.AddCommand(get, create)
+			root.SetArgs(c.args)
+
+			assert.NoError(t, root.Execute())
+			assert.Equal(t, c.fnNs, fnNs)
+			assert.Equal(t, c.watchedNs, watchedNs)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+// Commands returns auth commands
+func Commands() *cobra.Command {
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Get a short-lived token for invoking functions",
+		Long:  "Get a short-lived token for invoking functions. With authentication enabled in the chart, the router only serves HTTP triggers and async invocations to requests with a valid token in the Authorization header. The token is saved in ~/.fission/token and sent as a Bearer token by 'fission fn test' until it expires.",
+		RunE:  wrapper.Wrapper(Token),
+	}
+	wrapper.SetFlags(tokenCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.AuthUsername},
+		Optional: []flag.Flag{flag.AuthPassword, flag.AuthFunction, flag.AuthPath, flag.AuthExpiresIn},
+	})
+
+	command := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage tokens for invoking functions",
+	}
+
+	command.AddCommand(tokenCmd)
+
+	return command
+}
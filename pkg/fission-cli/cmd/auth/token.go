@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/auth"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// ENV_AUTH_PASSWORD is read if --password is not given, so that the
+// password doesn't show up in the shell history.
+const ENV_AUTH_PASSWORD = "FISSION_AUTH_PASSWORD"
+
+type TokenSubCommand struct {
+	cmd.CommandActioner
+	request *auth.TokenRequest
+}
+
+// Token requests a new token from the controller and caches it.
+func Token(input cli.Input) error {
+	return (&TokenSubCommand{}).do(input)
+}
+
+func (opts *TokenSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *TokenSubCommand) complete(input cli.Input) error {
+	password := input.String(flagkey.AuthPassword)
+	if len(password) == 0 {
+		password = os.Getenv(ENV_AUTH_PASSWORD)
+	}
+	if len(password) == 0 {
+		return errors.Errorf("--%v or %v is required", flagkey.AuthPassword, ENV_AUTH_PASSWORD)
+	}
+
+	expiresIn := input.String(flagkey.AuthExpiresIn)
+	ttl, err := time.ParseDuration(expiresIn)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing --%v", flagkey.AuthExpiresIn)
+	}
+	if ttl <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.AuthExpiresIn)
+	}
+
+	opts.request = &auth.TokenRequest{
+		Username:  input.String(flagkey.AuthUsername),
+		Password:  password,
+		Functions: input.StringSlice(flagkey.AuthFunction),
+		Paths:     input.StringSlice(flagkey.AuthPath),
+		ExpiresIn: expiresIn,
+	}
+	return nil
+}
+
+func (opts *TokenSubCommand) run(input cli.Input) error {
+	token, err := opts.Client().V1().Misc().AuthToken(opts.request)
+	if err != nil {
+		return errors.Wrap(err, "error getting auth token")
+	}
+
+	err = util.SaveAuthToken(token)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token.Token)
+	fmt.Fprintf(os.Stderr, "Token expires in %v (at %v)\n",
+		time.Until(token.ExpiresAt).Round(time.Second), token.ExpiresAt.Local().Format(time.RFC3339))
+	return nil
+}
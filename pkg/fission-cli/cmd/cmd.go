@@ -26,8 +26,20 @@ import (
 type (
 	CommandAction   func(input cli.Input) error
 	CommandActioner struct{}
+
+	// ExitError is returned by a command which has to exit with a
+	// specific code, e.g. to tell scripts how an invoked function failed.
+	// Message is printed unless it's empty.
+	ExitError struct {
+		Code    int
+		Message string
+	}
 )
 
+func (e ExitError) Error() string {
+	return e.Message
+}
+
 var (
 	once             = sync.Once{}
 	defaultClientset client.Interface
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+// Commands returns config commands
+func Commands() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create the CLI config file",
+		Long:  "Ask for the controller URL, router URL, default namespace and auth token and save them in ~/.fission/config.yaml, or the file $FISSION_CONFIG points to. Commands use these values unless the matching flag or environment variable is given.",
+		RunE:  wrapper.Wrapper(Init),
+	}
+	wrapper.SetFlags(initCmd, flag.FlagSet{})
+
+	command := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the CLI config",
+		// the config is written before there is a controller to talk
+		// to, so skip setting up the client in the root command
+		PersistentPreRunE: wrapper.Wrapper(func(input cli.Input) error {
+			console.Verbosity = input.Int(flagkey.Verbosity)
+			return nil
+		}),
+	}
+
+	command.AddCommand(initCmd)
+
+	return command
+}
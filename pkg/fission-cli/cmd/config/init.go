@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type InitSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Init asks for the settings of the CLI config and saves them. The
+// values of an existing config are offered as defaults.
+func Init(input cli.Input) error {
+	return (&InitSubCommand{}).do(input)
+}
+
+func (opts *InitSubCommand) do(input cli.Input) error {
+	path, err := util.ConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := util.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	err = promptConfig(os.Stdin, os.Stdout, config)
+	if err != nil {
+		return err
+	}
+
+	err = util.SaveConfig(config)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Config saved to %v\n", path)
+	return nil
+}
+
+// promptConfig asks for each setting in turn. An empty answer keeps
+// the current value and "-" clears it. Secret settings are masked and,
+// when reading from a terminal, typed without echo.
+func promptConfig(in io.Reader, out io.Writer, config *util.Config) error {
+	reader := bufio.NewReader(in)
+	settings := []struct {
+		question string
+		value    *string
+		secret   bool
+	}{
+		{"Fission controller URL, empty to port-forward to the controller", &config.Server, false},
+		{"Fission router URL", &config.RouterURL, false},
+		{"Default namespace", &config.Namespace, false},
+		{"Auth token for invoking functions", &config.AuthToken, true},
+		{"Prometheus URL", &config.PrometheusURL, false},
+	}
+
+	for _, s := range settings {
+		current := *s.value
+		if s.secret {
+			current = maskSecret(current)
+		}
+		if len(current) > 0 {
+			fmt.Fprintf(out, "%v [%v]: ", s.question, current)
+		} else {
+			fmt.Fprintf(out, "%v: ", s.question)
+		}
+		var answer string
+		var err error
+		if f, ok := in.(*os.File); ok && s.secret && term.IsTerminal(int(f.Fd())) {
+			var b []byte
+			b, err = term.ReadPassword(int(f.Fd()))
+			fmt.Fprintln(out)
+			if err != nil {
+				return errors.Wrap(err, "error reading answer")
+			}
+			answer = string(b)
+		} else {
+			answer, err = reader.ReadString('\n')
+		}
+		if err == io.EOF && len(answer) == 0 {
+			// keep the remaining settings as they are
+			fmt.Fprintln(out)
+			break
+		} else if err != nil && err != io.EOF {
+			return errors.Wrap(err, "error reading answer")
+		}
+		answer = strings.TrimSpace(answer)
+		switch answer {
+		case "":
+		case "-":
+			*s.value = ""
+		default:
+			*s.value = answer
+		}
+	}
+	config.RouterURL = strings.TrimSuffix(config.RouterURL, "/")
+	config.PrometheusURL = strings.TrimSuffix(config.PrometheusURL, "/")
+	return nil
+}
+
+// maskSecret hides all but the last four characters of a secret, and
+// all of it when it is too short for that to be safe.
+func maskSecret(secret string) string {
+	if len(secret) == 0 {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
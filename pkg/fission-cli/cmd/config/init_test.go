@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+func TestPromptConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		config   util.Config
+		expected util.Config
+	}{
+		{
+			name:     "new config",
+			input:    "\nhttp://router.example.com/\ndev\ntoken\nhttp://prometheus:9090/\n",
+			expected: util.Config{RouterURL: "http://router.example.com", Namespace: "dev", AuthToken: "token", PrometheusURL: "http://prometheus:9090"},
+		},
+		{
+			name:     "keep and clear",
+			input:    "\n\n-\n",
+			config:   util.Config{Server: "http://controller", RouterURL: "http://router", Namespace: "dev"},
+			expected: util.Config{Server: "http://controller", RouterURL: "http://router"},
+		},
+		{
+			name:     "input ends early",
+			input:    "http://controller",
+			config:   util.Config{Namespace: "dev"},
+			expected: util.Config{Server: "http://controller", Namespace: "dev"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config
+			err := promptConfig(strings.NewReader(test.input), io.Discard, &config)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, config)
+		})
+	}
+}
+
+func TestPromptConfigMasksAuthToken(t *testing.T) {
+	config := util.Config{AuthToken: "eyJhbGciOiJIUzI1NiJ9.secret"}
+	var out strings.Builder
+	err := promptConfig(strings.NewReader("\n\n\n\n\n"), &out, &config)
+	require.NoError(t, err)
+	require.NotContains(t, out.String(), config.AuthToken)
+	require.Contains(t, out.String(), "[****cret]")
+	require.Equal(t, "eyJhbGciOiJIUzI1NiJ9.secret", config.AuthToken)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+// Commands returns configmap commands
+func Commands() *cobra.Command {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a configmap for functions",
+		Long: `Create a Kubernetes configmap that functions can reference with 'fission fn create/update --configmap <name>'.
+
+The configmap is mounted into the function pod as a volume, not as
+environment variables: each key is a file at
+/configs/<namespace>/<configmap name>/<key>, e.g. a function in the default
+namespace reads the key 'log-level' of the configmap 'app-config' from
+/configs/default/app-config/log-level.`,
+		RunE: wrapper.Wrapper(Create),
+	}
+	wrapper.SetFlags(createCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.CfgMapName},
+		Optional: []flag.Flag{flag.CfgMapFromLiteral, flag.CfgMapFromFile, flag.NamespaceFunction},
+	})
+
+	command := &cobra.Command{
+		Use:     "configmap",
+		Aliases: []string{"cm"},
+		Short:   "Manage configmaps used by functions",
+	}
+
+	command.AddCommand(createCmd)
+
+	return command
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	// builder pods of environments in the default namespace run in the
+	// builder namespace of the fission install
+	ENV_BUILDER_NAMESPACE     = "FISSION_BUILDER_NAMESPACE"
+	DEFAULT_BUILDER_NAMESPACE = "fission-builder"
+
+	builderContainerName = "builder"
+)
+
+type BuilderLogsSubCommand struct {
+	cmd.CommandActioner
+}
+
+// BuilderLogs prints the logs of the builder pod of an environment.
+func BuilderLogs(input cli.Input) error {
+	return (&BuilderLogsSubCommand{}).do(input)
+}
+
+func (opts *BuilderLogsSubCommand) do(input cli.Input) error {
+	env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.EnvName),
+		Namespace: input.String(flagkey.NamespaceEnvironment),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting environment")
+	}
+	if len(env.Spec.Builder.Image) == 0 {
+		return errors.Errorf("environment '%v' has no builder", env.ObjectMeta.Name)
+	}
+
+	builderNs := env.ObjectMeta.Namespace
+	if builderNs == metav1.NamespaceDefault {
+		builderNs = os.Getenv(ENV_BUILDER_NAMESPACE)
+		if len(builderNs) == 0 {
+			builderNs = DEFAULT_BUILDER_NAMESPACE
+		}
+	}
+
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	// the same labels the builder manager puts on the builder deployment
+	selector := labels.Set{
+		"envName":            env.ObjectMeta.Name,
+		"envNamespace":       builderNs,
+		"envResourceVersion": env.ObjectMeta.ResourceVersion,
+		"owner":              "buildermgr",
+	}.AsSelector().String()
+
+	ctx := context.Background()
+	pods, err := kubeClient.CoreV1().Pods(builderNs).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrap(err, "error listing builder pods")
+	}
+
+	var pod *apiv1.Pod
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.ObjectMeta.DeletionTimestamp != nil {
+			continue
+		}
+		// prefer the most recently created pod
+		if pod == nil || p.ObjectMeta.CreationTimestamp.After(pod.ObjectMeta.CreationTimestamp.Time) {
+			pod = p
+		}
+	}
+	if pod == nil {
+		return errors.Errorf("no builder pod found for environment '%v' in namespace '%v'", env.ObjectMeta.Name, builderNs)
+	}
+	console.Verbose(2, "Builder pod %v/%v", pod.ObjectMeta.Namespace, pod.ObjectMeta.Name)
+
+	logOpts := &apiv1.PodLogOptions{
+		Container: builderContainerName,
+		Follow:    input.Bool(flagkey.EnvLogsFollow),
+	}
+	if tail := int64(input.Int(flagkey.EnvLogsTail)); tail > 0 {
+		logOpts.TailLines = &tail
+	}
+
+	stream, err := kubeClient.CoreV1().Pods(pod.ObjectMeta.Namespace).GetLogs(pod.ObjectMeta.Name, logOpts).Stream(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "error getting logs of builder pod '%v'", pod.ObjectMeta.Name)
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(os.Stdout, stream)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error reading logs of builder pod '%v'", pod.ObjectMeta.Name))
+	}
+	return nil
+}
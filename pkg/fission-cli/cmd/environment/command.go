@@ -33,9 +33,10 @@ func Commands() *cobra.Command {
 		Required: []flag.Flag{flag.EnvName, flag.EnvImage},
 		Optional: []flag.Flag{
 			flag.EnvPoolsize, flag.EnvBuilderImage, flag.EnvBuildCmd,
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
-			flag.EnvTerminationGracePeriod, flag.EnvVersion, flag.EnvImagePullSecret, flag.EnvKeepArchive,
-			flag.NamespaceEnvironment, flag.EnvExternalNetwork,
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
+			flag.EnvTerminationGracePeriod, flag.EnvVersion, flag.EnvImagePullSecret, flag.EnvKeepArchive, flag.EnvRetainArchive,
+			flag.EnvConcurrencyModels,
+			flag.NamespaceEnvironment, flag.EnvExternalNetwork, flag.EnvSkipImageCheck, flag.EnvExecutorType,
 			flag.Labels, flag.Annotation,
 			flag.SpecSave, flag.SpecDry},
 	})
@@ -59,10 +60,10 @@ func Commands() *cobra.Command {
 		Required: []flag.Flag{flag.EnvName},
 		Optional: []flag.Flag{flag.EnvImage, flag.EnvPoolsize,
 			flag.EnvBuilderImage, flag.EnvBuildCmd, flag.EnvImagePullSecret,
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
-			flag.EnvTerminationGracePeriod, flag.EnvKeepArchive,
-			flag.NamespaceEnvironment, flag.EnvExternalNetwork,
-			flag.Labels, flag.Annotation},
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
+			flag.EnvTerminationGracePeriod, flag.EnvKeepArchive, flag.EnvRetainArchive,
+			flag.EnvConcurrencyModels, flag.NamespaceEnvironment, flag.EnvExternalNetwork,
+			flag.EnvForce, flag.Labels, flag.Annotation},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -82,7 +83,48 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceEnvironment},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvListVerbose, flag.EnvListOutput},
+	})
+
+	builderLogsCmd := &cobra.Command{
+		Use:   "builder-logs",
+		Short: "Show the logs of the builder pod of an environment",
+		RunE:  wrapper.Wrapper(BuilderLogs),
+	}
+	wrapper.SetFlags(builderLogsCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.EnvName},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvLogsTail, flag.EnvLogsFollow},
+	})
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the health of the pool pods of an environment",
+		Long:  "Call the health check endpoint of each pool pod of an environment and report pass/fail per pod, to find broken environment images before deploying functions.",
+		RunE:  wrapper.Wrapper(Validate),
+	}
+	wrapper.SetFlags(validateCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.EnvName, flag.EnvValidateAll, flag.NamespaceEnvironment},
+	})
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete environments that no function uses",
+		Long:  "Delete the environments in a namespace that are not referenced by any function or package, so that their warm pools stop using resources. Asks for confirmation unless --yes is given.",
+		RunE:  wrapper.Wrapper(Prune),
+	}
+	wrapper.SetFlags(pruneCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvPruneYes},
+	})
+
+	imageUpdateCmd := &cobra.Command{
+		Use:   "image-update",
+		Short: "Update the image of an environment without shrinking its pool",
+		Long:  "Update the runtime image of an environment and wait for its pool to roll out. A full set of pool pods with the new image becomes ready before the old pods are removed, so the environment keeps warm pods during the update.",
+		RunE:  wrapper.Wrapper(ImageUpdate),
+	}
+	wrapper.SetFlags(imageUpdateCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.EnvName, flag.EnvImage},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvSkipImageCheck, flag.EnvImageUpdateTimeout},
 	})
 
 	listPodsCmd := &cobra.Command{
@@ -103,7 +145,8 @@ func Commands() *cobra.Command {
 		Short:   "Create, update and manage environments",
 	}
 
-	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd, listPodsCmd)
+	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd, listPodsCmd, builderLogsCmd, validateCmd, pruneCmd,
+		imageUpdateCmd)
 
 	return command
 }
@@ -45,6 +45,10 @@ func Create(input cli.Input) error {
 func (opts *CreateSubCommand) do(input cli.Input) error {
 	err := opts.complete(input)
 	if err != nil {
+		util.RecordValidationFailure(input, fv1.CRD_NAME_ENVIRONMENT, metav1.ObjectMeta{
+			Name:      input.String(flagkey.EnvName),
+			Namespace: input.String(flagkey.NamespaceEnvironment),
+		}, err)
 		return err
 	}
 	return opts.run(input)
@@ -89,6 +93,13 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 		return nil
 	}
 
+	if !input.Bool(flagkey.EnvSkipImageCheck) {
+		err = checkEnvironmentImages(opts.env)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = opts.Client().V1().Environment().Create(opts.env)
 	if err != nil {
 		return errors.Wrap(err, "error creating environment")
@@ -98,7 +109,42 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 	return nil
 }
 
+// checkEnvironmentImages makes sure the runtime and builder images exist
+// so that a wrong image name doesn't surface only when a function runs.
+func checkEnvironmentImages(env *fv1.Environment) error {
+	images := []string{env.Spec.Runtime.Image}
+	if len(env.Spec.Builder.Image) > 0 {
+		images = append(images, env.Spec.Builder.Image)
+	}
+
+	for _, image := range images {
+		console.Verbose(2, "Checking image %v", image)
+		err := checkImageExists(image)
+		if err == nil {
+			continue
+		}
+		if errors.Cause(err) == errImageUnauthorized && len(env.Spec.ImagePullSecret) > 0 {
+			// the registry credentials are only available inside the cluster
+			console.Warn(fmt.Sprintf("Unable to verify private image '%v' with image pull secret '%v'", image, env.Spec.ImagePullSecret))
+			continue
+		}
+		return errors.Wrapf(err, "image check failed, use --%v to skip the check", flagkey.EnvSkipImageCheck)
+	}
+
+	return nil
+}
+
 // createEnvironmentFromCmd creates environment initialized with CLI input.
+// concurrencyModels returns the concurrency models given with
+// --concurrency-models.
+func concurrencyModels(input cli.Input) []fv1.ConcurrencyModel {
+	var models []fv1.ConcurrencyModel
+	for _, m := range input.StringSlice(flagkey.EnvConcurrencyModels) {
+		models = append(models, fv1.ConcurrencyModel(m))
+	}
+	return models
+}
+
 func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 	e := utils.MultiErrorWithFormat()
 
@@ -108,6 +154,7 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 	envBuildCmd := input.String(flagkey.EnvBuildcommand)
 	envExternalNetwork := input.Bool(flagkey.EnvExternalNetwork)
 	keepArchive := input.Bool(flagkey.EnvKeeparchive)
+	retainArchive := input.Bool(flagkey.EnvRetainArchive)
 	envGracePeriod := input.Int64(flagkey.EnvGracePeriod)
 	pullSecret := input.String(flagkey.EnvImagePullSecret)
 
@@ -128,8 +175,17 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 	}
 
 	poolsize := input.Int(flagkey.EnvPoolsize)
-	if poolsize < 1 {
-		console.Warn("poolsize is not positive, if you are using pool manager please set positive value")
+	executorType := fv1.ExecutorType(input.String(flagkey.EnvExecutorType))
+	switch executorType {
+	case "", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer:
+	default:
+		e = multierror.Append(e, errors.Errorf("executor type must be one of '%v', '%v' or '%v'",
+			fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer))
+	}
+	// the pool is only used by poolmgr functions, an environment meant
+	// for newdeploy functions doesn't need one
+	if poolsize < 1 && (len(executorType) == 0 || executorType == fv1.ExecutorTypePoolmgr) {
+		console.Warn(fmt.Sprintf("Pool size %v means every invocation will cold-start; consider `--%v 1` or higher.", poolsize, flagkey.EnvPoolsize))
 	}
 
 	envBuilderImg := input.String(flagkey.EnvBuilderImage)
@@ -141,6 +197,7 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 		if len(envBuildCmd) == 0 {
 			envBuildCmd = "build"
 		}
+		warnLatestTag(envBuilderImg)
 	}
 
 	resourceReq, err := util.GetResourceReqs(input, nil)
@@ -176,6 +233,8 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 			TerminationGracePeriod:       envGracePeriod,
 			KeepArchive:                  keepArchive,
 			ImagePullSecret:              pullSecret,
+			RetainArchive:                retainArchive,
+			SupportedConcurrencyModels:   concurrencyModels(input),
 		},
 	}
 
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/fission-cli/console"
+)
+
+const (
+	defaultRegistry   = "registry-1.docker.io"
+	imageCheckTimeout = 30 * time.Second
+)
+
+var errImageUnauthorized = errors.New("unauthorized to access image manifest")
+
+type imageReference struct {
+	registry   string
+	repository string
+	reference  string // tag or digest
+}
+
+// parseImageReference parses a docker image name into registry,
+// repository and tag/digest, applying the same defaults as docker:
+// Docker Hub as registry, "library/" for official images and the
+// "latest" tag.
+func parseImageReference(image string) (*imageReference, error) {
+	if len(image) == 0 {
+		return nil, errors.New("empty image name")
+	}
+
+	ref := &imageReference{registry: defaultRegistry}
+	name := image
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.registry = parts[0]
+		name = parts[1]
+	}
+	if ref.registry == "docker.io" || ref.registry == "index.docker.io" {
+		ref.registry = defaultRegistry
+	}
+
+	if idx := strings.Index(name, "@"); idx >= 0 {
+		ref.reference = name[idx+1:]
+		name = name[:idx]
+	} else if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		ref.reference = name[idx+1:]
+		name = name[:idx]
+	} else {
+		ref.reference = "latest"
+	}
+
+	if len(name) == 0 || len(ref.reference) == 0 {
+		return nil, errors.Errorf("invalid image name '%v'", image)
+	}
+
+	if ref.registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.repository = name
+
+	return ref, nil
+}
+
+// warnLatestTag warns about builder images with the "latest" tag,
+// explicit or implied, as builds then depend on when the image is pulled.
+func warnLatestTag(image string) {
+	ref, err := parseImageReference(image)
+	if err != nil || ref.reference != "latest" {
+		return
+	}
+	console.Warn(fmt.Sprintf("Builder image '%v' uses the 'latest' tag, pin a version to get reproducible builds", image))
+}
+
+// checkImageExists queries the registry HTTP API v2 for the image
+// manifest. Anonymous bearer tokens are requested when the registry
+// asks for them.
+func checkImageExists(image string) error {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageCheckTimeout)
+	defer cancel()
+
+	manifestURL := fmt.Sprintf("https://%v/v2/%v/manifests/%v", ref.registry, ref.repository, ref.reference)
+
+	resp, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return errors.Wrapf(err, "error checking image '%v'", image)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := getRegistryToken(ctx, resp.Header.Get("Www-Authenticate"))
+		if err != nil {
+			return errors.Wrapf(err, "error getting registry token for image '%v'", image)
+		}
+		resp, err = headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return errors.Wrapf(err, "error checking image '%v'", image)
+		}
+		resp.Body.Close()
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return errors.Errorf("image '%v' not found in registry %v", image, ref.registry)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.Wrapf(errImageUnauthorized, "image '%v'", image)
+	default:
+		return errors.Errorf("unexpected status code %v from registry %v when checking image '%v'",
+			resp.StatusCode, ref.registry, image)
+	}
+}
+
+func headManifest(ctx context.Context, manifestURL string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/vnd.docker.distribution.manifest.v2+json")
+	req.Header.Add("Accept", "application/vnd.docker.distribution.manifest.list.v2+json")
+	req.Header.Add("Accept", "application/vnd.oci.image.manifest.v1+json")
+	req.Header.Add("Accept", "application/vnd.oci.image.index.v1+json")
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// getRegistryToken requests an anonymous token from the realm given in
+// a challenge like:
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/node:pull"
+func getRegistryToken(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", errImageUnauthorized
+	}
+
+	params := make(map[string]string)
+	for _, kv := range strings.Split(challenge[len("bearer "):], ",") {
+		parts := strings.SplitN(strings.TrimSpace(kv), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		params[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+
+	realm, ok := params["realm"]
+	if !ok {
+		return "", errors.Errorf("no realm in registry challenge '%v'", challenge)
+	}
+	u, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid realm '%v'", realm)
+	}
+	q := u.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v, ok := params[k]; ok {
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errImageUnauthorized
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&tokenResp)
+	if err != nil {
+		return "", errors.Wrap(err, "error decoding registry token")
+	}
+	if len(tokenResp.Token) > 0 {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseImageReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		want    *imageReference
+		wantErr bool
+	}{
+		{
+			name:  "official-image-without-tag",
+			image: "node",
+			want:  &imageReference{registry: defaultRegistry, repository: "library/node", reference: "latest"},
+		},
+		{
+			name:  "docker-hub-image-with-tag",
+			image: "fission/node-env:1.31.1",
+			want:  &imageReference{registry: defaultRegistry, repository: "fission/node-env", reference: "1.31.1"},
+		},
+		{
+			name:  "private-registry-with-port",
+			image: "localhost:5000/env/python:dev",
+			want:  &imageReference{registry: "localhost:5000", repository: "env/python", reference: "dev"},
+		},
+		{
+			name:  "digest",
+			image: "ghcr.io/fission/go-env@sha256:abcdef",
+			want:  &imageReference{registry: "ghcr.io", repository: "fission/go-env", reference: "sha256:abcdef"},
+		},
+		{
+			name:    "empty-tag",
+			image:   "fission/node-env:",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImageReference(tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseImageReference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImageReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const rolloutPollInterval = time.Second
+
+type ImageUpdateSubCommand struct {
+	cmd.CommandActioner
+	env     *fv1.Environment
+	image   string
+	timeout time.Duration
+}
+
+// ImageUpdate changes the runtime image of an environment and waits for
+// the pool deployment to roll out. The executor brings up a full set of
+// pool pods with the new image before it removes the old ones.
+func ImageUpdate(input cli.Input) error {
+	return (&ImageUpdateSubCommand{}).do(input)
+}
+
+func (opts *ImageUpdateSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ImageUpdateSubCommand) complete(input cli.Input) error {
+	opts.timeout = input.Duration(flagkey.EnvImageUpdateTimeout)
+	if opts.timeout <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.EnvImageUpdateTimeout)
+	}
+
+	env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.EnvName),
+		Namespace: input.String(flagkey.NamespaceEnvironment),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error finding environment")
+	}
+	// an image in the container spec takes precedence over the runtime
+	// image, so changing the latter would have no effect
+	if env.Spec.Runtime.Container != nil && len(env.Spec.Runtime.Container.Image) > 0 {
+		return errors.Errorf("environment '%v' sets the image in its runtime container spec, update the spec instead", env.ObjectMeta.Name)
+	}
+
+	opts.image = input.String(flagkey.EnvImage)
+	if len(opts.image) == 0 {
+		return errors.Errorf("--%v must not be empty", flagkey.EnvImage)
+	}
+	env.Spec.Runtime.Image = opts.image
+	opts.env = env
+
+	if !input.Bool(flagkey.EnvSkipImageCheck) {
+		err = checkEnvironmentImages(opts.env)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (opts *ImageUpdateSubCommand) run(input cli.Input) error {
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	env, err := opts.Client().V1().Environment().Update(opts.env)
+	if err != nil {
+		return errors.Wrap(err, "error updating environment")
+	}
+	fmt.Printf("environment '%v' updated to image %v\n", env.ObjectMeta.Name, opts.image)
+
+	if env.Spec.Version >= 3 && env.Spec.Poolsize == 0 {
+		fmt.Printf("environment '%v' has no pool, nothing to roll out\n", env.ObjectMeta.Name)
+		return nil
+	}
+
+	deadline := time.Now().Add(opts.timeout)
+	lastStatus := ""
+	for {
+		status := fmt.Sprintf("Waiting for the pool deployment of environment '%v' to be created...", env.ObjectMeta.Name)
+		done := false
+
+		depl, err := getPoolDeployment(kubeClient, env)
+		if err != nil {
+			return err
+		}
+		if depl != nil {
+			status, done = rolloutStatus(depl, env.ObjectMeta.Name, opts.image)
+		}
+		if status != lastStatus {
+			fmt.Println(status)
+			lastStatus = status
+		}
+		if done {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %v waiting for the pool of environment '%v' to roll out", opts.timeout, env.ObjectMeta.Name)
+		}
+		time.Sleep(rolloutPollInterval)
+	}
+}
+
+// getPoolDeployment returns the deployment of the pool of the
+// environment, or nil if the executor hasn't created it. Pools of
+// environments in the default namespace run in the function namespace,
+// so the deployment is looked up across namespaces.
+func getPoolDeployment(kubeClient kubernetes.Interface, env *fv1.Environment) (*appsv1.Deployment, error) {
+	selector := labels.SelectorFromSet(map[string]string{
+		fv1.EXECUTOR_TYPE:         string(fv1.ExecutorTypePoolmgr),
+		fv1.ENVIRONMENT_NAME:      env.ObjectMeta.Name,
+		fv1.ENVIRONMENT_NAMESPACE: env.ObjectMeta.Namespace,
+		fv1.ENVIRONMENT_UID:       string(env.ObjectMeta.UID),
+	})
+	deployments, err := kubeClient.AppsV1().Deployments(metav1.NamespaceAll).List(context.Background(),
+		metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting pool deployment of environment '%v'", env.ObjectMeta.Name)
+	}
+	if len(deployments.Items) == 0 {
+		return nil, nil
+	}
+	return &deployments.Items[0], nil
+}
+
+// rolloutStatus describes the progress of the rollout of the pool
+// deployment to the given image, in the same terms as
+// `kubectl rollout status`, and reports whether it's done.
+func rolloutStatus(depl *appsv1.Deployment, container, image string) (string, bool) {
+	updated := false
+	for _, c := range depl.Spec.Template.Spec.Containers {
+		if c.Name == container && c.Image == image {
+			updated = true
+		}
+	}
+	if !updated {
+		return fmt.Sprintf("Waiting for the executor to update deployment %q...", depl.ObjectMeta.Name), false
+	}
+
+	if depl.ObjectMeta.Generation > depl.Status.ObservedGeneration {
+		return fmt.Sprintf("Waiting for deployment %q spec update to be observed...", depl.ObjectMeta.Name), false
+	}
+
+	replicas := int32(1)
+	if depl.Spec.Replicas != nil {
+		replicas = *depl.Spec.Replicas
+	}
+	switch {
+	case depl.Status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %v out of %v new replicas have been updated...",
+			depl.ObjectMeta.Name, depl.Status.UpdatedReplicas, replicas), false
+	case depl.Status.Replicas > depl.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %v old replicas are pending termination...",
+			depl.ObjectMeta.Name, depl.Status.Replicas-depl.Status.UpdatedReplicas), false
+	case depl.Status.AvailableReplicas < depl.Status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %v of %v updated replicas are available...",
+			depl.ObjectMeta.Name, depl.Status.AvailableReplicas, depl.Status.UpdatedReplicas), false
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", depl.ObjectMeta.Name), true
+}
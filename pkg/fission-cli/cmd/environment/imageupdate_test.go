@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	deployment := func(image string, generation, observed int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		status.ObservedGeneration = observed
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "poolmgr-nodejs", Generation: generation},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: apiv1.PodTemplateSpec{Spec: apiv1.PodSpec{
+					Containers: []apiv1.Container{{Name: "nodejs", Image: image}},
+				}},
+			},
+			Status: status,
+		}
+	}
+
+	cases := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		expected   string
+		done       bool
+	}{
+		{
+			name:       "old image",
+			deployment: deployment("node:16", 1, 1, appsv1.DeploymentStatus{}),
+			expected:   `Waiting for the executor to update deployment "poolmgr-nodejs"...`,
+		},
+		{
+			name:       "not observed",
+			deployment: deployment("node:18", 2, 1, appsv1.DeploymentStatus{}),
+			expected:   `Waiting for deployment "poolmgr-nodejs" spec update to be observed...`,
+		},
+		{
+			name:       "updating",
+			deployment: deployment("node:18", 2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1}),
+			expected:   `Waiting for deployment "poolmgr-nodejs" rollout to finish: 1 out of 3 new replicas have been updated...`,
+		},
+		{
+			name:       "terminating",
+			deployment: deployment("node:18", 2, 2, appsv1.DeploymentStatus{Replicas: 5, UpdatedReplicas: 3, AvailableReplicas: 5}),
+			expected:   `Waiting for deployment "poolmgr-nodejs" rollout to finish: 2 old replicas are pending termination...`,
+		},
+		{
+			name:       "unavailable",
+			deployment: deployment("node:18", 2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			expected:   `Waiting for deployment "poolmgr-nodejs" rollout to finish: 2 of 3 updated replicas are available...`,
+		},
+		{
+			name:       "done",
+			deployment: deployment("node:18", 2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			expected:   `deployment "poolmgr-nodejs" successfully rolled out`,
+			done:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status, done := rolloutStatus(c.deployment, "nodejs", "node:18")
+			assert.Equal(t, c.expected, status)
+			assert.Equal(t, c.done, done)
+		})
+	}
+}
@@ -22,10 +22,14 @@ import (
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -37,21 +41,67 @@ func List(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) do(input cli.Input) error {
+	formatter, err := util.NewOutputFormatter(input.String(flagkey.EnvListOutput))
+	if err != nil {
+		return err
+	}
+
 	envs, err := opts.Client().V1().Environment().List(input.String(flagkey.NamespaceEnvironment))
 	if err != nil {
 		return errors.Wrap(err, "error listing environments")
 	}
 
+	if formatter.IsStructured() {
+		return formatter.Print(envs)
+	}
+
+	verbose := input.Bool(flagkey.EnvListVerbose) || formatter.IsWide()
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "IMAGE", "BUILDER_IMAGE", "POOLSIZE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "EXTNET", "GRACETIME")
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v", "NAME", "IMAGE", "BUILDER_IMAGE", "POOLSIZE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "EXTNET", "GRACETIME")
+	if verbose {
+		fmt.Fprintf(w, "\t%v\t%v\t%v", "RUNNING", "PENDING", "FAILED")
+	}
+	fmt.Fprintln(w)
 	for _, env := range envs {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v",
 			env.ObjectMeta.Name, env.Spec.Runtime.Image, env.Spec.Builder.Image, env.Spec.Poolsize,
 			env.Spec.Resources.Requests.Cpu(), env.Spec.Resources.Limits.Cpu(),
 			env.Spec.Resources.Requests.Memory(), env.Spec.Resources.Limits.Memory(),
 			env.Spec.AllowAccessToExternalNetwork, env.Spec.TerminationGracePeriod)
+		if verbose {
+			counts, err := opts.countPods(&env)
+			if err != nil {
+				return errors.Wrapf(err, "error listing pods of environment '%v'", env.ObjectMeta.Name)
+			}
+			fmt.Fprintf(w, "\t%v\t%v\t%v", counts[apiv1.PodRunning], counts[apiv1.PodPending], counts[apiv1.PodFailed])
+		}
+		fmt.Fprintln(w)
 	}
 	w.Flush()
 
 	return nil
 }
+
+// countPods returns the number of pods of the environment in each phase.
+func (opts *ListSubCommand) countPods(env *fv1.Environment) (map[apiv1.PodPhase]int, error) {
+	pods, err := opts.Client().V1().Environment().ListPods(&metav1.ObjectMeta{
+		Name: env.ObjectMeta.Name,
+		Labels: map[string]string{
+			fv1.ENVIRONMENT_NAMESPACE: env.ObjectMeta.Namespace,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[apiv1.PodPhase]int)
+	for _, pod := range pods {
+		// A deletion timestamp indicates that a pod is terminating. Do not count this pod.
+		if pod.ObjectMeta.DeletionTimestamp != nil {
+			continue
+		}
+		counts[pod.Status.Phase]++
+	}
+	return counts, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type PruneSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Prune deletes the environments no function or package refers to, so
+// that their warm pools stop using resources.
+func Prune(input cli.Input) error {
+	return (&PruneSubCommand{}).do(input)
+}
+
+func (opts *PruneSubCommand) do(input cli.Input) error {
+	envs, err := opts.Client().V1().Environment().List(input.String(flagkey.NamespaceEnvironment))
+	if err != nil {
+		return errors.Wrap(err, "error listing environments")
+	}
+	// functions may use environments of other namespaces
+	fns, err := opts.Client().V1().Function().List(metav1.NamespaceAll)
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
+	}
+	// packages still need their environment to be rebuilt
+	pkgs, err := opts.Client().V1().Package().List(metav1.NamespaceAll)
+	if err != nil {
+		return errors.Wrap(err, "error listing packages")
+	}
+
+	unused := findUnusedEnvironments(envs, fns, pkgs)
+	if len(unused) == 0 {
+		fmt.Println("No unused environments found")
+		return nil
+	}
+
+	if !input.Bool(flagkey.EnvPruneYes) {
+		fmt.Println("The following environments are not used by any function or package and will be deleted:")
+		for _, env := range unused {
+			fmt.Printf("  %v/%v\n", env.ObjectMeta.Namespace, env.ObjectMeta.Name)
+		}
+		if !util.Confirm(os.Stdin, fmt.Sprintf("Delete %v environments?", len(unused))) {
+			fmt.Println("Skipped pruning.")
+			return nil
+		}
+	}
+
+	for _, env := range unused {
+		err = opts.Client().V1().Environment().Delete(&env.ObjectMeta)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting environment '%v'", env.ObjectMeta.Name)
+		}
+		fmt.Printf("environment '%v' deleted\n", env.ObjectMeta.Name)
+	}
+
+	return nil
+}
+
+// findUnusedEnvironments returns the environments which are not
+// referenced by any of the functions or packages.
+func findUnusedEnvironments(envs []fv1.Environment, fns []fv1.Function, pkgs []fv1.Package) []fv1.Environment {
+	used := make(map[string]bool)
+	markUsed := func(ref fv1.EnvironmentReference, namespace string) {
+		if len(ref.Namespace) > 0 {
+			namespace = ref.Namespace
+		}
+		used[namespace+"/"+ref.Name] = true
+	}
+	for _, fn := range fns {
+		markUsed(fn.Spec.Environment, fn.ObjectMeta.Namespace)
+	}
+	for _, pkg := range pkgs {
+		markUsed(pkg.Spec.Environment, pkg.ObjectMeta.Namespace)
+	}
+
+	var unused []fv1.Environment
+	for _, env := range envs {
+		if !used[env.ObjectMeta.Namespace+"/"+env.ObjectMeta.Name] {
+			unused = append(unused, env)
+		}
+	}
+	return unused
+}
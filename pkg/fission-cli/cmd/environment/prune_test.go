@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestFindUnusedEnvironments(t *testing.T) {
+	env := func(namespace, name string) fv1.Environment {
+		return fv1.Environment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	envs := []fv1.Environment{env("default", "nodejs"), env("default", "python"), env("dev", "go"), env("dev", "java")}
+	fns := []fv1.Function{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hello"},
+		Spec:       fv1.FunctionSpec{Environment: fv1.EnvironmentReference{Name: "nodejs"}},
+	}}
+	pkgs := []fv1.Package{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hello-pkg"},
+			Spec:       fv1.PackageSpec{Environment: fv1.EnvironmentReference{Namespace: "dev", Name: "go"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "orphan-pkg"},
+			Spec:       fv1.PackageSpec{Environment: fv1.EnvironmentReference{Name: "python"}},
+		},
+	}
+
+	unused := findUnusedEnvironments(envs, fns, pkgs)
+	if len(unused) != 1 || unused[0].ObjectMeta.Name != "java" {
+		t.Fatalf("expected only environment 'java' to be unused, got %v", unused)
+	}
+}
@@ -19,10 +19,12 @@ package environment
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -60,6 +62,7 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	if err != nil {
 		return errors.Wrap(err, "error finding environment")
 	}
+	oldSpec := env.Spec.DeepCopy()
 
 	env, err = updateExistingEnvironmentWithCmd(env, input)
 	if err != nil {
@@ -72,9 +75,66 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	if err != nil {
 		return err
 	}
+
+	if !input.Bool(flagkey.EnvForce) && needsRollout(oldSpec, &opts.env.Spec) {
+		fns, err := opts.getRunningFunctions()
+		if err != nil {
+			return errors.Wrap(err, "error checking running functions of environment")
+		}
+		if len(fns) > 0 {
+			return errors.Errorf("environment '%v' is used by running functions %v, use --%v to force update",
+				env.ObjectMeta.Name, strings.Join(fns, ", "), flagkey.EnvForce)
+		}
+	}
+
 	return nil
 }
 
+// needsRollout returns true if the spec change replaces the pods of the
+// functions using the environment. Builder, pool size and archive
+// retention changes leave the running function pods alone.
+func needsRollout(oldSpec, newSpec *fv1.EnvironmentSpec) bool {
+	runtimeSpec := func(spec *fv1.EnvironmentSpec) *fv1.EnvironmentSpec {
+		s := spec.DeepCopy()
+		s.Builder = fv1.Builder{}
+		s.Poolsize = 0
+		s.RetainArchive = false
+		s.SupportedConcurrencyModels = nil
+		return s
+	}
+	return !apiequality.Semantic.DeepEqual(runtimeSpec(oldSpec), runtimeSpec(newSpec))
+}
+
+// getRunningFunctions returns the names of the functions using the
+// environment which have at least one running pod.
+func (opts *UpdateSubCommand) getRunningFunctions() ([]string, error) {
+	pods, err := opts.Client().V1().Environment().ListPods(&metav1.ObjectMeta{
+		Name: opts.env.ObjectMeta.Name,
+		Labels: map[string]string{
+			fv1.ENVIRONMENT_NAMESPACE: opts.env.ObjectMeta.Namespace,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var running []string
+	seen := make(map[string]bool)
+	for _, pod := range pods {
+		// pool pods waiting for specialization have no function yet
+		fn := pod.ObjectMeta.Labels[fv1.FUNCTION_NAME]
+		if len(fn) == 0 || seen[fn] {
+			continue
+		}
+		// A deletion timestamp indicates that a pod is terminating. Do not count this pod.
+		if pod.ObjectMeta.DeletionTimestamp == nil && pod.Status.Phase == v1.PodRunning {
+			seen[fn] = true
+			running = append(running, fn)
+		}
+	}
+	return running, nil
+}
+
 func (opts *UpdateSubCommand) run(input cli.Input) error {
 	_, err := opts.Client().V1().Environment().Update(opts.env)
 	if err != nil {
@@ -95,6 +155,7 @@ func updateExistingEnvironmentWithCmd(env *fv1.Environment, input cli.Input) (*f
 
 	if input.IsSet(flagkey.EnvBuilderImage) {
 		env.Spec.Builder.Image = input.String(flagkey.EnvBuilderImage)
+		warnLatestTag(env.Spec.Builder.Image)
 	}
 
 	if input.IsSet(flagkey.EnvBuildcommand) {
@@ -123,10 +184,25 @@ func updateExistingEnvironmentWithCmd(env *fv1.Environment, input cli.Input) (*f
 		env.Spec.KeepArchive = input.Bool(flagkey.EnvKeeparchive)
 	}
 
+	if input.IsSet(flagkey.EnvRetainArchive) {
+		env.Spec.RetainArchive = input.Bool(flagkey.EnvRetainArchive)
+	}
+
+	if input.IsSet(flagkey.EnvConcurrencyModels) {
+		env.Spec.SupportedConcurrencyModels = concurrencyModels(input)
+	}
+
 	if input.IsSet(flagkey.EnvImagePullSecret) {
 		env.Spec.ImagePullSecret = input.String(flagkey.EnvImagePullSecret)
 	}
 
+	if env.Spec.Resources.Requests == nil {
+		env.Spec.Resources.Requests = v1.ResourceList{}
+	}
+	if env.Spec.Resources.Limits == nil {
+		env.Spec.Resources.Limits = v1.ResourceList{}
+	}
+
 	if input.IsSet(flagkey.RuntimeMincpu) {
 		mincpu := input.Int(flagkey.RuntimeMincpu)
 		cpuRequest, err := resource.ParseQuantity(strconv.Itoa(mincpu) + "m")
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	// the fetcher sidecar serves the health check of pool pods
+	healthCheckPort    = "8000"
+	healthCheckPath    = "/healthz"
+	healthCheckTimeout = 5 * time.Second
+)
+
+type ValidateSubCommand struct {
+	cmd.CommandActioner
+	envs []fv1.Environment
+}
+
+// Validate calls the health check endpoint of the pool pods of one or
+// all environments and reports the result per pod.
+func Validate(input cli.Input) error {
+	return (&ValidateSubCommand{}).do(input)
+}
+
+func (opts *ValidateSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ValidateSubCommand) complete(input cli.Input) error {
+	namespace := input.String(flagkey.NamespaceEnvironment)
+
+	if input.Bool(flagkey.EnvValidateAll) {
+		envs, err := opts.Client().V1().Environment().List(namespace)
+		if err != nil {
+			return errors.Wrap(err, "error listing environments")
+		}
+		opts.envs = envs
+		return nil
+	}
+
+	if !input.IsSet(flagkey.EnvName) {
+		return errors.Errorf("need --%v or --%v", flagkey.EnvName, flagkey.EnvValidateAll)
+	}
+	env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.EnvName),
+		Namespace: namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting environment")
+	}
+	opts.envs = []fv1.Environment{*env}
+
+	return nil
+}
+
+func (opts *ValidateSubCommand) run(input cli.Input) error {
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "ENVIRONMENT", "POD", "RESULT", "TIME", "ERROR")
+	for _, env := range opts.envs {
+		pods, err := opts.Client().V1().Environment().ListPods(&metav1.ObjectMeta{
+			Name: env.ObjectMeta.Name,
+			Labels: map[string]string{
+				fv1.ENVIRONMENT_NAMESPACE: env.ObjectMeta.Namespace,
+				fv1.EXECUTOR_TYPE:         string(fv1.ExecutorTypePoolmgr),
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error listing pods of environment '%v'", env.ObjectMeta.Name)
+		}
+
+		checked := 0
+		for _, pod := range pods {
+			if pod.ObjectMeta.DeletionTimestamp != nil {
+				continue
+			}
+			checked++
+			latency, err := checkPodHealth(kubeClient, pod.ObjectMeta.Namespace, pod.ObjectMeta.Name)
+			if err != nil {
+				failed++
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", env.ObjectMeta.Name, pod.ObjectMeta.Name, "FAIL", latency.Round(time.Millisecond), err)
+				continue
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", env.ObjectMeta.Name, pod.ObjectMeta.Name, "PASS", latency.Round(time.Millisecond), "")
+		}
+		if checked == 0 {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", env.ObjectMeta.Name, "-", "SKIP", "-", "no pool pods running")
+		}
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return errors.Errorf("%v pod(s) failed the health check", failed)
+	}
+	return nil
+}
+
+// checkPodHealth calls the health check of a pod through the API
+// server proxy, so the pod doesn't have to be reachable from the CLI.
+func checkPodHealth(kubeClient kubernetes.Interface, namespace, name string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := kubeClient.CoreV1().Pods(namespace).ProxyGet("http", name, healthCheckPort, healthCheckPath, nil).DoRaw(ctx)
+	return time.Since(start), err
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	// the router serves the access log on its metrics port
+	routerAccessLogPort = "8080"
+	routerAccessLogPath = "/access-log"
+
+	accessLogFormatText = "text"
+	accessLogFormatJSON = "json"
+)
+
+type AccessLogSubCommand struct {
+	cmd.CommandActioner
+}
+
+type accessLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	LatencyMs float64   `json:"latencyMs"`
+	Bytes     int64     `json:"bytes"`
+}
+
+// AccessLog streams the access log entries of a function from all
+// running router pods.
+func AccessLog(input cli.Input) error {
+	return (&AccessLogSubCommand{}).do(input)
+}
+
+func (opts *AccessLogSubCommand) do(input cli.Input) error {
+	format := input.String(flagkey.FnAccessLogFormat)
+	if format != accessLogFormatText && format != accessLogFormatJSON {
+		return errors.Errorf("unsupported format '%v', must be one of: %v, %v", format, accessLogFormatText, accessLogFormatJSON)
+	}
+
+	fnMeta := &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+	_, err := opts.Client().V1().Function().Get(fnMeta)
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", fnMeta.Name)
+	}
+
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	namespace := util.GetFissionNamespace()
+	if len(namespace) == 0 {
+		namespace = metav1.NamespaceAll
+	}
+	pods, err := kubeClient.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: "application=fission-router",
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing router pods")
+	}
+
+	lines := make(chan []byte)
+	var wg sync.WaitGroup
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != apiv1.PodRunning {
+			console.Warn(fmt.Sprintf("Router '%v' is not running, its requests are not shown", pod.ObjectMeta.Name))
+			continue
+		}
+		console.Verbose(2, "Streaming the access log of router '%v'", pod.ObjectMeta.Name)
+		wg.Add(1)
+		go func(pod apiv1.Pod) {
+			defer wg.Done()
+			err := streamAccessLog(kubeClient, &pod, fnMeta, lines)
+			if err != nil {
+				console.Warn(fmt.Sprintf("Stopped streaming the access log of router '%v': %v", pod.ObjectMeta.Name, err))
+			}
+		}(pod)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	streamed := false
+	for line := range lines {
+		streamed = true
+		if format == accessLogFormatJSON {
+			fmt.Println(string(line))
+			continue
+		}
+		var entry accessLogEntry
+		err := json.Unmarshal(line, &entry)
+		if err != nil {
+			console.Verbose(2, "Unable to parse access log entry '%v': %v", string(line), err)
+			continue
+		}
+		fmt.Printf("%v %v %v %v %.1fms %v\n", entry.Timestamp.Format(time.RFC3339), entry.Method,
+			entry.Path, entry.Status, entry.LatencyMs, entry.Bytes)
+	}
+	if !streamed && len(pods.Items) == 0 {
+		return errors.New("no router pods found, set FISSION_NAMESPACE to the namespace fission is installed in")
+	}
+	return nil
+}
+
+// streamAccessLog follows the access log of a router pod through the
+// API server proxy and sends the entries to lines.
+func streamAccessLog(kubeClient kubernetes.Interface, pod *apiv1.Pod, fnMeta *metav1.ObjectMeta, lines chan<- []byte) error {
+	stream, err := kubeClient.CoreV1().Pods(pod.ObjectMeta.Namespace).ProxyGet("http", pod.ObjectMeta.Name,
+		routerAccessLogPort, routerAccessLogPath, map[string]string{
+			"function":  fnMeta.Name,
+			"namespace": fnMeta.Namespace,
+		}).Stream(context.Background())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		line := make([]byte, len(scanner.Bytes()))
+		copy(line, scanner.Bytes())
+		lines <- line
+	}
+	return scanner.Err()
+}
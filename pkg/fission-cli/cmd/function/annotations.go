@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	genClientset "github.com/fission/fission/pkg/generated/clientset/versioned"
+)
+
+type AnnotationsSubCommand struct {
+	cmd.CommandActioner
+}
+
+// AnnotationsGet prints the annotations of a function as key=value pairs.
+func AnnotationsGet(input cli.Input) error {
+	return (&AnnotationsSubCommand{}).get(input)
+}
+
+// AnnotationsSet sets a single annotation of a function. Only the
+// annotation is patched, so the spec of the function can't be
+// overwritten by a concurrent update.
+func AnnotationsSet(input cli.Input) error {
+	value := input.String(flagkey.FnAnnotationValue)
+	return (&AnnotationsSubCommand{}).patch(input, &value)
+}
+
+// AnnotationsDelete removes a single annotation of a function.
+func AnnotationsDelete(input cli.Input) error {
+	return (&AnnotationsSubCommand{}).patch(input, nil)
+}
+
+func (opts *AnnotationsSubCommand) get(input cli.Input) error {
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	keys := make([]string, 0, len(fn.ObjectMeta.Annotations))
+	for k := range fn.ObjectMeta.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("%v=%v\n", k, fn.ObjectMeta.Annotations[k])
+	}
+	return nil
+}
+
+// patch sends a JSON merge patch with only the annotation to the API
+// server. A nil value removes the annotation.
+func (opts *AnnotationsSubCommand) patch(input cli.Input, value *string) error {
+	fnName := input.String(flagkey.FnName)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+	key := input.String(flagkey.FnAnnotationKey)
+
+	patch, err := annotationPatch(key, value)
+	if err != nil {
+		return err
+	}
+
+	restConfig, _, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	fissionClient, err := genClientset.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "error creating fission client")
+	}
+
+	// custom resources don't support strategic merge patches, a
+	// merge patch of the annotations map leaves other keys untouched
+	_, err = fissionClient.CoreV1().Functions(fnNamespace).Patch(context.Background(), fnName,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error patching annotations of function '%v'", fnName)
+	}
+
+	if value == nil {
+		fmt.Printf("Annotation '%v' removed from function '%v'\n", key, fnName)
+	} else {
+		fmt.Printf("Annotation '%v' of function '%v' set\n", key, fnName)
+	}
+	return nil
+}
+
+// annotationPatch returns a JSON merge patch that sets the annotation to
+// value, or removes it if value is nil.
+func annotationPatch(key string, value *string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errors.New("annotation key cannot be empty")
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				key: value,
+			},
+		},
+	}
+	return json.Marshal(patch)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mholt/archiver"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+var zipMagic = []byte("PK\x03\x04")
+
+type ArchiveDownloadSubCommand struct {
+	cmd.CommandActioner
+}
+
+// ArchiveDownload saves the deployment archive of the package a
+// function is running, optionally extracted into a directory.
+func ArchiveDownload(input cli.Input) error {
+	return (&ArchiveDownloadSubCommand{}).do(input)
+}
+
+func (opts *ArchiveDownloadSubCommand) do(input cli.Input) error {
+	fnName := input.String(flagkey.FnName)
+	reader, err := openDeployArchive(opts.Client(), &metav1.ObjectMeta{
+		Name:      fnName,
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if !input.Bool(flagkey.FnArchiveUnzip) {
+		output := input.String(flagkey.FnArchiveOutput)
+		if len(output) == 0 {
+			output = fnName + ".zip"
+		}
+		err = pkgutil.WriteArchiveToFile(output, reader)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Archive of function '%v' saved to %v\n", fnName, output)
+		return nil
+	}
+
+	// deploy archives of single file functions are not zipped
+	br := bufio.NewReader(reader)
+	magic, _ := br.Peek(len(zipMagic))
+	if !bytes.Equal(magic, zipMagic) {
+		return errors.Errorf("deployment archive of function '%v' is not a zip file, download it without --%v", fnName, flagkey.FnArchiveUnzip)
+	}
+
+	tmpFile, err := os.CreateTemp("", fnName+"-*.zip")
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary file")
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = io.Copy(tmpFile, br)
+	tmpFile.Close()
+	if err != nil {
+		return errors.Wrap(err, "error downloading archive")
+	}
+
+	output := input.String(flagkey.FnArchiveOutput)
+	if len(output) == 0 {
+		output = fnName
+	}
+	err = archiver.Zip.Open(tmpFile.Name(), output)
+	if err != nil {
+		return errors.Wrapf(err, "error extracting archive to %v", output)
+	}
+	fmt.Printf("Archive of function '%v' extracted to %v\n", fnName, output)
+
+	return nil
+}
+
+// openDeployArchive returns the deployment archive of the package the
+// function is running.
+func openDeployArchive(client client.Interface, fnMeta *metav1.ObjectMeta) (io.ReadCloser, error) {
+	fn, err := client.V1().Function().Get(fnMeta)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting function")
+	}
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+		return nil, errors.Errorf("function '%v' runs a container image and has no archive", fnMeta.Name)
+	}
+
+	pkg, err := client.V1().Package().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Package.PackageRef.Name,
+		Namespace: fn.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting function package")
+	}
+
+	archive := pkg.Spec.Deployment
+	switch archive.Type {
+	case fv1.ArchiveTypeLiteral:
+		return io.NopCloser(bytes.NewReader(archive.Literal)), nil
+	case fv1.ArchiveTypeUrl:
+		return pkgutil.DownloadStoragesvcURL(client, archive.URL)
+	default:
+		return nil, errors.Errorf("package '%v' has no deployment archive, its build status is '%v'",
+			pkg.ObjectMeta.Name, pkg.Status.BuildStatus)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const asyncStatusPollInterval = time.Second
+
+type (
+	AsyncSubCommand struct {
+		cmd.CommandActioner
+	}
+
+	// asyncJob is the status of an asynchronous invocation as returned
+	// by the router.
+	asyncJob struct {
+		ID         string `json:"id"`
+		Status     string `json:"status"`
+		StatusCode int    `json:"statusCode"`
+		Body       []byte `json:"body"`
+		Error      string `json:"error"`
+	}
+)
+
+// InvokeAsync starts an asynchronous invocation of a function and
+// prints the job ID to poll the result with 'fn async-status'.
+func InvokeAsync(input cli.Input) error {
+	return (&AsyncSubCommand{}).invoke(input)
+}
+
+// AsyncStatus waits for an asynchronous invocation to complete and
+// prints the response of the function.
+func AsyncStatus(input cli.Input) error {
+	return (&AsyncSubCommand{}).status(input)
+}
+
+func (opts *AsyncSubCommand) invoke(input cli.Input) error {
+	body, contentType, err := getRequestBody(input)
+	if err != nil {
+		return err
+	}
+	headers := withContentType(input.StringSlice(flagkey.FnTestHeader), contentType)
+	headers, err = withAuthToken(headers)
+	if err != nil {
+		return err
+	}
+
+	routerURL, err := portForwardRouter(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%v/async-invoke/%v/%v", routerURL,
+		input.String(flagkey.NamespaceFunction), input.String(flagkey.FnName)), bytes.NewReader([]byte(body)))
+	if err != nil {
+		return errors.Wrap(err, "error creating HTTP request")
+	}
+	for _, header := range headers {
+		key, value, err := parseHeader(header)
+		if err != nil {
+			return err
+		}
+		req.Header.Add(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error executing HTTP request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf("router returned status code %v", resp.StatusCode)
+	}
+	var accepted struct {
+		JobID string `json:"jobID"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&accepted)
+	if err != nil {
+		return errors.Wrap(err, "error reading job ID")
+	}
+
+	fmt.Println(accepted.JobID)
+	return nil
+}
+
+func (opts *AsyncSubCommand) status(input cli.Input) error {
+	routerURL, err := portForwardRouter(input)
+	if err != nil {
+		return err
+	}
+	statusURL := fmt.Sprintf("%v/async-status/%v", routerURL, input.String(flagkey.FnAsyncJob))
+	// the router checks the token on the job status too
+	headers, err := withAuthToken(nil)
+	if err != nil {
+		return err
+	}
+
+	var deadline time.Time
+	if timeout := input.Duration(flagkey.FnTestTimeout); timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		job, err := getAsyncJob(statusURL, headers)
+		if err != nil {
+			return err
+		}
+		if job.Status != "pending" {
+			os.Stdout.Write(job.Body)
+			if len(job.Error) > 0 {
+				return errors.Errorf("job %v failed: %v", job.ID, job.Error)
+			}
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return errors.Errorf("job %v is still pending, try again later", job.ID)
+		}
+		console.Verbose(2, "Job %v is pending", job.ID)
+		time.Sleep(asyncStatusPollInterval)
+	}
+}
+
+func getAsyncJob(url string, headers []string) (*asyncJob, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating HTTP request")
+	}
+	for _, header := range headers {
+		key, value, err := parseHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting job status")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.New("job not found, it may have expired")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("router returned status code %v", resp.StatusCode)
+	}
+	var job asyncJob
+	err = json.NewDecoder(resp.Body).Decode(&job)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading job status")
+	}
+	return &job, nil
+}
+
+// portForwardRouter returns the URL of a local port forwarded to the router.
+func portForwardRouter(input cli.Input) (string, error) {
+	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", input.String(flagkey.KubeContext))
+	if err != nil {
+		return "", err
+	}
+	return "http://127.0.0.1:" + localRouterPort, nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/httptrigger"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type BenchmarkSubCommand struct {
+	cmd.CommandActioner
+	meta     *metav1.ObjectMeta
+	url      string
+	method   string
+	headers  []string
+	body     string
+	rps      int
+	duration time.Duration
+	timeout  time.Duration
+	output   *util.OutputFormatter
+}
+
+// BenchmarkResult is the summary of a benchmark run. Latencies are
+// in milliseconds.
+type BenchmarkResult struct {
+	Function   string  `json:"function"`
+	Duration   string  `json:"duration"`
+	TargetRPS  int     `json:"targetRPS"`
+	ActualRPS  float64 `json:"actualRPS"`
+	Requests   int     `json:"requests"`
+	Errors     int     `json:"errors"`
+	ErrorRate  float64 `json:"errorRate"`
+	ColdStarts int     `json:"coldStarts"`
+	P50        float64 `json:"p50"`
+	P90        float64 `json:"p90"`
+	P99        float64 `json:"p99"`
+}
+
+// Benchmark sends requests to a function through the router at a fixed
+// rate and reports the latency distribution, error rate and the number
+// of cold starts.
+func Benchmark(input cli.Input) error {
+	return (&BenchmarkSubCommand{}).do(input)
+}
+
+func (opts *BenchmarkSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *BenchmarkSubCommand) complete(input cli.Input) error {
+	opts.meta = &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	opts.rps = input.Int(flagkey.FnBenchmarkRPS)
+	if opts.rps <= 0 {
+		return errors.New("--rps must be greater than 0")
+	}
+	opts.duration = input.Duration(flagkey.FnBenchmarkDuration)
+	if opts.duration <= 0 {
+		return errors.New("--duration must be greater than 0")
+	}
+	opts.timeout = input.Duration(flagkey.FnTestTimeout)
+
+	methods := input.StringSlice(flagkey.HtMethod)
+	if len(methods) != 1 {
+		return errors.New("exactly one HTTP method must be given")
+	}
+	method, err := httptrigger.GetMethod(methods[0])
+	if err != nil {
+		return err
+	}
+	opts.method = method
+
+	for _, header := range input.StringSlice(flagkey.FnTestHeader) {
+		if len(strings.SplitN(header, ":", 2)) != 2 {
+			return errors.Errorf("invalid header '%v', must be of the form key:value", header)
+		}
+	}
+	opts.headers, err = withAuthToken(input.StringSlice(flagkey.FnTestHeader))
+	if err != nil {
+		return err
+	}
+	opts.body = input.String(flagkey.FnTestBody)
+
+	opts.output, err = util.NewOutputFormatter(input.String(flagkey.FnBenchmarkOutput))
+	if err != nil {
+		return err
+	}
+
+	_, err = opts.Client().V1().Function().Get(opts.meta)
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", opts.meta.Name)
+	}
+
+	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	opts.url = "http://127.0.0.1:" + localRouterPort + util.UrlForFunction(opts.meta.Name, opts.meta.Namespace)
+	if input.IsSet(flagkey.FnSubPath) {
+		opts.url = opts.url + "/" + strings.TrimPrefix(input.String(flagkey.FnSubPath), "/")
+	}
+	console.Verbose(2, "Function benchmark url: %v", opts.url)
+
+	return nil
+}
+
+func (opts *BenchmarkSubCommand) run(input cli.Input) error {
+	podsBefore, err := opts.functionPods()
+	if err != nil {
+		return err
+	}
+
+	// stderr keeps the output parsable when --output json is used
+	fmt.Fprintf(os.Stderr, "Benchmarking function '%v' at %v requests/s for %v\n", opts.meta.Name, opts.rps, opts.duration)
+
+	hc := &http.Client{Timeout: opts.timeout}
+	if opts.timeout < 0 {
+		hc.Timeout = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.duration)
+	defer cancel()
+
+	limiter := rate.NewLimiter(rate.Limit(opts.rps), 1)
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies []time.Duration
+		failures  int
+	)
+	start := time.Now()
+	for limiter.Wait(ctx) == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			latency, err := opts.invoke(hc)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				console.Verbose(2, "Request failed: %v", err)
+				failures++
+				return
+			}
+			latencies = append(latencies, latency)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	podsAfter, err := opts.functionPods()
+	if err != nil {
+		return err
+	}
+	coldStarts := 0
+	for uid := range podsAfter {
+		if _, ok := podsBefore[uid]; !ok {
+			coldStarts++
+		}
+	}
+
+	result := summarize(latencies, failures, elapsed)
+	result.Function = opts.meta.Name
+	result.Duration = opts.duration.String()
+	result.TargetRPS = opts.rps
+	result.ColdStarts = coldStarts
+
+	if opts.output.IsStructured() {
+		return opts.output.Print(result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "REQUESTS", "RPS", "P50", "P90", "P99", "ERRORS", "ERROR RATE", "COLD STARTS")
+	fmt.Fprintf(w, "%v\t%.1f\t%.1fms\t%.1fms\t%.1fms\t%v\t%.2f%%\t%v\n",
+		result.Requests, result.ActualRPS, result.P50, result.P90, result.P99,
+		result.Errors, result.ErrorRate*100, result.ColdStarts)
+	w.Flush()
+
+	return nil
+}
+
+// invoke sends a single request and returns its latency. Responses
+// with a status code of 400 and above are counted as errors.
+func (opts *BenchmarkSubCommand) invoke(hc *http.Client) (time.Duration, error) {
+	req, err := http.NewRequest(opts.method, opts.url, strings.NewReader(opts.body))
+	if err != nil {
+		return 0, errors.Wrap(err, "error creating HTTP request")
+	}
+	for _, header := range opts.headers {
+		kv := strings.SplitN(header, ":", 2)
+		req.Header.Set(kv[0], kv[1])
+	}
+
+	start := time.Now()
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "error executing HTTP request")
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, errors.Wrap(err, "error reading response from function")
+	}
+	if resp.StatusCode >= 400 {
+		return 0, errors.Errorf("function returned status code %v", resp.StatusCode)
+	}
+	return latency, nil
+}
+
+// functionPods returns the UIDs of the pods currently serving the
+// function. Pods that show up during the run were specialized to
+// serve the benchmark traffic, so they are counted as cold starts.
+func (opts *BenchmarkSubCommand) functionPods() (map[types.UID]struct{}, error) {
+	pods, err := opts.Client().V1().Function().ListPods(opts.meta)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing function pods")
+	}
+	uids := make(map[types.UID]struct{}, len(pods))
+	for _, pod := range pods {
+		uids[pod.ObjectMeta.UID] = struct{}{}
+	}
+	return uids, nil
+}
+
+func summarize(latencies []time.Duration, failures int, elapsed time.Duration) *BenchmarkResult {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	result := &BenchmarkResult{
+		Requests: len(latencies) + failures,
+		Errors:   failures,
+		P50:      percentile(latencies, 50),
+		P90:      percentile(latencies, 90),
+		P99:      percentile(latencies, 99),
+	}
+	if result.Requests > 0 {
+		result.ErrorRate = float64(failures) / float64(result.Requests)
+	}
+	if elapsed > 0 {
+		result.ActualRPS = float64(result.Requests) / elapsed.Seconds()
+	}
+	return result
+}
+
+// percentile returns the p-th percentile of the sorted latencies in
+// milliseconds, using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return float64(sorted[rank-1]) / float64(time.Millisecond)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type CanarySubCommand struct {
+	cmd.CommandActioner
+	trigger   *fv1.HTTPTrigger
+	canary    *fv1.CanaryConfig
+	fnName    string
+	newFnName string
+}
+
+// Canary splits the traffic of the HTTP trigger of a function between
+// the function and a new version of it. With --increment the canary
+// config manager shifts the weight to the new version every --interval
+// and rolls back if the error rate exceeds --max-error-rate.
+func Canary(input cli.Input) error {
+	return (&CanarySubCommand{}).do(input)
+}
+
+func (opts *CanarySubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CanarySubCommand) complete(input cli.Input) error {
+	fnName := input.String(flagkey.FnName)
+	newFnName := input.String(flagkey.FnCanaryNewVersion)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+
+	weight := input.Int(flagkey.FnCanaryWeight)
+	if weight < 0 || weight > 100 {
+		return errors.Errorf("--%v must be between 0 and 100", flagkey.FnCanaryWeight)
+	}
+	increment := input.Int(flagkey.FnCanaryIncrement)
+	if increment < 0 || increment > 100 {
+		return errors.Errorf("--%v must be between 0 and 100", flagkey.FnCanaryIncrement)
+	}
+	maxErrorRate := input.Int(flagkey.FnCanaryMaxErrorRate)
+	if maxErrorRate < 0 || maxErrorRate > 100 {
+		return errors.Errorf("--%v must be between 0 and 100", flagkey.FnCanaryMaxErrorRate)
+	}
+	interval := input.String(flagkey.FnCanaryInterval)
+	_, err := time.ParseDuration(interval)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing --%v", flagkey.FnCanaryInterval)
+	}
+
+	if fnName == newFnName {
+		return errors.New("the new version must be a different function")
+	}
+	err = util.CheckFunctionExistence(opts.Client(), []string{fnName, newFnName}, fnNamespace)
+	if err != nil {
+		return errors.Wrap(err, "error checking functions existence")
+	}
+
+	trigger, err := opts.getTrigger(input.String(flagkey.FnCanaryTrigger), fnName, fnNamespace)
+	if err != nil {
+		return err
+	}
+	trigger.Spec.FunctionReference = fv1.FunctionReference{
+		Type: fv1.FunctionReferenceTypeFunctionWeights,
+		FunctionWeights: map[string]int{
+			fnName:    100 - weight,
+			newFnName: weight,
+		},
+	}
+	opts.trigger = trigger
+	opts.fnName = fnName
+	opts.newFnName = newFnName
+
+	if increment > 0 {
+		opts.canary = &fv1.CanaryConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      util.KubifyName(fmt.Sprintf("%v-%v-canary", fnName, newFnName)),
+				Namespace: fnNamespace,
+			},
+			Spec: fv1.CanaryConfigSpec{
+				Trigger:                 trigger.ObjectMeta.Name,
+				NewFunction:             newFnName,
+				OldFunction:             fnName,
+				WeightIncrement:         increment,
+				WeightIncrementDuration: interval,
+				FailureThreshold:        maxErrorRate,
+				FailureType:             fv1.FailureTypeStatusCode,
+			},
+			Status: fv1.CanaryConfigStatus{
+				Status: fv1.CanaryConfigStatusPending,
+			},
+		}
+	}
+
+	return nil
+}
+
+// getTrigger returns the given HTTP trigger, or the only HTTP trigger
+// referencing the function if no trigger name is given.
+func (opts *CanarySubCommand) getTrigger(triggerName, fnName, fnNamespace string) (*fv1.HTTPTrigger, error) {
+	if len(triggerName) > 0 {
+		trigger, err := opts.Client().V1().HTTPTrigger().Get(&metav1.ObjectMeta{
+			Name:      triggerName,
+			Namespace: fnNamespace,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting HTTP trigger")
+		}
+		if !referencesFunction(trigger.Spec.FunctionReference, fnName) {
+			return nil, errors.Errorf("HTTP trigger '%v' doesn't reference function '%v'", triggerName, fnName)
+		}
+		return trigger, nil
+	}
+
+	triggers, err := opts.Client().V1().HTTPTrigger().List(fnNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing HTTP triggers")
+	}
+	var found []fv1.HTTPTrigger
+	for _, t := range triggers {
+		if referencesFunction(t.Spec.FunctionReference, fnName) {
+			found = append(found, t)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("no HTTP trigger references function '%v', create one with 'fission httptrigger create'", fnName)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, errors.Errorf("function '%v' is referenced by %v HTTP triggers, use --%v to choose one", fnName, len(found), flagkey.FnCanaryTrigger)
+	}
+}
+
+func (opts *CanarySubCommand) run(input cli.Input) error {
+	_, err := opts.Client().V1().HTTPTrigger().Update(opts.trigger)
+	if err != nil {
+		return errors.Wrap(err, "error updating HTTP trigger")
+	}
+	weights := opts.trigger.Spec.FunctionReference.FunctionWeights
+	fmt.Printf("HTTP trigger '%v' routes %v%% to '%v' and %v%% to '%v'\n", opts.trigger.ObjectMeta.Name,
+		weights[opts.fnName], opts.fnName, weights[opts.newFnName], opts.newFnName)
+
+	if opts.canary == nil {
+		return nil
+	}
+
+	existing, err := opts.Client().V1().CanaryConfig().Get(&opts.canary.ObjectMeta)
+	if err != nil && !ferror.IsNotFound(err) {
+		return errors.Wrap(err, "error getting canary config")
+	}
+	if existing != nil {
+		existing.Spec = opts.canary.Spec
+		existing.Status = opts.canary.Status
+		_, err = opts.Client().V1().CanaryConfig().Update(existing)
+		if err != nil {
+			return errors.Wrap(err, "error updating canary config")
+		}
+		fmt.Printf("canary config '%v' updated\n", existing.ObjectMeta.Name)
+		return nil
+	}
+
+	_, err = opts.Client().V1().CanaryConfig().Create(opts.canary)
+	if err != nil {
+		return errors.Wrap(err, "error creating canary config")
+	}
+	fmt.Printf("canary config '%v' created, shifting %v%% every %v\n", opts.canary.ObjectMeta.Name,
+		opts.canary.Spec.WeightIncrement, opts.canary.Spec.WeightIncrementDuration)
+	return nil
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
+	"github.com/fission/fission/pkg/controller/client/rest"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type CloneSubCommand struct {
+	cmd.CommandActioner
+	target   client.Interface
+	function *fv1.Function
+
+	// the resources created on the target cluster before the function,
+	// deleted again if the function can't be created
+	createdEnv *metav1.ObjectMeta
+	createdPkg *metav1.ObjectMeta
+}
+
+// Clone copies a function to another Fission cluster, together with its
+// package and, if missing there, its environment. Archives kept in the
+// storage service are downloaded and uploaded to the target cluster,
+// archives at external URLs are referenced as they are.
+func Clone(input cli.Input) error {
+	return (&CloneSubCommand{}).do(input)
+}
+
+func (opts *CloneSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CloneSubCommand) complete(input cli.Input) error {
+	targetServer := input.String(flagkey.FnCloneTargetServer)
+	opts.target = client.MakeClientset(rest.NewRESTClient(targetServer))
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	existing, err := opts.target.V1().Function().Get(&fn.ObjectMeta)
+	if err != nil && !ferror.IsNotFound(err) {
+		return errors.Wrapf(err, "error checking function on %v", targetServer)
+	} else if existing != nil {
+		// the same object, whatever the URLs look like
+		if existing.ObjectMeta.UID == fn.ObjectMeta.UID {
+			return errors.New("target server must be a different cluster")
+		}
+		return errors.Errorf("function '%v' already exists on %v", fn.ObjectMeta.Name, targetServer)
+	}
+
+	opts.function = &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fn.ObjectMeta.Name,
+			Namespace: fn.ObjectMeta.Namespace,
+		},
+		Spec: *fn.Spec.DeepCopy(),
+	}
+	// the revisions refer to packages of the source cluster
+	spec.CopyUnmanagedMeta(&opts.function.ObjectMeta, &fn.ObjectMeta)
+	delete(opts.function.ObjectMeta.Annotations, REVISION_HISTORY_ANNOTATION)
+
+	return nil
+}
+
+func (opts *CloneSubCommand) run(input cli.Input) error {
+	err := opts.cloneEnvironment()
+	if err != nil {
+		return err
+	}
+
+	if opts.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		pkgMeta, err := opts.clonePackage()
+		if err != nil {
+			return opts.cleanup(err)
+		}
+		opts.function.Spec.Package.PackageRef = fv1.PackageRef{
+			Namespace:       pkgMeta.Namespace,
+			Name:            pkgMeta.Name,
+			ResourceVersion: pkgMeta.ResourceVersion,
+		}
+	}
+
+	_, err = opts.target.V1().Function().Create(opts.function)
+	if err != nil {
+		return opts.cleanup(errors.Wrap(err, "error creating function on target cluster"))
+	}
+	fmt.Printf("Function '%v' cloned to %v\n", opts.function.ObjectMeta.Name, input.String(flagkey.FnCloneTargetServer))
+
+	if input.Bool(flagkey.FnCloneWithTriggers) {
+		return opts.cloneTriggers()
+	}
+	return nil
+}
+
+// cleanup deletes the package and environment created on the target
+// cluster when the function couldn't be cloned, and returns err along
+// with the resources it failed to delete.
+func (opts *CloneSubCommand) cleanup(err error) error {
+	result := multierror.Append(nil, err)
+	if opts.createdPkg != nil {
+		e := opts.target.V1().Package().Delete(opts.createdPkg)
+		if e != nil {
+			result = multierror.Append(result, errors.Wrapf(e, "error deleting package '%v' from target cluster", opts.createdPkg.Name))
+		}
+	}
+	if opts.createdEnv != nil {
+		e := opts.target.V1().Environment().Delete(opts.createdEnv)
+		if e != nil {
+			result = multierror.Append(result, errors.Wrapf(e, "error deleting environment '%v' from target cluster", opts.createdEnv.Name))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// cloneEnvironment creates the environment of the function on the
+// target cluster unless an environment with that name exists there.
+func (opts *CloneSubCommand) cloneEnvironment() error {
+	envMeta := &metav1.ObjectMeta{
+		Name:      opts.function.Spec.Environment.Name,
+		Namespace: opts.function.Spec.Environment.Namespace,
+	}
+	_, err := opts.target.V1().Environment().Get(envMeta)
+	if err == nil {
+		return nil
+	} else if !ferror.IsNotFound(err) {
+		return errors.Wrap(err, "error checking environment on target cluster")
+	}
+
+	env, err := opts.Client().V1().Environment().Get(envMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting environment")
+	}
+	clone := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      env.ObjectMeta.Name,
+			Namespace: env.ObjectMeta.Namespace,
+		},
+		Spec: *env.Spec.DeepCopy(),
+	}
+	spec.CopyUnmanagedMeta(&clone.ObjectMeta, &env.ObjectMeta)
+	envMeta, err = opts.target.V1().Environment().Create(clone)
+	if err != nil {
+		return errors.Wrap(err, "error creating environment on target cluster")
+	}
+	opts.createdEnv = envMeta
+	fmt.Printf("Environment '%v' created on target cluster\n", env.ObjectMeta.Name)
+	return nil
+}
+
+// clonePackage creates a copy of the package of the function on the
+// target cluster. The package keeps its name unless the name is taken.
+func (opts *CloneSubCommand) clonePackage() (*metav1.ObjectMeta, error) {
+	src, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      opts.function.Spec.Package.PackageRef.Name,
+		Namespace: opts.function.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting function package")
+	}
+
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      src.ObjectMeta.Name,
+			Namespace: src.ObjectMeta.Namespace,
+		},
+		Spec: *src.Spec.DeepCopy(),
+		Status: fv1.PackageStatus{
+			BuildStatus:         src.Status.BuildStatus,
+			BuildLog:            src.Status.BuildLog,
+			LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+		},
+	}
+	spec.CopyUnmanagedMeta(&pkg.ObjectMeta, &src.ObjectMeta)
+	existing, err := opts.target.V1().Package().Get(&pkg.ObjectMeta)
+	if err != nil && !ferror.IsNotFound(err) {
+		return nil, errors.Wrap(err, "error checking package on target cluster")
+	} else if existing != nil {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating uuid")
+		}
+		pkg.ObjectMeta.Name = fmt.Sprintf("%v-%v", opts.function.ObjectMeta.Name, id.String())
+	}
+
+	for _, archive := range []*fv1.Archive{&pkg.Spec.Source, &pkg.Spec.Deployment} {
+		if archive.Type != fv1.ArchiveTypeUrl || len(archive.URL) == 0 {
+			continue
+		}
+		// external URLs are reachable from the target cluster as well
+		stored, err := pkgutil.IsStoragesvcURL(opts.Client(), archive.URL)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			continue
+		}
+		copied, err := opts.cloneArchive(archive)
+		if err != nil {
+			return nil, err
+		}
+		*archive = *copied
+	}
+
+	pkgMeta, err := opts.target.V1().Package().Create(pkg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating package on target cluster")
+	}
+	opts.createdPkg = pkgMeta
+	return pkgMeta, nil
+}
+
+// cloneArchive downloads an archive from the storage service of the
+// source cluster and uploads it to the one of the target cluster.
+func (opts *CloneSubCommand) cloneArchive(archive *fv1.Archive) (*fv1.Archive, error) {
+	reader, err := pkgutil.DownloadStoragesvcURL(opts.Client(), archive.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	tmpFile, err := os.CreateTemp("", opts.function.ObjectMeta.Name+"-*.zip")
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating temporary file")
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = io.Copy(tmpFile, reader)
+	tmpFile.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading archive")
+	}
+
+	copied, err := pkgutil.UploadArchiveFile(context.Background(), opts.target, tmpFile.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "error uploading archive to target cluster")
+	}
+	if len(archive.Checksum.Sum) > 0 && copied.Checksum.Sum != archive.Checksum.Sum {
+		return nil, errors.Errorf("checksum of archive %v doesn't match the stored checksum", archive.URL)
+	}
+	return copied, nil
+}
+
+// cloneTriggers creates the triggers invoking the function on the
+// target cluster under the same names. Triggers whose name is taken
+// there are skipped.
+func (opts *CloneSubCommand) cloneTriggers() error {
+	ns := opts.function.ObjectMeta.Namespace
+	matches := func(ref fv1.FunctionReference) bool {
+		return ref.Type == fv1.FunctionReferenceTypeFunctionName && ref.Name == opts.function.ObjectMeta.Name
+	}
+	created := func(kind string, meta *metav1.ObjectMeta, err error) error {
+		if ferror.IsNameExists(err) {
+			console.Warn(fmt.Sprintf("%v '%v' already exists on target cluster, skipped", kind, meta.Name))
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "error creating %v '%v' on target cluster", kind, meta.Name)
+		}
+		fmt.Printf("%v '%v' cloned\n", kind, meta.Name)
+		return nil
+	}
+
+	hts, err := opts.Client().V1().HTTPTrigger().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing HTTP triggers")
+	}
+	for _, ht := range hts {
+		if !matches(ht.Spec.FunctionReference) {
+			continue
+		}
+		_, err = opts.target.V1().HTTPTrigger().Create(&fv1.HTTPTrigger{ObjectMeta: triggerMeta(&ht.ObjectMeta), Spec: ht.Spec})
+		if err = created("HTTP trigger", &ht.ObjectMeta, err); err != nil {
+			return err
+		}
+	}
+
+	tts, err := opts.Client().V1().TimeTrigger().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing time triggers")
+	}
+	for _, tt := range tts {
+		if !matches(tt.Spec.FunctionReference) {
+			continue
+		}
+		_, err = opts.target.V1().TimeTrigger().Create(&fv1.TimeTrigger{ObjectMeta: triggerMeta(&tt.ObjectMeta), Spec: tt.Spec})
+		if err = created("time trigger", &tt.ObjectMeta, err); err != nil {
+			return err
+		}
+	}
+
+	// without a queue type the controller returns the triggers of all namespaces
+	mqts, err := opts.Client().V1().MessageQueueTrigger().List("", ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+	for _, mqt := range mqts {
+		if mqt.ObjectMeta.Namespace != ns || !matches(mqt.Spec.FunctionReference) {
+			continue
+		}
+		_, err = opts.target.V1().MessageQueueTrigger().Create(&fv1.MessageQueueTrigger{ObjectMeta: triggerMeta(&mqt.ObjectMeta), Spec: mqt.Spec})
+		if err = created("message queue trigger", &mqt.ObjectMeta, err); err != nil {
+			return err
+		}
+	}
+
+	kws, err := opts.Client().V1().KubeWatcher().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing kube watchers")
+	}
+	for _, kw := range kws {
+		if !matches(kw.Spec.FunctionReference) {
+			continue
+		}
+		_, err = opts.target.V1().KubeWatcher().Create(&fv1.KubernetesWatchTrigger{ObjectMeta: triggerMeta(&kw.ObjectMeta), Spec: kw.Spec})
+		if err = created("kube watcher", &kw.ObjectMeta, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// triggerMeta returns the metadata of a trigger without the fields
+// that belong to the object or the spec deployment in the source cluster.
+func triggerMeta(src *metav1.ObjectMeta) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      src.Name,
+		Namespace: src.Namespace,
+	}
+	spec.CopyUnmanagedMeta(&meta, src)
+	return meta
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	podTerminationTimeout = 2 * time.Minute
+	podPollInterval       = time.Second
+)
+
+type ColdStartTimeSubCommand struct {
+	cmd.CommandActioner
+	function   *fv1.Function
+	kubeClient kubernetes.Interface
+	selector   string
+	url        string
+	headers    []string
+	samples    int
+	timeout    time.Duration
+}
+
+// ColdStartTime removes the warm pods of a function before every
+// request and reports the time to the first byte of the responses.
+func ColdStartTime(input cli.Input) error {
+	return (&ColdStartTimeSubCommand{}).do(input)
+}
+
+func (opts *ColdStartTimeSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ColdStartTimeSubCommand) complete(input cli.Input) error {
+	opts.samples = input.Int(flagkey.FnColdStartSamples)
+	if opts.samples <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.FnColdStartSamples)
+	}
+	opts.timeout = input.Duration(flagkey.FnTestTimeout)
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	executorType := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
+	if executorType != fv1.ExecutorTypePoolmgr && executorType != fv1.ExecutorTypeNewdeploy {
+		return errors.Errorf("function '%v' uses executor type '%v', cold start measurement is only supported for '%v' and '%v' functions",
+			fn.ObjectMeta.Name, executorType, fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy)
+	}
+	opts.function = fn
+	opts.selector = labels.Set{fv1.FUNCTION_UID: string(fn.ObjectMeta.UID)}.AsSelector().String()
+
+	_, opts.kubeClient, err = util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	opts.headers, err = withAuthToken(nil)
+	if err != nil {
+		return err
+	}
+
+	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	opts.url = "http://127.0.0.1:" + localRouterPort + util.UrlForFunction(fn.ObjectMeta.Name, fn.ObjectMeta.Namespace)
+	if input.IsSet(flagkey.FnSubPath) {
+		opts.url = opts.url + "/" + strings.TrimPrefix(input.String(flagkey.FnSubPath), "/")
+	}
+	console.Verbose(2, "Function cold start url: %v", opts.url)
+
+	return nil
+}
+
+func (opts *ColdStartTimeSubCommand) run(input cli.Input) error {
+	if opts.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+		restore, err := opts.saveReplicas()
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	hc := &http.Client{Timeout: opts.timeout}
+	if opts.timeout < 0 {
+		hc.Timeout = 0
+	}
+
+	var latencies []time.Duration
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\n", "SAMPLE", "STATUS", "TIME TO FIRST BYTE")
+	for i := 1; i <= opts.samples; i++ {
+		err := opts.removeWarmPods()
+		if err != nil {
+			w.Flush()
+			return err
+		}
+		status, ttfb, err := opts.invoke(hc)
+		if err != nil {
+			w.Flush()
+			return errors.Wrapf(err, "error invoking function in sample %v", i)
+		}
+		latencies = append(latencies, ttfb)
+		fmt.Fprintf(w, "%v\t%v\t%v\n", i, status, ttfb.Round(time.Millisecond))
+		w.Flush()
+	}
+
+	fastest, slowest, sum := latencies[0], latencies[0], time.Duration(0)
+	for _, l := range latencies {
+		if l < fastest {
+			fastest = l
+		}
+		if l > slowest {
+			slowest = l
+		}
+		sum += l
+	}
+	avg := sum / time.Duration(len(latencies))
+	fmt.Printf("\nCold start time of function '%v' over %v samples: min %v, avg %v, max %v\n",
+		opts.function.ObjectMeta.Name, len(latencies),
+		fastest.Round(time.Millisecond), avg.Round(time.Millisecond), slowest.Round(time.Millisecond))
+
+	return nil
+}
+
+// saveReplicas returns a function that scales the deployments of a
+// newdeploy function back to the replicas they have now.
+func (opts *ColdStartTimeSubCommand) saveReplicas() (func(), error) {
+	deployments, err := opts.deployments()
+	if err != nil {
+		return nil, err
+	}
+	replicas := make(map[string]int32, len(deployments))
+	for _, d := range deployments {
+		if d.Spec.Replicas != nil {
+			replicas[d.ObjectMeta.Namespace+"/"+d.ObjectMeta.Name] = *d.Spec.Replicas
+		}
+	}
+
+	return func() {
+		deployments, err := opts.deployments()
+		if err != nil {
+			console.Warn(fmt.Sprintf("Unable to restore the replicas of function '%v': %v", opts.function.ObjectMeta.Name, err))
+			return
+		}
+		for _, d := range deployments {
+			original, ok := replicas[d.ObjectMeta.Namespace+"/"+d.ObjectMeta.Name]
+			if !ok {
+				continue
+			}
+			err = opts.scaleDeployment(&d, original)
+			if err != nil {
+				console.Warn(fmt.Sprintf("Unable to restore deployment '%v' to %v replicas: %v", d.ObjectMeta.Name, original, err))
+			}
+		}
+	}, nil
+}
+
+func (opts *ColdStartTimeSubCommand) deployments() ([]appsv1.Deployment, error) {
+	list, err := opts.kubeClient.AppsV1().Deployments(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		LabelSelector: opts.selector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing function deployments")
+	}
+	return list.Items, nil
+}
+
+func (opts *ColdStartTimeSubCommand) scaleDeployment(d *appsv1.Deployment, replicas int32) error {
+	scale, err := opts.kubeClient.AppsV1().Deployments(d.ObjectMeta.Namespace).GetScale(context.Background(), d.ObjectMeta.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	scale.Spec.Replicas = replicas
+	_, err = opts.kubeClient.AppsV1().Deployments(d.ObjectMeta.Namespace).UpdateScale(context.Background(), d.ObjectMeta.Name, scale, metav1.UpdateOptions{})
+	return err
+}
+
+// removeWarmPods makes sure the next request hits a cold function. The
+// deployments of newdeploy functions are scaled to zero, as deleted pods
+// would be replaced right away; specialized pool pods are deleted, the
+// pool replaces them with generic pods.
+func (opts *ColdStartTimeSubCommand) removeWarmPods() error {
+	ctx := context.Background()
+
+	if opts.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+		deployments, err := opts.deployments()
+		if err != nil {
+			return err
+		}
+		for _, d := range deployments {
+			err = opts.scaleDeployment(&d, 0)
+			if err != nil {
+				return errors.Wrapf(err, "error scaling deployment '%v' to zero", d.ObjectMeta.Name)
+			}
+		}
+	} else {
+		err := opts.kubeClient.CoreV1().Pods(metav1.NamespaceAll).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{
+			LabelSelector: opts.selector,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error deleting function pods")
+		}
+	}
+
+	deadline := time.Now().Add(podTerminationTimeout)
+	for {
+		pods, err := opts.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			LabelSelector: opts.selector,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error listing function pods")
+		}
+		if len(pods.Items) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("function pods still running after %v", podTerminationTimeout)
+		}
+		time.Sleep(podPollInterval)
+	}
+}
+
+// invoke sends a GET request to the function and returns the status
+// code and the time to the first byte of the response.
+func (opts *ColdStartTimeSubCommand) invoke(hc *http.Client) (int, time.Duration, error) {
+	var firstByte time.Time
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+
+	req, err := http.NewRequest(http.MethodGet, opts.url, nil)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "error creating HTTP request")
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	for _, header := range opts.headers {
+		kv := strings.SplitN(header, ":", 2)
+		req.Header.Set(kv[0], kv[1])
+	}
+
+	start := time.Now()
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "error executing HTTP request")
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "error reading response from function")
+	}
+
+	return resp.StatusCode, firstByte.Sub(start), nil
+}
@@ -27,6 +27,7 @@ func Commands() *cobra.Command {
 	createCmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a function (and optionally, an HTTP route to it)",
+		Long:  "Create a function (and optionally, an HTTP route to it). If --env is not given, the environment is inferred from the extension of the --code file (.js, .py, .go, .rb, .java) when exactly one environment name starts with that language.",
 		RunE:  wrapper.Wrapper(Create),
 	}
 	wrapper.SetFlags(createCmd, flag.FlagSet{
@@ -36,17 +37,18 @@ func Commands() *cobra.Command {
 			flag.FnExecutorType, flag.FnCfgMap, flag.FnSecret,
 			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
 			flag.FnIdleTimeout, flag.FnConcurrency, flag.FnRequestsPerPod,
-			flag.FnOnceOnly, flag.Labels, flag.Annotation,
+			flag.FnOnceOnly, flag.Labels, flag.Annotation, flag.FnSpecFile,
+			flag.FnWait, flag.FnWaitTimeout, flag.FnConcurrencyModel,
 
 			// TODO retired pkg & trigger related flags from function cmd
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgChunkSize,
 			flag.FnBuildCmd,
 
 			flag.HtUrl, flag.HtPrefix, flag.HtMethod,
 
 			// flag for newdeploy to use.
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
 
@@ -64,6 +66,75 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceFunction},
 	})
 
+	exportCmd := &cobra.Command{
+		Use:     "export",
+		Aliases: []string{},
+		Short:   "Export a function and its dependencies as a Helm chart",
+		Long:    "Export a function together with its package, environment and triggers as a Helm chart that can be installed with 'helm install'",
+		RunE:    wrapper.Wrapper(Export),
+	}
+	wrapper.SetFlags(exportCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.FnExportOutput},
+	})
+
+	importCmd := &cobra.Command{
+		Use:     "import",
+		Aliases: []string{},
+		Short:   "Create a function from a Function manifest",
+		Long:    "Create a function from a Function manifest, e.g. the output of 'kubectl get function <name> -o yaml' in another cluster. Fields managed by the API server are removed; if the package of the function doesn't exist, it's created from --package-file.",
+		RunE:    wrapper.Wrapper(Import),
+	}
+	wrapper.SetFlags(importCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnImportFile},
+		Optional: []flag.Flag{flag.FnImportPackageFile, flag.NamespaceFunction},
+	})
+
+	importLambdaCmd := &cobra.Command{
+		Use:     "import-from-lambda",
+		Aliases: []string{},
+		Short:   "Create a function from an AWS Lambda function",
+		Long:    "Create a function from the deployment package of an AWS Lambda function. The environment is chosen from the Lambda runtime unless --env is given, and the Lambda environment variables are stored in a ConfigMap named <function name>-env. AWS credentials are read from the environment or the shared credentials file. Settings without a Fission equivalent are listed so they can be migrated by hand.",
+		RunE:    wrapper.Wrapper(ImportFromLambda),
+	}
+	wrapper.SetFlags(importLambdaCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnLambdaRegion, flag.FnLambdaFunctionName},
+		Optional: []flag.Flag{flag.FnName, flag.FnEnvName, flag.NamespaceFunction, flag.NamespaceEnvironment},
+	})
+
+	rollbackCmd := &cobra.Command{
+		Use:     "rollback",
+		Aliases: []string{},
+		Short:   "Roll back a function to a previous package revision",
+		RunE:    wrapper.Wrapper(Rollback),
+	}
+	wrapper.SetFlags(rollbackCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnRevision},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.FnRevisionHistoryLimit},
+	})
+
+	historyCmd := &cobra.Command{
+		Use:     "history",
+		Aliases: []string{},
+		Short:   "List the package revisions recorded for a function",
+		RunE:    wrapper.Wrapper(History),
+	}
+	wrapper.SetFlags(historyCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	scaleCmd := &cobra.Command{
+		Use:     "scale",
+		Aliases: []string{},
+		Short:   "Update the min/max scale of a newdeploy or container function",
+		RunE:    wrapper.Wrapper(Scale),
+	}
+	wrapper.SetFlags(scaleCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnScaleMin, flag.FnScaleMax, flag.NamespaceFunction},
+	})
+
 	getmetaCmd := &cobra.Command{
 		Use:     "getmeta",
 		Aliases: []string{},
@@ -89,12 +160,13 @@ func Commands() *cobra.Command {
 			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
 			flag.FnIdleTimeout, flag.FnConcurrency, flag.FnRequestsPerPod,
 			flag.FnOnceOnly, flag.Labels, flag.Annotation,
+			flag.FnRevisionHistoryLimit, flag.FnSpecFile, flag.FnConcurrencyModel,
 
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure,
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgChunkSize,
 			flag.FnBuildCmd, flag.PkgForce,
 
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
 			flag.RunTimeTargetCPU,
 
@@ -110,7 +182,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(deleteCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.FnName},
-		Optional: []flag.Flag{flag.NamespaceFunction},
+		Optional: []flag.Flag{flag.FnDeleteWithTriggers, flag.NamespaceFunction},
 	})
 
 	listCmd := &cobra.Command{
@@ -121,7 +193,8 @@ func Commands() *cobra.Command {
 		RunE:    wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceFunction},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.FnListOutput, flag.FnListLabelSelector,
+			flag.FnListSortBy, flag.FnListReverse, flag.FnListPrometheus},
 	})
 
 	logsCmd := &cobra.Command{
@@ -134,7 +207,8 @@ func Commands() *cobra.Command {
 		Required: []flag.Flag{flag.FnName},
 		Optional: []flag.Flag{
 			flag.FnLogFollow, flag.FnLogReverseQuery, flag.FnLogCount,
-			flag.FnLogDetail, flag.FnLogPod, flag.NamespaceFunction, flag.FnLogDBType},
+			flag.FnLogDetail, flag.FnLogPod, flag.NamespaceFunction, flag.FnLogDBType,
+			flag.FnLogInvocationID, flag.FnLogLevel, flag.FnLogSince, flag.FnLogUntil},
 	})
 
 	testCmd := &cobra.Command{
@@ -145,8 +219,9 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(testCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.FnName},
-		Optional: []flag.Flag{flag.HtMethod, flag.FnTestHeader, flag.FnTestBody,
-			flag.FnTestQuery, flag.FnTestTimeout, flag.NamespaceFunction,
+		Optional: []flag.Flag{flag.HtMethod, flag.FnTestHeader, flag.FnTestBody, flag.FnTestBodyFile, flag.FnTestContentType,
+			flag.FnTestQuery, flag.FnTestIgnoreError, flag.FnTestTimeout, flag.FnTestStream, flag.FnTestWebSocket, flag.NamespaceFunction,
+			flag.FnTestRouterNamespace, flag.FnTestTiming,
 			// for getting log from log database if
 			// we failed to get logs from function pod.
 			flag.FnLogDBType,
@@ -170,7 +245,7 @@ func Commands() *cobra.Command {
 			flag.Labels, flag.Annotation,
 
 			// flag for newdeploy to use.
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
 
@@ -193,7 +268,7 @@ func Commands() *cobra.Command {
 			flag.FnExecutionTimeout, flag.FnIdleTimeout,
 			flag.Labels, flag.Annotation,
 
-			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
+			flag.RunTimeRequest, flag.RunTimeLimit, flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
 			flag.RunTimeTargetCPU,
 
@@ -213,13 +288,375 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceFunction},
 	})
 
+	canaryCmd := &cobra.Command{
+		Use:     "canary",
+		Aliases: []string{},
+		Short:   "Split the traffic of a function between it and a new version",
+		Long:    "Split the traffic of the HTTP trigger of a function between the function and a new version of it. With --increment the weight of the new version is increased every --interval and rolled back if its error rate exceeds --max-error-rate.",
+		RunE:    wrapper.Wrapper(Canary),
+	}
+	wrapper.SetFlags(canaryCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnCanaryNewVersion},
+		Optional: []flag.Flag{flag.FnCanaryWeight, flag.FnCanaryIncrement, flag.FnCanaryInterval,
+			flag.FnCanaryMaxErrorRate, flag.FnCanaryTrigger, flag.NamespaceFunction},
+	})
+
+	copyCmd := &cobra.Command{
+		Use:     "copy",
+		Aliases: []string{"cp"},
+		Short:   "Copy a function to a new function",
+		Long:    "Copy a function and its package to a new function. The environment and entrypoint can be changed with --env and --entrypoint; a source package is rebuilt if the environment changes.",
+		RunE:    wrapper.Wrapper(Copy),
+	}
+	wrapper.SetFlags(copyCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnCopyFrom, flag.FnCopyTo},
+		Optional: []flag.Flag{flag.FnEnvName, flag.FnEntryPoint, flag.NamespaceFunction, flag.NamespaceEnvironment},
+	})
+
+	copyTriggerCmd := &cobra.Command{
+		Use:   "copy-trigger",
+		Short: "Copy the triggers of a function to another function",
+		Long:  "Create copies of the time triggers, message queue triggers and kube watchers of a function that invoke another function, e.g. for a blue/green deployment. HTTP triggers are only moved, with --swap, since a URL routes to a single function. With --swap the triggers of the source function are deleted once all of them were copied. If a copy fails, the copies already made are reverted.",
+		RunE:  wrapper.Wrapper(CopyTrigger),
+	}
+	wrapper.SetFlags(copyTriggerCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnCopyTriggerFrom, flag.FnCopyTriggerTo},
+		Optional: []flag.Flag{flag.FnCopyTriggerSwap, flag.NamespaceFunction},
+	})
+
+	benchmarkCmd := &cobra.Command{
+		Use:     "benchmark",
+		Aliases: []string{"bench"},
+		Short:   "Load test a function",
+		Long:    "Send requests to a function through the router at a fixed rate and report the p50/p90/p99 latency, error rate and number of cold starts.",
+		RunE:    wrapper.Wrapper(Benchmark),
+	}
+	wrapper.SetFlags(benchmarkCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnBenchmarkRPS, flag.FnBenchmarkDuration, flag.HtMethod, flag.FnTestHeader,
+			flag.FnTestBody, flag.FnTestTimeout, flag.FnSubPath, flag.FnBenchmarkOutput, flag.NamespaceFunction},
+	})
+
+	traceCmd := &cobra.Command{
+		Use:     "trace",
+		Aliases: []string{},
+		Short:   "Invoke a function and print its trace",
+		Long:    "Invoke a function through the router with a trace context set by the CLI, then fetch the trace from Jaeger or Tempo and print the spans as a tree. Waits up to 30 seconds for the trace to be exported.",
+		RunE:    wrapper.Wrapper(Trace),
+	}
+	wrapper.SetFlags(traceCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnTraceBackend, flag.HtMethod, flag.FnTestHeader, flag.FnTestBody,
+			flag.FnTestTimeout, flag.FnSubPath, flag.NamespaceFunction},
+	})
+
+	coldStartCmd := &cobra.Command{
+		Use:     "cold-start-time",
+		Aliases: []string{},
+		Short:   "Measure the cold start latency of a function",
+		Long:    "Remove the warm pods of a function before each request and measure the time to the first byte of the response. Works with poolmgr and newdeploy functions; the replicas of newdeploy functions are restored afterwards.",
+		RunE:    wrapper.Wrapper(ColdStartTime),
+	}
+	wrapper.SetFlags(coldStartCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnColdStartSamples, flag.FnTestTimeout, flag.FnSubPath, flag.NamespaceFunction},
+	})
+
+	rateLimitCmd := &cobra.Command{
+		Use:     "rate-limit",
+		Aliases: []string{},
+		Short:   "Set the rate limit of a function",
+		Long:    "Set the maximum number of requests per second the router forwards to a function. Requests above the limit are rejected with 429 Too Many Requests.",
+		RunE:    wrapper.Wrapper(RateLimit),
+	}
+	wrapper.SetFlags(rateLimitCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnRateLimitRPS},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	concurrencyCmd := &cobra.Command{
+		Use:     "concurrency",
+		Aliases: []string{},
+		Short:   "Set the number of in-flight requests per pod of a function",
+		Long:    "Set the maximum number of requests a specialized pod of a poolmgr function serves at the same time. Once all pods are busy, the executor specializes a new pod, up to --concurrency pods, instead of queuing requests on a busy one. Functions of other executor types are scaled by their autoscaler and are rejected.",
+		RunE:    wrapper.Wrapper(Concurrency),
+	}
+	wrapper.SetFlags(concurrencyCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnMaxConcurrency},
+		Optional: []flag.Flag{flag.FnConcurrency, flag.NamespaceFunction},
+	})
+
+	topCmd := &cobra.Command{
+		Use:     "top",
+		Aliases: []string{},
+		Short:   "Display CPU and memory usage of function pods",
+		Long:    "Display the CPU and memory usage of the pods of each function, summed per function, with the change since the previous refresh. Functions of all namespaces are shown unless --fnNamespace is given. Usage is read from the Kubernetes metrics API, which requires metrics-server.",
+		RunE:    wrapper.Wrapper(Top),
+	}
+	wrapper.SetFlags(topCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.FnTopInterval, flag.NamespaceFunction},
+	})
+
+	cloneCmd := &cobra.Command{
+		Use:     "clone",
+		Aliases: []string{},
+		Short:   "Copy a function to another Fission cluster",
+		Long:    "Copy a function with its package to another Fission cluster, e.g. to promote it from staging to production. Archives in the storage service are downloaded and uploaded to the target cluster, and the environment of the function is created there if it doesn't exist yet. The function keeps its name and namespace.",
+		RunE:    wrapper.Wrapper(Clone),
+	}
+	wrapper.SetFlags(cloneCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnCloneTargetServer},
+		Optional: []flag.Flag{flag.FnCloneWithTriggers, flag.NamespaceFunction},
+	})
+
+	exportMetricsCmd := &cobra.Command{
+		Use:     "export-metrics",
+		Aliases: []string{},
+		Short:   "Print Prometheus recording rules for a function",
+		Long:    "Print a Prometheus recording rules file that pre-computes the p50 and p99 latency, throughput and error rate of a function from the router metrics, e.g. 'fission fn export-metrics --name foo > foo-rules.yaml' and add the file to the rule_files of Prometheus.",
+		RunE:    wrapper.Wrapper(ExportMetrics),
+	}
+	wrapper.SetFlags(exportMetricsCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnExportMetricsOutput, flag.NamespaceFunction},
+	})
+
+	verifySignatureCmd := &cobra.Command{
+		Use:     "verify-signature",
+		Aliases: []string{},
+		Short:   "Check the archives of a function against their stored checksum",
+		Long:    "Download the deployment and source archives of the package of a function, compute their SHA256 checksum and compare it with the checksum stored in the package. Prints OK for each matching archive and the expected and actual checksum of mismatching ones, and exits with an error if any archive doesn't match or has no stored checksum.",
+		RunE:    wrapper.Wrapper(VerifySignature),
+	}
+	wrapper.SetFlags(verifySignatureCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	usageCmd := &cobra.Command{
+		Use:     "usage",
+		Aliases: []string{},
+		Short:   "Report the invocations, errors and bandwidth of a function",
+		Long:    "Report the number of invocations, errors and response bytes of a function over a period, read from the router metrics in Prometheus. With --compare, the period of the same length that long before is reported next to it with the percentage change.",
+		RunE:    wrapper.Wrapper(Usage),
+	}
+	wrapper.SetFlags(usageCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnUsageSince, flag.FnUsageCompare, flag.FnUsagePrometheus, flag.NamespaceFunction},
+	})
+
+	accessLogCmd := &cobra.Command{
+		Use:     "access-log",
+		Aliases: []string{},
+		Short:   "Stream the router access log of a function",
+		Long:    "Stream the requests the routers forward to a function with timestamp, method, path, status, latency and response size, until interrupted. Unlike 'fission fn log', which shows the logs of the function pods, this shows the requests as seen by the router. Each router replica only logs the requests it forwards itself, so the logs of all router pods running when the command starts are merged; router pods started later are not followed.",
+		RunE:    wrapper.Wrapper(AccessLog),
+	}
+	wrapper.SetFlags(accessLogCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnAccessLogFormat, flag.NamespaceFunction},
+	})
+
+	invokeAsyncCmd := &cobra.Command{
+		Use:     "invoke-async",
+		Aliases: []string{},
+		Short:   "Invoke a function asynchronously",
+		Long:    "Invoke a function asynchronously through the router and print the ID of the job. Use 'fission fn async-status --job <id>' to get the result.",
+		RunE:    wrapper.Wrapper(InvokeAsync),
+	}
+	wrapper.SetFlags(invokeAsyncCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnTestHeader, flag.FnTestBody, flag.FnTestBodyFile, flag.FnTestContentType, flag.NamespaceFunction},
+	})
+
+	asyncStatusCmd := &cobra.Command{
+		Use:     "async-status",
+		Aliases: []string{},
+		Short:   "Wait for an asynchronous invocation and print the result",
+		Long:    "Poll the status of a job started with 'fission fn invoke-async' until it completes or --timeout expires, and print the response of the function.",
+		RunE:    wrapper.Wrapper(AsyncStatus),
+	}
+	wrapper.SetFlags(asyncStatusCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnAsyncJob},
+		Optional: []flag.Flag{flag.FnTestTimeout},
+	})
+
+	replicasCmd := &cobra.Command{
+		Use:     "replicas",
+		Aliases: []string{},
+		Short:   "Show the desired and running replicas of a function",
+		Long:    "Show the desired, ready, available and unavailable replicas of the deployments serving a newdeploy or container function together with its autoscaler, or the pool pods specialized for a poolmgr function.",
+		RunE:    wrapper.Wrapper(Replicas),
+	}
+	wrapper.SetFlags(replicasCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	debugCmd := &cobra.Command{
+		Use:     "debug",
+		Aliases: []string{},
+		Short:   "Attach a Delve debugger to a container function",
+		Long:    "Restart the pods of a container function with the function binary running under a headless Delve server, forward the Delve port to localhost and print the 'dlv connect' command. The image must contain the dlv binary. Press Ctrl-C to restore the original pod spec.",
+		RunE:    wrapper.Wrapper(Debug),
+	}
+	wrapper.SetFlags(debugCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnDebugPort, flag.FnDebugExec, flag.NamespaceFunction},
+	})
+
+	watchCmd := &cobra.Command{
+		Use:     "watch",
+		Aliases: []string{},
+		Short:   "Re-deploy a function whenever local files change",
+		Long:    "Watch a local directory and, whenever a file changes, zip the directory, upload it as the new archive of the function package and print how long the update took.",
+		RunE:    wrapper.Wrapper(Watch),
+	}
+	wrapper.SetFlags(watchCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnWatchDir, flag.FnWatchFilter, flag.NamespaceFunction},
+	})
+
+	cpuProfileCmd := &cobra.Command{
+		Use:     "cpu-profile",
+		Aliases: []string{},
+		Short:   "Collect a CPU profile from a running Go function",
+		Long:    "Collect a pprof CPU profile from the net/http/pprof endpoint of a running pod of a Go function through the Kubernetes API server and save it to a file.",
+		RunE:    wrapper.Wrapper(CPUProfile),
+	}
+	wrapper.SetFlags(cpuProfileCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnProfileDuration, flag.FnProfileOutput, flag.FnProfileInteractive, flag.FnPort, flag.NamespaceFunction},
+	})
+
+	eventTestCmd := &cobra.Command{
+		Use:     "event-test",
+		Aliases: []string{},
+		Short:   "Send a Kubernetes watch event to a function",
+		Long:    "Send a Kubernetes watch event read from a file to a function through the router, with the same body and headers a watch trigger uses, and print the response.",
+		RunE:    wrapper.Wrapper(EventTest),
+	}
+	wrapper.SetFlags(eventTestCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnEventFile},
+		Optional: []flag.Flag{flag.FnTestTimeout, flag.NamespaceFunction},
+	})
+
+	archiveDownloadCmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download the deployment archive of a function",
+		Long:  "Download the deployment archive of the package a function is running, e.g. to inspect what a CI pipeline built and deployed.",
+		RunE:  wrapper.Wrapper(ArchiveDownload),
+	}
+	wrapper.SetFlags(archiveDownloadCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.FnArchiveOutput, flag.FnArchiveUnzip, flag.NamespaceFunction},
+	})
+
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Manage the archive of a function",
+	}
+	archiveCmd.AddCommand(archiveDownloadCmd)
+
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare a local file with the deployed code of a function",
+		Long:  "Compare a local file with the file of the same name in the deployment archive of a function and print a unified diff.",
+		RunE:  wrapper.Wrapper(Diff),
+	}
+	wrapper.SetFlags(diffCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnDiffLocal},
+		Optional: []flag.Flag{flag.FnDiffExitCode, flag.NamespaceFunction},
+	})
+
+	grepCmd := &cobra.Command{
+		Use:   "grep",
+		Short: "Search the deployed code of all functions",
+		Long:  "Search the files in the deployment archives of the functions in a namespace for a regular expression and print the function, file, line number and matching line of each match, like 'grep -rn'. Binary files and functions running container images are skipped.",
+		RunE:  wrapper.Wrapper(Grep),
+	}
+	wrapper.SetFlags(grepCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnGrepPattern},
+		Optional: []flag.Flag{flag.FnGrepEnv, flag.NamespaceFunction},
+	})
+
+	annotationsGetCmd := &cobra.Command{
+		Use:   "get",
+		Short: "Print the annotations of a function",
+		RunE:  wrapper.Wrapper(AnnotationsGet),
+	}
+	wrapper.SetFlags(annotationsGetCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	annotationsSetCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set an annotation of a function",
+		Long:  "Set an annotation of a function. Only the annotation is patched, the spec of the function is left untouched.",
+		RunE:  wrapper.Wrapper(AnnotationsSet),
+	}
+	wrapper.SetFlags(annotationsSetCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnAnnotationKey, flag.FnAnnotationValue},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	annotationsDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Remove an annotation of a function",
+		RunE:  wrapper.Wrapper(AnnotationsDelete),
+	}
+	wrapper.SetFlags(annotationsDeleteCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnAnnotationKey},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	annotationsCmd := &cobra.Command{
+		Use:   "annotations",
+		Short: "Manage the annotations of a function",
+	}
+	annotationsCmd.AddCommand(annotationsGetCmd, annotationsSetCmd, annotationsDeleteCmd)
+
+	setEnvVarCmd := &cobra.Command{
+		Use:   "set-env-var",
+		Short: "Set a runtime environment variable of a function",
+		Long:  "Set an environment variable in the container of a function. Only the runtime env of the function is patched. This is only supported by functions with the newdeploy or container executor.",
+		RunE:  wrapper.Wrapper(SetEnvVar),
+	}
+	wrapper.SetFlags(setEnvVarCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnEnvVarKey, flag.FnEnvVarValue},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	unsetEnvVarCmd := &cobra.Command{
+		Use:   "unset-env-var",
+		Short: "Remove a runtime environment variable of a function",
+		RunE:  wrapper.Wrapper(UnsetEnvVar),
+	}
+	wrapper.SetFlags(unsetEnvVarCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnEnvVarKey},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	specExampleCmd := &cobra.Command{
+		Use:     "spec-example",
+		Aliases: []string{},
+		Short:   "Print an example function spec file for --spec-file",
+		RunE:    wrapper.Wrapper(SpecExample),
+	}
+
 	command := &cobra.Command{
 		Use:     "function",
 		Aliases: []string{"fn"},
 		Short:   "Create, update and manage functions",
 	}
 	command.AddCommand(createCmd, getCmd, getmetaCmd, updateCmd, deleteCmd, listCmd, logsCmd, testCmd,
-		runContainerCmd, updateContainerCmd, listPodsCmd)
+		runContainerCmd, updateContainerCmd, listPodsCmd, exportCmd, importCmd, importLambdaCmd, rollbackCmd, historyCmd, scaleCmd,
+		specExampleCmd, canaryCmd, copyCmd, benchmarkCmd, traceCmd, archiveCmd, diffCmd,
+		coldStartCmd, rateLimitCmd, accessLogCmd, eventTestCmd, annotationsCmd,
+		invokeAsyncCmd, asyncStatusCmd, replicasCmd, debugCmd, cpuProfileCmd,
+		watchCmd, concurrencyCmd, topCmd, grepCmd, copyTriggerCmd,
+		usageCmd, verifySignatureCmd, exportMetricsCmd, cloneCmd, setEnvVarCmd, unsetEnvVarCmd)
 
 	return command
 }
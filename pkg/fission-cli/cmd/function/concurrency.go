@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type ConcurrencySubCommand struct {
+	cmd.CommandActioner
+}
+
+// Concurrency sets the number of requests a specialized pod of a
+// function serves at the same time, and optionally the maximum number
+// of pods, leaving the rest of the function spec untouched.
+func Concurrency(input cli.Input) error {
+	return (&ConcurrencySubCommand{}).do(input)
+}
+
+func (opts *ConcurrencySubCommand) do(input cli.Input) error {
+	requestsPerPod := input.Int(flagkey.FnMaxConcurrency)
+	if requestsPerPod <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.FnMaxConcurrency)
+	}
+	if input.IsSet(flagkey.FnConcurrency) && input.Int(flagkey.FnConcurrency) <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.FnConcurrency)
+	}
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	// newdeploy and container functions spread requests over the pods
+	// of a deployment scaled by the autoscaler, which ignores the limit
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypePoolmgr {
+		return errors.Errorf("function '%v' uses executor type %v, --%v is only supported for %v functions",
+			fn.ObjectMeta.Name, fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType, flagkey.FnMaxConcurrency, fv1.ExecutorTypePoolmgr)
+	}
+
+	fn.Spec.RequestsPerPod = requestsPerPod
+	if input.IsSet(flagkey.FnConcurrency) {
+		fn.Spec.Concurrency = input.Int(flagkey.FnConcurrency)
+	}
+	_, err = opts.Client().V1().Function().Update(fn)
+	if err != nil {
+		return errors.Wrap(err, "error updating function")
+	}
+
+	maxPods := fn.Spec.Concurrency
+	if maxPods == 0 {
+		maxPods = DEFAULT_CONCURRENCY
+	}
+	fmt.Printf("Pods of function '%v' serve up to %v requests at a time, scaling up to %v pods\n",
+		fn.ObjectMeta.Name, requestsPerPod, maxPods)
+	return nil
+}
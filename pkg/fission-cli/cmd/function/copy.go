@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type CopySubCommand struct {
+	cmd.CommandActioner
+	function *fv1.Function
+	pkg      *fv1.Package
+}
+
+// Copy clones a function under a new name. The package is duplicated
+// with the same archives, so that updating one function doesn't affect
+// the other.
+func Copy(input cli.Input) error {
+	return (&CopySubCommand{}).do(input)
+}
+
+func (opts *CopySubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CopySubCommand) complete(input cli.Input) error {
+	from := input.String(flagkey.FnCopyFrom)
+	to := input.String(flagkey.FnCopyTo)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+
+	if from == to {
+		return errors.New("source and target function must have different names")
+	}
+
+	src, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      from,
+		Namespace: fnNamespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting source function")
+	}
+
+	existing, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      to,
+		Namespace: fnNamespace,
+	})
+	if err != nil && !ferror.IsNotFound(err) {
+		return err
+	} else if existing != nil {
+		return errors.Errorf("function '%v' already exists", to)
+	}
+
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      to,
+			Namespace: fnNamespace,
+		},
+		Spec: *src.Spec.DeepCopy(),
+	}
+	// the copy isn't part of the spec of the source function, and the
+	// revisions belong to the source function
+	spec.CopyUnmanagedMeta(&fn.ObjectMeta, &src.ObjectMeta)
+	delete(fn.ObjectMeta.Annotations, REVISION_HISTORY_ANNOTATION)
+
+	envChanged := false
+	if input.IsSet(flagkey.FnEnvironmentName) && input.String(flagkey.FnEnvironmentName) != fn.Spec.Environment.Name {
+		fn.Spec.Environment.Name = input.String(flagkey.FnEnvironmentName)
+		envChanged = true
+	}
+	if input.IsSet(flagkey.NamespaceEnvironment) && input.String(flagkey.NamespaceEnvironment) != fn.Spec.Environment.Namespace {
+		fn.Spec.Environment.Namespace = input.String(flagkey.NamespaceEnvironment)
+		envChanged = true
+	}
+	if input.IsSet(flagkey.FnEntrypoint) {
+		fn.Spec.Package.FunctionName = input.String(flagkey.FnEntrypoint)
+	}
+	opts.function = fn
+
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+		return nil
+	}
+
+	srcPkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      src.Spec.Package.PackageRef.Name,
+		Namespace: src.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting package of source function")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "error generating uuid")
+	}
+
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v-%v", to, id.String()),
+			Namespace: fnNamespace,
+		},
+		Spec: *srcPkg.Spec.DeepCopy(),
+		Status: fv1.PackageStatus{
+			BuildStatus:         srcPkg.Status.BuildStatus,
+			BuildLog:            srcPkg.Status.BuildLog,
+			LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+		},
+	}
+	pkg.Spec.Environment = fn.Spec.Environment
+	// a source archive has to be built again with the builder of the new environment
+	if envChanged && len(pkg.Spec.Source.Type) > 0 && (len(pkg.Spec.Source.URL) > 0 || len(pkg.Spec.Source.Literal) > 0) {
+		pkg.Spec.Deployment = fv1.Archive{}
+		pkg.Status.BuildStatus = fv1.BuildStatusPending
+		pkg.Status.BuildLog = ""
+	}
+	opts.pkg = pkg
+
+	return nil
+}
+
+func (opts *CopySubCommand) run(input cli.Input) error {
+	if opts.pkg != nil {
+		pkgMeta, err := opts.Client().V1().Package().Create(opts.pkg)
+		if err != nil {
+			return errors.Wrap(err, "error creating package")
+		}
+		opts.function.Spec.Package.PackageRef = fv1.PackageRef{
+			Namespace:       pkgMeta.Namespace,
+			Name:            pkgMeta.Name,
+			ResourceVersion: pkgMeta.ResourceVersion,
+		}
+	}
+
+	_, err := opts.Client().V1().Function().Create(opts.function)
+	if err != nil {
+		if opts.pkg != nil {
+			// don't leave the copied package behind
+			_ = opts.Client().V1().Package().Delete(&metav1.ObjectMeta{
+				Name:      opts.function.Spec.Package.PackageRef.Name,
+				Namespace: opts.function.Spec.Package.PackageRef.Namespace,
+			})
+		}
+		return errors.Wrap(err, "error creating function")
+	}
+
+	fmt.Printf("Function '%v' copied to '%v'\n", input.String(flagkey.FnCopyFrom), opts.function.ObjectMeta.Name)
+	return nil
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type CopyTriggerSubCommand struct {
+	cmd.CommandActioner
+	from      string
+	to        string
+	namespace string
+	swap      bool
+	copies    []triggerCopy
+	skipped   int
+}
+
+// triggerCopy copies one trigger of the source function. apply creates
+// the copy, or points an HTTP trigger at the target function, and undo
+// reverts it. With --swap, remove deletes the source trigger once all
+// triggers were copied.
+type triggerCopy struct {
+	kind   string
+	name   string
+	apply  func() (string, error)
+	undo   func() error
+	remove func() error
+}
+
+// CopyTrigger duplicates the triggers of a function for another
+// function. With --swap the triggers of the source function are
+// removed afterwards, so all events go to the new function. If a copy
+// fails, the copies made so far are reverted.
+func CopyTrigger(input cli.Input) error {
+	return (&CopyTriggerSubCommand{}).do(input)
+}
+
+func (opts *CopyTriggerSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CopyTriggerSubCommand) complete(input cli.Input) error {
+	opts.from = input.String(flagkey.FnCopyTriggerFrom)
+	opts.to = input.String(flagkey.FnCopyTriggerTo)
+	opts.namespace = input.String(flagkey.NamespaceFunction)
+	opts.swap = input.Bool(flagkey.FnCopyTriggerSwap)
+
+	if opts.from == opts.to {
+		return errors.New("source and target function must have different names")
+	}
+	_, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      opts.to,
+		Namespace: opts.namespace,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", opts.to)
+	}
+
+	// prepare all copies first, so nothing is changed if a trigger
+	// can't be listed
+	err = opts.prepareHTTPTriggers()
+	if err != nil {
+		return err
+	}
+	err = opts.prepareTimeTriggers()
+	if err != nil {
+		return err
+	}
+	err = opts.prepareMQTriggers()
+	if err != nil {
+		return err
+	}
+	return opts.prepareKubeWatchers()
+}
+
+func (opts *CopyTriggerSubCommand) run(input cli.Input) error {
+	if len(opts.copies) == 0 {
+		if opts.skipped > 0 {
+			fmt.Printf("No triggers of function '%v' copied, %v HTTP trigger(s) skipped\n", opts.from, opts.skipped)
+		} else {
+			fmt.Printf("Function '%v' has no triggers to copy\n", opts.from)
+		}
+		return nil
+	}
+
+	for i, c := range opts.copies {
+		msg, err := c.apply()
+		if err != nil {
+			err = errors.Wrapf(err, "error copying %v '%v'", c.kind, c.name)
+			return opts.revert(opts.copies[:i], err)
+		}
+		fmt.Println(msg)
+	}
+
+	if !opts.swap {
+		return nil
+	}
+	for _, c := range opts.copies {
+		if c.remove == nil {
+			continue
+		}
+		err := c.remove()
+		if err != nil {
+			return errors.Wrapf(err, "error deleting %v '%v', the triggers were copied but it and the source triggers after it are kept",
+				c.kind, c.name)
+		}
+		fmt.Printf("%v '%v' deleted\n", c.kind, c.name)
+	}
+	return nil
+}
+
+// revert undoes the given copies after a copy failed. Copies which
+// can't be reverted are reported in the returned error.
+func (opts *CopyTriggerSubCommand) revert(copies []triggerCopy, cause error) error {
+	var errs *multierror.Error
+	errs = multierror.Append(errs, cause)
+	for i := len(copies) - 1; i >= 0; i-- {
+		c := copies[i]
+		err := c.undo()
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "error reverting the copy of %v '%v'", c.kind, c.name))
+			continue
+		}
+		fmt.Printf("copy of %v '%v' reverted\n", c.kind, c.name)
+	}
+	return errs.ErrorOrNil()
+}
+
+// prepareHTTPTriggers points the HTTP triggers of the source function at
+// the target function with --swap. They can't be copied, as the router
+// sends the requests for a URL and method to a single function.
+func (opts *CopyTriggerSubCommand) prepareHTTPTriggers() error {
+	hts, err := opts.Client().V1().HTTPTrigger().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing HTTP triggers")
+	}
+	for i := range hts {
+		ht := hts[i]
+		if !opts.matches(ht.Spec.FunctionReference) {
+			continue
+		}
+		if !opts.swap {
+			console.Warn(fmt.Sprintf("HTTP trigger '%v' not copied, its URL can only route to one function, use --%v to point it at '%v'",
+				ht.ObjectMeta.Name, flagkey.FnCopyTriggerSwap, opts.to))
+			opts.skipped++
+			continue
+		}
+		point := func(fnName string) error {
+			ht.Spec.FunctionReference.Name = fnName
+			m, err := opts.Client().V1().HTTPTrigger().Update(&ht)
+			if err != nil {
+				return err
+			}
+			ht.ObjectMeta.ResourceVersion = m.ResourceVersion
+			return nil
+		}
+		opts.copies = append(opts.copies, triggerCopy{
+			kind: "HTTP trigger",
+			name: ht.ObjectMeta.Name,
+			apply: func() (string, error) {
+				return fmt.Sprintf("HTTP trigger '%v' now invokes '%v'", ht.ObjectMeta.Name, opts.to), point(opts.to)
+			},
+			undo: func() error {
+				return point(opts.from)
+			},
+		})
+	}
+	return nil
+}
+
+func (opts *CopyTriggerSubCommand) prepareTimeTriggers() error {
+	tts, err := opts.Client().V1().TimeTrigger().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing time triggers")
+	}
+	for i := range tts {
+		tt := tts[i]
+		if !opts.matches(tt.Spec.FunctionReference) {
+			continue
+		}
+		copied := &fv1.TimeTrigger{Spec: *tt.Spec.DeepCopy()}
+		copied.ObjectMeta, err = copyTriggerMeta(&tt.ObjectMeta)
+		if err != nil {
+			return err
+		}
+		copied.Spec.FunctionReference.Name = opts.to
+		opts.copies = append(opts.copies, triggerCopy{
+			kind: "time trigger",
+			name: tt.ObjectMeta.Name,
+			apply: func() (string, error) {
+				_, err := opts.Client().V1().TimeTrigger().Create(copied)
+				return fmt.Sprintf("time trigger '%v' copied to '%v'", tt.ObjectMeta.Name, copied.ObjectMeta.Name), err
+			},
+			undo: func() error {
+				return opts.Client().V1().TimeTrigger().Delete(&copied.ObjectMeta)
+			},
+			remove: func() error {
+				return opts.Client().V1().TimeTrigger().Delete(&tt.ObjectMeta)
+			},
+		})
+	}
+	return nil
+}
+
+func (opts *CopyTriggerSubCommand) prepareMQTriggers() error {
+	// without a queue type the controller returns the triggers of all namespaces
+	mqts, err := opts.Client().V1().MessageQueueTrigger().List("", opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+	for i := range mqts {
+		mqt := mqts[i]
+		if mqt.ObjectMeta.Namespace != opts.namespace || !opts.matches(mqt.Spec.FunctionReference) {
+			continue
+		}
+		copied := &fv1.MessageQueueTrigger{Spec: *mqt.Spec.DeepCopy()}
+		copied.ObjectMeta, err = copyTriggerMeta(&mqt.ObjectMeta)
+		if err != nil {
+			return err
+		}
+		copied.Spec.FunctionReference.Name = opts.to
+		opts.copies = append(opts.copies, triggerCopy{
+			kind: "message queue trigger",
+			name: mqt.ObjectMeta.Name,
+			apply: func() (string, error) {
+				_, err := opts.Client().V1().MessageQueueTrigger().Create(copied)
+				return fmt.Sprintf("message queue trigger '%v' copied to '%v'", mqt.ObjectMeta.Name, copied.ObjectMeta.Name), err
+			},
+			undo: func() error {
+				return opts.Client().V1().MessageQueueTrigger().Delete(&copied.ObjectMeta)
+			},
+			remove: func() error {
+				return opts.Client().V1().MessageQueueTrigger().Delete(&mqt.ObjectMeta)
+			},
+		})
+	}
+	return nil
+}
+
+func (opts *CopyTriggerSubCommand) prepareKubeWatchers() error {
+	kws, err := opts.Client().V1().KubeWatcher().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing kube watchers")
+	}
+	for i := range kws {
+		kw := kws[i]
+		if !opts.matches(kw.Spec.FunctionReference) {
+			continue
+		}
+		copied := &fv1.KubernetesWatchTrigger{Spec: *kw.Spec.DeepCopy()}
+		copied.ObjectMeta, err = copyTriggerMeta(&kw.ObjectMeta)
+		if err != nil {
+			return err
+		}
+		copied.Spec.FunctionReference.Name = opts.to
+		opts.copies = append(opts.copies, triggerCopy{
+			kind: "kube watcher",
+			name: kw.ObjectMeta.Name,
+			apply: func() (string, error) {
+				_, err := opts.Client().V1().KubeWatcher().Create(copied)
+				return fmt.Sprintf("kube watcher '%v' copied to '%v'", kw.ObjectMeta.Name, copied.ObjectMeta.Name), err
+			},
+			undo: func() error {
+				return opts.Client().V1().KubeWatcher().Delete(&copied.ObjectMeta)
+			},
+			remove: func() error {
+				return opts.Client().V1().KubeWatcher().Delete(&kw.ObjectMeta)
+			},
+		})
+	}
+	return nil
+}
+
+// matches returns true if a trigger invokes the source function by
+// name. Triggers splitting traffic between functions belong to a canary
+// deployment and are left alone.
+func (opts *CopyTriggerSubCommand) matches(ref fv1.FunctionReference) bool {
+	return ref.Type == fv1.FunctionReferenceTypeFunctionName && ref.Name == opts.from
+}
+
+// copyTriggerMeta returns the metadata for the copy of a trigger, with
+// a generated name like the one trigger create assigns.
+func copyTriggerMeta(src *metav1.ObjectMeta) (metav1.ObjectMeta, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return metav1.ObjectMeta{}, errors.Wrap(err, "error generating uuid")
+	}
+	m := metav1.ObjectMeta{
+		Name:      id.String(),
+		Namespace: src.Namespace,
+	}
+	// the copy isn't part of the spec of the source trigger
+	spec.CopyUnmanagedMeta(&m, src)
+	return m, nil
+}
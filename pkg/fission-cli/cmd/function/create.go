@@ -18,14 +18,18 @@ package function
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
-	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
@@ -56,6 +60,10 @@ func Create(input cli.Input) error {
 func (opts *CreateSubCommand) do(input cli.Input) error {
 	err := opts.complete(input)
 	if err != nil {
+		util.RecordValidationFailure(input, "Function", metav1.ObjectMeta{
+			Name:      input.String(flagkey.FnName),
+			Namespace: input.String(flagkey.NamespaceFunction),
+		}, err)
 		return err
 	}
 	return opts.run(input)
@@ -75,6 +83,16 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	specDir := util.GetSpecDir(input)
 	specIgnore := util.GetSpecIgnore(input)
 
+	// values from the spec file are used for the flags which are not set
+	fileSpec := &fv1.FunctionSpec{}
+	if input.IsSet(flagkey.FnSpecFile) {
+		fs, err := readFunctionSpecFile(input.String(flagkey.FnSpecFile))
+		if err != nil {
+			return err
+		}
+		fileSpec = fs
+	}
+
 	if !toSpec {
 		// check for unique function names within a namespace
 		fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
@@ -89,33 +107,66 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	}
 
 	entrypoint := input.String(flagkey.FnEntrypoint)
+	if len(entrypoint) == 0 {
+		entrypoint = fileSpec.Package.FunctionName
+	}
 
+	// --fntimeout is applied to the spec once it's built
 	fnTimeout := input.Int(flagkey.FnExecutionTimeout)
-	if fnTimeout <= 0 {
-		return errors.Errorf("--%v must be greater than 0", flagkey.FnExecutionTimeout)
+	if fileSpec.FunctionTimeout > 0 {
+		fnTimeout = fileSpec.FunctionTimeout
 	}
 
 	fnIdleTimeout := input.Int(flagkey.FnIdleTimeout)
+	if !input.IsSet(flagkey.FnIdleTimeout) && fileSpec.IdleTimeout != nil {
+		fnIdleTimeout = *fileSpec.IdleTimeout
+	}
 
 	fnConcurrency := DEFAULT_CONCURRENCY
 	if input.IsSet(flagkey.FnConcurrency) {
 		fnConcurrency = input.Int(flagkey.FnConcurrency)
+	} else if fileSpec.Concurrency > 0 {
+		fnConcurrency = fileSpec.Concurrency
 	}
 
 	requestsPerPod := input.Int(flagkey.FnRequestsPerPod)
+	if !input.IsSet(flagkey.FnRequestsPerPod) && fileSpec.RequestsPerPod > 0 {
+		requestsPerPod = fileSpec.RequestsPerPod
+	}
 
 	fnOnceOnly := input.Bool(flagkey.FnOnceOnly)
+	if !input.IsSet(flagkey.FnOnceOnly) {
+		fnOnceOnly = fileSpec.OnceOnly
+	}
+
+	concurrencyModel := fileSpec.ConcurrencyModel
+	if input.IsSet(flagkey.FnConcurrencyModel) {
+		concurrencyModel = fv1.ConcurrencyModel(input.String(flagkey.FnConcurrencyModel))
+	}
+	if len(concurrencyModel) > 0 {
+		err := concurrencyModel.Validate()
+		if err != nil {
+			return err
+		}
+	}
 
 	pkgName := input.String(flagkey.FnPackageName)
+	if len(pkgName) == 0 {
+		pkgName = fileSpec.Package.PackageRef.Name
+	}
 
 	secretNames := input.StringSlice(flagkey.FnSecret)
 	cfgMapNames := input.StringSlice(flagkey.FnCfgMap)
 
-	invokeStrategy, err := getInvokeStrategy(input, nil)
+	var existingStrategy *fv1.InvokeStrategy
+	if len(fileSpec.InvokeStrategy.ExecutionStrategy.ExecutorType) > 0 {
+		existingStrategy = &fileSpec.InvokeStrategy
+	}
+	invokeStrategy, err := getInvokeStrategy(input, existingStrategy)
 	if err != nil {
 		return err
 	}
-	resourceReq, err := util.GetResourceReqs(input, &apiv1.ResourceRequirements{})
+	resourceReq, err := util.GetResourceReqs(input, &fileSpec.Resources)
 	if err != nil {
 		return err
 	}
@@ -163,6 +214,29 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	} else {
 		// need to specify environment for creating new package
 		envName = input.String(flagkey.FnEnvironmentName)
+		if len(envName) == 0 && len(fileSpec.Environment.Name) > 0 {
+			envName = fileSpec.Environment.Name
+			if !input.IsSet(flagkey.NamespaceEnvironment) && len(fileSpec.Environment.Namespace) > 0 {
+				envNamespace = fileSpec.Environment.Namespace
+			}
+		}
+		if len(envName) == 0 && input.String(flagkey.PkgCode) == "-" {
+			// there is no file name to infer the environment from
+			return errors.Errorf("need --%v argument when reading the code from stdin", flagkey.FnEnvironmentName)
+		}
+		if len(envName) == 0 && input.IsSet(flagkey.PkgCode) && !toSpec {
+			envs, err := opts.Client().V1().Environment().List(envNamespace)
+			if err != nil {
+				return errors.Wrap(err, "error listing environments")
+			}
+			envName, err = inferEnvironment(envs, input.String(flagkey.PkgCode))
+			if err != nil {
+				return err
+			}
+			if len(envName) > 0 {
+				console.Infof("Using environment '%v' for %v, use --env to choose a different one", envName, input.String(flagkey.PkgCode))
+			}
+		}
 		if len(envName) == 0 {
 			return errors.New("need --env argument")
 		}
@@ -204,10 +278,20 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		var deployArchiveFiles []string
 		noZip := false
 		code := input.String(flagkey.PkgCode)
+		if code == "-" {
+			if toSpec {
+				return errors.Errorf("--%v - can't be used with --%v, the spec needs a file to refer to", flagkey.PkgCode, flagkey.SpecSave)
+			}
+			code, err = codeFromStdin(os.Stdin)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(code)
+		}
 		if len(code) == 0 {
 			deployArchiveFiles = input.StringSlice(flagkey.PkgDeployArchive)
 		} else {
-			deployArchiveFiles = append(deployArchiveFiles, input.String(flagkey.PkgCode))
+			deployArchiveFiles = append(deployArchiveFiles, code)
 			noZip = true
 		}
 		// return error when both src & deploy archive are empty
@@ -285,24 +369,38 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		}
 	}
 
+	if len(secrets) == 0 {
+		secrets = fileSpec.Secrets
+	}
+	if len(cfgmaps) == 0 {
+		cfgmaps = fileSpec.ConfigMaps
+	}
+
 	opts.function = &fv1.Function{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fnName,
 			Namespace: fnNamespace,
 		},
 		Spec: fv1.FunctionSpec{
-			Secrets:         secrets,
-			ConfigMaps:      cfgmaps,
-			Resources:       *resourceReq,
-			InvokeStrategy:  *invokeStrategy,
-			FunctionTimeout: fnTimeout,
-			IdleTimeout:     &fnIdleTimeout,
-			Concurrency:     fnConcurrency,
-			RequestsPerPod:  requestsPerPod,
-			OnceOnly:        fnOnceOnly,
+			Secrets:          secrets,
+			ConfigMaps:       cfgmaps,
+			Resources:        *resourceReq,
+			InvokeStrategy:   *invokeStrategy,
+			FunctionTimeout:  fnTimeout,
+			IdleTimeout:      &fnIdleTimeout,
+			Concurrency:      fnConcurrency,
+			RequestsPerPod:   requestsPerPod,
+			OnceOnly:         fnOnceOnly,
+			PodSpec:          fileSpec.PodSpec,
+			ConcurrencyModel: concurrencyModel,
 		},
 	}
 
+	err = applyFunctionTimeout(input, &opts.function.Spec)
+	if err != nil {
+		return err
+	}
+
 	err = util.ApplyLabelsAndAnnotations(input, &opts.function.ObjectMeta)
 	if err != nil {
 		return err
@@ -320,6 +418,46 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		},
 	}
 
+	if len(concurrencyModel) > 0 && !toSpec {
+		err = checkConcurrencyModel(opts.Client(), opts.function)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.IsSet(flagkey.FnSpecFile) {
+		return validateFunction(opts.function)
+	}
+
+	return nil
+}
+
+// checkConcurrencyModel makes sure the environment of the function
+// supports its concurrency model. A missing environment was already
+// warned about, so it is not an error here.
+func checkConcurrencyModel(client client.Interface, fn *fv1.Function) error {
+	env, err := client.V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Environment.Name,
+		Namespace: fn.Spec.Environment.Namespace,
+	})
+	if err != nil {
+		if ferror.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "error retrieving environment information")
+	}
+	if !env.Spec.SupportsConcurrencyModel(fn.Spec.ConcurrencyModel) {
+		supported := "none"
+		if len(env.Spec.SupportedConcurrencyModels) > 0 {
+			var models []string
+			for _, m := range env.Spec.SupportedConcurrencyModels {
+				models = append(models, string(m))
+			}
+			supported = strings.Join(models, ", ")
+		}
+		return errors.Errorf("environment '%v' does not support the concurrency model '%v', supported: %v",
+			env.ObjectMeta.Name, fn.Spec.ConcurrencyModel, supported)
+	}
 	return nil
 }
 
@@ -347,7 +485,21 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 
 	fmt.Printf("function '%v' created\n", opts.function.ObjectMeta.Name)
 
-	// Allow the user to specify an HTTP trigger while creating a function.
+	err = opts.createHTTPTrigger(input)
+	if err != nil {
+		return err
+	}
+
+	if input.Bool(flagkey.FnWait) {
+		return waitForFunctionReady(opts.Client(), input.String(flagkey.KubeContext),
+			&opts.function.ObjectMeta, input.Duration(flagkey.FnWaitTimeout))
+	}
+	return nil
+}
+
+// createHTTPTrigger allows the user to specify an HTTP trigger while
+// creating a function.
+func (opts *CreateSubCommand) createHTTPTrigger(input cli.Input) error {
 	triggerUrl := input.String(flagkey.HtUrl)
 	prefix := input.String(flagkey.HtPrefix)
 	if len(triggerUrl) == 0 && len(prefix) == 0 {
@@ -601,6 +753,31 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 	return strategy, nil
 }
 
+// getFunctionTimeout returns the timeout in seconds the router waits
+// for a response from the function, given with --fntimeout or
+// falling back to the given default.
+func getFunctionTimeout(input cli.Input, fallback int) (int, error) {
+	fnTimeout := fallback
+	if input.IsSet(flagkey.FnExecutionTimeout) {
+		fnTimeout = input.Int(flagkey.FnExecutionTimeout)
+	}
+	if fnTimeout <= 0 {
+		return 0, errors.Errorf("--%v must be greater than 0", flagkey.FnExecutionTimeout)
+	}
+	return fnTimeout, nil
+}
+
+// applyFunctionTimeout sets the timeout of the function spec from
+// --fntimeout, the current timeout of the spec is kept if it isn't set.
+func applyFunctionTimeout(input cli.Input, spec *fv1.FunctionSpec) error {
+	fnTimeout, err := getFunctionTimeout(input, spec.FunctionTimeout)
+	if err != nil {
+		return err
+	}
+	spec.FunctionTimeout = fnTimeout
+	return nil
+}
+
 func getTargetCPU(input cli.Input) (int, error) {
 	targetCPU := input.Int(flagkey.RuntimeTargetcpu)
 	if targetCPU <= 0 || targetCPU > 100 {
@@ -608,3 +785,61 @@ func getTargetCPU(input cli.Input) (int, error) {
 	}
 	return targetCPU, nil
 }
+
+// extensionLanguages maps source file extensions to the prefix of the
+// names environments of that language usually have.
+var extensionLanguages = map[string]string{
+	".js":   "nodejs",
+	".py":   "python",
+	".go":   "go",
+	".rb":   "ruby",
+	".java": "java",
+}
+
+// inferEnvironment returns the environment whose name starts with the
+// language of the code file. Nothing is returned for unknown extensions;
+// it is an error if no or more than one environment matches.
+func inferEnvironment(envs []fv1.Environment, code string) (string, error) {
+	lang, ok := extensionLanguages[strings.ToLower(filepath.Ext(code))]
+	if !ok {
+		return "", nil
+	}
+
+	var matches []string
+	for _, env := range envs {
+		if strings.HasPrefix(strings.ToLower(env.ObjectMeta.Name), lang) {
+			matches = append(matches, env.ObjectMeta.Name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", errors.Errorf("no %v environment found for '%v', need --env argument", lang, code)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", errors.Errorf("more than one environment matches '%v' (%v), need --env argument", code, strings.Join(matches, ", "))
+	}
+}
+
+// codeFromStdin saves the code piped in for --code - to a temporary
+// file and returns its path. It fails instead of waiting for input if
+// stdin is a terminal, as the code was most likely not piped in by
+// mistake.
+func codeFromStdin(stdin *os.File) (string, error) {
+	if isTerminal(stdin) {
+		return "", errors.Errorf("--%v - reads the code from stdin, pipe it in, e.g. 'cat hello.js | fission fn create --name hello --env nodejs --%v -'",
+			flagkey.PkgCode, flagkey.PkgCode)
+	}
+
+	f, err := os.CreateTemp("", "fission-code-*")
+	if err != nil {
+		return "", errors.Wrap(err, "error creating temporary file")
+	}
+	_, err = io.Copy(f, stdin)
+	f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "error reading code from stdin")
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const delveCommand = "dlv"
+
+type DebugSubCommand struct {
+	cmd.CommandActioner
+	function *fv1.Function
+	original *apiv1.PodSpec
+	debug    *apiv1.PodSpec
+	port     int
+}
+
+// Debug restarts the pods of a container function with the function
+// binary running under a headless Delve server, forwards the Delve port
+// to localhost and restores the original pod spec on Ctrl-C.
+func Debug(input cli.Input) error {
+	return (&DebugSubCommand{}).do(input)
+}
+
+func (opts *DebugSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *DebugSubCommand) complete(input cli.Input) (err error) {
+	opts.function, err = opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	fnName := opts.function.ObjectMeta.Name
+	if opts.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		return errors.Errorf("function '%v' doesn't run a container image, only functions created with 'fission fn run-container' can be debugged", fnName)
+	}
+	if opts.function.Spec.PodSpec == nil || len(opts.function.Spec.PodSpec.Containers) == 0 {
+		return errors.Errorf("function '%v' has no container in its pod spec", fnName)
+	}
+
+	opts.port = input.Int(flagkey.FnDebugPort)
+	if opts.port <= 0 || opts.port > 65535 {
+		return errors.Errorf("invalid --%v %v", flagkey.FnDebugPort, opts.port)
+	}
+
+	container := opts.function.Spec.PodSpec.Containers[0]
+	if len(container.Command) > 0 && container.Command[0] == delveCommand {
+		return errors.Errorf("function '%v' is already running under Delve", fnName)
+	}
+	debugContainer, err := delveContainer(container, input.String(flagkey.FnDebugExec), opts.port)
+	if err != nil {
+		return err
+	}
+
+	opts.original = opts.function.Spec.PodSpec.DeepCopy()
+	opts.debug = opts.function.Spec.PodSpec.DeepCopy()
+	opts.debug.Containers[0] = *debugContainer
+
+	return nil
+}
+
+func (opts *DebugSubCommand) run(input cli.Input) error {
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	// catch Ctrl-C before touching the function so that the
+	// original pod spec is always restored
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	fnName := opts.function.ObjectMeta.Name
+	opts.function.Spec.PodSpec = opts.debug
+	_, err = opts.Client().V1().Function().Update(opts.function)
+	if err != nil {
+		return errors.Wrap(err, "error updating function")
+	}
+	defer func() {
+		err := opts.restore()
+		if err != nil {
+			console.Error(err.Error())
+			return
+		}
+		fmt.Printf("Restored the original pod spec of function '%v'\n", fnName)
+	}()
+
+	fmt.Printf("Waiting for a pod of function '%v' running under Delve...\n", fnName)
+	selector := labels.Set{fv1.FUNCTION_UID: string(opts.function.ObjectMeta.UID)}.AsSelector().String()
+	var pod *apiv1.Pod
+	for pod == nil {
+		select {
+		case <-sig:
+			return nil
+		case <-time.After(2 * time.Second):
+		}
+
+		pods, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return errors.Wrap(err, "error listing function pods")
+		}
+		for i := range pods.Items {
+			if pods.Items[i].ObjectMeta.DeletionTimestamp == nil && isDelvePod(&pods.Items[i]) && isPodReady(&pods.Items[i]) {
+				pod = &pods.Items[i]
+				break
+			}
+		}
+	}
+
+	port := strconv.Itoa(opts.port)
+	stop := make(chan struct{})
+	defer close(stop)
+	err = util.PortForwardPod(input.String(flagkey.KubeContext), pod.ObjectMeta.Namespace, pod.ObjectMeta.Name, port, port, stop)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Delve is listening in pod %v/%v, connect with:\n\n", pod.ObjectMeta.Namespace, pod.ObjectMeta.Name)
+	fmt.Printf("    dlv connect 127.0.0.1:%v\n\n", port)
+	fmt.Printf("Press Ctrl-C to stop debugging and restore function '%v'\n", fnName)
+	<-sig
+
+	return nil
+}
+
+// restore puts back the pod spec the function had before debugging.
+// The function is fetched again since the executor may have updated
+// it in the meantime.
+func (opts *DebugSubCommand) restore() error {
+	fn, err := opts.Client().V1().Function().Get(&opts.function.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting function to restore its pod spec")
+	}
+	fn.Spec.PodSpec = opts.original
+	_, err = opts.Client().V1().Function().Update(fn)
+	if err != nil {
+		return errors.Wrap(err, "error restoring the pod spec of the function")
+	}
+	return nil
+}
+
+// delveContainer returns a copy of the container running the binary
+// under a headless Delve server. The binary replaces the first element
+// of the container command, the rest of the command and the args are
+// passed to it.
+func delveContainer(container apiv1.Container, binary string, port int) (*apiv1.Container, error) {
+	var args []string
+	if len(container.Command) > 0 {
+		if len(binary) == 0 {
+			binary = container.Command[0]
+		}
+		args = append(args, container.Command[1:]...)
+	}
+	if len(binary) == 0 {
+		return nil, errors.Errorf("container '%v' has no command, set the binary to debug with --%v", container.Name, flagkey.FnDebugExec)
+	}
+	args = append(args, container.Args...)
+
+	debug := container.DeepCopy()
+	debug.Command = []string{delveCommand, "exec", binary,
+		"--headless", fmt.Sprintf("--listen=:%v", port), "--api-version=2",
+		"--accept-multiclient", "--continue"}
+	debug.Args = nil
+	if len(args) > 0 {
+		debug.Command = append(debug.Command, "--")
+		debug.Args = args
+	}
+	debug.Ports = append(debug.Ports, apiv1.ContainerPort{
+		Name:          "dlv",
+		ContainerPort: int32(port),
+		Protocol:      apiv1.ProtocolTCP,
+	})
+	return debug, nil
+}
+
+func isDelvePod(pod *apiv1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if len(c.Command) > 0 && c.Command[0] == delveCommand {
+			return true
+		}
+	}
+	return false
+}
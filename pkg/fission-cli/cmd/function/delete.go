@@ -18,12 +18,17 @@ package function
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 )
 
@@ -41,7 +46,24 @@ func (opts *DeleteSubCommand) do(input cli.Input) error {
 		Namespace: input.String(flagkey.NamespaceFunction),
 	}
 
-	err := opts.Client().V1().Function().Delete(m)
+	triggers, err := functionTriggers(opts.Client(), m)
+	if input.Bool(flagkey.FnDeleteWithTriggers) {
+		if err != nil {
+			return err
+		}
+		err = deleteTriggers(triggers)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		// the triggers are only looked up for the warning below
+		console.Warn(fmt.Sprintf("Unable to check for triggers referencing function '%v': %v", m.Name, err))
+	} else if len(triggers) > 0 {
+		console.Warn(fmt.Sprintf("%v trigger(s) referencing function '%v' will be left orphaned, use --%v to delete them",
+			len(triggers), m.Name, flagkey.FnDeleteWithTriggers))
+	}
+
+	err = opts.Client().V1().Function().Delete(m)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("delete function '%v'", m.Name))
 	}
@@ -49,3 +71,93 @@ func (opts *DeleteSubCommand) do(input cli.Input) error {
 	fmt.Printf("function '%v' deleted\n", m.Name)
 	return nil
 }
+
+// functionTrigger is a trigger of any type referencing a function.
+type functionTrigger struct {
+	kind   string
+	meta   metav1.ObjectMeta
+	ref    fv1.FunctionReference
+	delete func(*metav1.ObjectMeta) error
+}
+
+// functionTriggers returns the triggers of all types in the namespace of
+// the function that reference it.
+func functionTriggers(client client.Interface, fnMeta *metav1.ObjectMeta) ([]functionTrigger, error) {
+	var triggers []functionTrigger
+
+	hts, err := client.V1().HTTPTrigger().List(fnMeta.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing HTTP triggers")
+	}
+	for _, t := range hts {
+		if referencesFunction(t.Spec.FunctionReference, fnMeta.Name) {
+			triggers = append(triggers, functionTrigger{"HTTP trigger", t.ObjectMeta, t.Spec.FunctionReference, client.V1().HTTPTrigger().Delete})
+		}
+	}
+
+	tts, err := client.V1().TimeTrigger().List(fnMeta.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing time triggers")
+	}
+	for _, t := range tts {
+		if referencesFunction(t.Spec.FunctionReference, fnMeta.Name) {
+			triggers = append(triggers, functionTrigger{"time trigger", t.ObjectMeta, t.Spec.FunctionReference, client.V1().TimeTrigger().Delete})
+		}
+	}
+
+	mqts, err := client.V1().MessageQueueTrigger().List("", fnMeta.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing message queue triggers")
+	}
+	for _, t := range mqts {
+		if t.ObjectMeta.Namespace == fnMeta.Namespace && referencesFunction(t.Spec.FunctionReference, fnMeta.Name) {
+			triggers = append(triggers, functionTrigger{"message queue trigger", t.ObjectMeta, t.Spec.FunctionReference, client.V1().MessageQueueTrigger().Delete})
+		}
+	}
+
+	ws, err := client.V1().KubeWatcher().List(fnMeta.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing kubernetes watch triggers")
+	}
+	for _, t := range ws {
+		if referencesFunction(t.Spec.FunctionReference, fnMeta.Name) {
+			triggers = append(triggers, functionTrigger{"kubernetes watch trigger", t.ObjectMeta, t.Spec.FunctionReference, client.V1().KubeWatcher().Delete})
+		}
+	}
+
+	return triggers, nil
+}
+
+// deleteTriggers deletes the triggers in parallel. Canary triggers that
+// also route traffic to other functions are kept, deleting them would
+// break those functions.
+func deleteTriggers(triggers []functionTrigger) error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs *multierror.Error
+	)
+	for _, t := range triggers {
+		if len(t.ref.FunctionWeights) > 1 {
+			console.Warn(fmt.Sprintf("Skipping %v '%v', it routes traffic to other functions too", t.kind, t.meta.Name))
+			continue
+		}
+
+		wg.Add(1)
+		go func(t functionTrigger) {
+			defer wg.Done()
+			err := t.delete(&t.meta)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "error deleting %v '%v'", t.kind, t.meta.Name))
+				return
+			}
+			fmt.Printf("%v '%v' deleted\n", t.kind, t.meta.Name)
+		}(t)
+	}
+	wg.Wait()
+
+	return errs.ErrorOrNil()
+}
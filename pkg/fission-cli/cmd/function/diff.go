@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+	colorReset = "\033[0m"
+)
+
+type DiffSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Diff compares a local file with the same file in the deployment
+// archive of a function and prints the differences as a unified diff.
+func Diff(input cli.Input) error {
+	return (&DiffSubCommand{}).do(input)
+}
+
+func (opts *DiffSubCommand) do(input cli.Input) error {
+	fnName := input.String(flagkey.FnName)
+	localPath := input.String(flagkey.FnDiffLocal)
+
+	local, err := os.ReadFile(localPath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading '%v'", localPath)
+	}
+
+	reader, err := openDeployArchive(opts.Client(), &metav1.ObjectMeta{
+		Name:      fnName,
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	archive, err := io.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "error downloading archive")
+	}
+
+	deployedName, deployed, err := findArchiveFile(archive, filepath.Base(localPath))
+	if err != nil {
+		return errors.Wrapf(err, "error finding '%v' in the archive of function '%v'", filepath.Base(localPath), fnName)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(deployed)),
+		B:        difflib.SplitLines(string(local)),
+		FromFile: fmt.Sprintf("%v/%v (deployed)", fnName, deployedName),
+		ToFile:   localPath,
+		Context:  3,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error computing diff")
+	}
+
+	if isTerminal(os.Stdout) {
+		diff = colorizeDiff(diff)
+	}
+	fmt.Print(diff)
+
+	if len(diff) > 0 && input.Bool(flagkey.FnDiffExitCode) {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// findArchiveFile returns the file with the given base name from a zip
+// archive. Archives that are not zipped hold the single source file of
+// the function, which is returned as is.
+func findArchiveFile(archive []byte, name string) (string, []byte, error) {
+	if !bytes.HasPrefix(archive, zipMagic) {
+		return name, archive, nil
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error reading zip archive")
+	}
+
+	var matches []*zip.File
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() && path.Base(f.Name) == name {
+			matches = append(matches, f)
+		}
+	}
+	if len(matches) == 0 {
+		return "", nil, errors.New("file not found in archive")
+	}
+	if len(matches) > 1 {
+		var names []string
+		for _, f := range matches {
+			names = append(names, f.Name)
+		}
+		return "", nil, errors.Errorf("more than one file matches: %v", strings.Join(names, ", "))
+	}
+
+	rc, err := matches[0].Open()
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error opening '%v'", matches[0].Name)
+	}
+	defer rc.Close()
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error reading '%v'", matches[0].Name)
+	}
+	return matches[0].Name, contents, nil
+}
+
+func colorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorGreen + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorRed + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = colorCyan + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	genClientset "github.com/fission/fission/pkg/generated/clientset/versioned"
+)
+
+type EnvVarSubCommand struct {
+	cmd.CommandActioner
+}
+
+// SetEnvVar sets a runtime environment variable of a function. Only the
+// runtime env of the function is patched.
+func SetEnvVar(input cli.Input) error {
+	value := input.String(flagkey.FnEnvVarValue)
+	return (&EnvVarSubCommand{}).patch(input, &value)
+}
+
+// UnsetEnvVar removes a runtime environment variable of a function.
+func UnsetEnvVar(input cli.Input) error {
+	return (&EnvVarSubCommand{}).patch(input, nil)
+}
+
+// patch updates the runtime env of the function with a JSON merge patch.
+// A nil value removes the variable.
+func (opts *EnvVarSubCommand) patch(input cli.Input, value *string) error {
+	fnName := input.String(flagkey.FnName)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+	key := input.String(flagkey.FnEnvVarKey)
+
+	if len(key) == 0 {
+		return errors.New("environment variable name cannot be empty")
+	}
+	if value != nil && strings.Contains(*value, "\n") {
+		console.Warn(fmt.Sprintf("The value of environment variable '%v' contains a newline", key))
+	}
+
+	restConfig, _, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	fissionClient, err := genClientset.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "error creating fission client")
+	}
+
+	fn, err := fissionClient.CoreV1().Functions(fnNamespace).Get(context.Background(), fnName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", fnName)
+	}
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypePoolmgr {
+		console.Warn(fmt.Sprintf("Function '%v' uses the poolmgr executor, runtime environment variables are only set by the newdeploy and container executors", fnName))
+	}
+
+	runtimeEnv, found := setEnvVar(fn.Spec.RuntimeEnv, key, value)
+	if value == nil && !found {
+		return errors.Errorf("environment variable '%v' is not set on function '%v'", key, fnName)
+	}
+
+	patch, err := envVarPatch(fn.ObjectMeta.ResourceVersion, runtimeEnv)
+	if err != nil {
+		return err
+	}
+
+	_, err = fissionClient.CoreV1().Functions(fnNamespace).Patch(context.Background(), fnName,
+		types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error patching runtime env of function '%v'", fnName)
+	}
+
+	if value == nil {
+		fmt.Printf("Environment variable '%v' removed from function '%v'\n", key, fnName)
+	} else {
+		fmt.Printf("Environment variable '%v' of function '%v' set\n", key, fnName)
+	}
+	return nil
+}
+
+// setEnvVar returns a copy of env with the variable key set to value, or
+// removed if value is nil. found reports whether key was in env.
+func setEnvVar(env []apiv1.EnvVar, key string, value *string) (result []apiv1.EnvVar, found bool) {
+	for _, e := range env {
+		if e.Name != key {
+			result = append(result, e)
+			continue
+		}
+		found = true
+		if value != nil {
+			result = append(result, apiv1.EnvVar{Name: key, Value: *value})
+		}
+	}
+	if !found && value != nil {
+		result = append(result, apiv1.EnvVar{Name: key, Value: *value})
+	}
+	return result, found
+}
+
+// envVarPatch returns a JSON merge patch replacing the runtime env of a
+// function. A merge patch replaces lists as a whole, so the resource
+// version is included to fail on a concurrent update instead of losing it.
+func envVarPatch(resourceVersion string, env []apiv1.EnvVar) ([]byte, error) {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": resourceVersion,
+		},
+		"spec": map[string]interface{}{
+			"runtimeEnv": env,
+		},
+	}
+	return json.Marshal(patch)
+}
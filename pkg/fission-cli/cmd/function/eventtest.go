@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type EventTestSubCommand struct {
+	cmd.CommandActioner
+}
+
+// EventTest sends a Kubernetes watch event read from a file to a
+// function the same way the kubewatcher does, so functions invoked by
+// watch triggers can be tested without changing cluster resources.
+func EventTest(input cli.Input) error {
+	return (&EventTestSubCommand{}).do(input)
+}
+
+func (opts *EventTestSubCommand) do(input cli.Input) error {
+	m := &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	eventFile := input.String(flagkey.FnEventFile)
+	contents, err := os.ReadFile(eventFile)
+	if err != nil {
+		return errors.Wrapf(err, "error reading '%v'", eventFile)
+	}
+	body, headers, err := watchEventRequest(contents)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing event file '%v'", eventFile)
+	}
+
+	_, err = opts.Client().V1().Function().Get(m)
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", m.Name)
+	}
+
+	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	fnURL := "http://127.0.0.1:" + localRouterPort + util.UrlForFunction(m.Name, m.Namespace)
+	console.Verbose(2, "Function test url: %v", fnURL)
+
+	req, err := http.NewRequest(http.MethodPost, fnURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating HTTP request")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	authHeaders, err := withAuthToken(nil)
+	if err != nil {
+		return err
+	}
+	for _, header := range authHeaders {
+		kv := strings.SplitN(header, ":", 2)
+		req.Header.Set(kv[0], kv[1])
+	}
+
+	hc := &http.Client{}
+	if timeout := input.Duration(flagkey.FnTestTimeout); timeout > 0 {
+		hc.Timeout = timeout
+	}
+	start := time.Now()
+	resp, err := hc.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error executing HTTP request")
+	}
+	defer resp.Body.Close()
+	console.Verbose(2, "Function returned %v in %v", resp.Status, time.Since(start))
+
+	_, err = io.Copy(os.Stdout, resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "error reading response from function")
+	}
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("function returned status code %v", resp.StatusCode)
+	}
+	return nil
+}
+
+// watchEventRequest converts a serialized watch.Event into the body
+// and headers of the request the kubewatcher sends for it: the body is
+// the object only, the event type and the kind of the object are
+// passed as headers.
+func watchEventRequest(contents []byte) ([]byte, map[string]string, error) {
+	var event struct {
+		Type   watch.EventType `json:"type"`
+		Object json.RawMessage `json:"object"`
+	}
+	err := json.Unmarshal(contents, &event)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch event.Type {
+	case watch.Added, watch.Modified, watch.Deleted:
+	default:
+		return nil, nil, errors.Errorf("invalid event type '%v', must be one of %v, %v or %v",
+			event.Type, watch.Added, watch.Modified, watch.Deleted)
+	}
+	if len(event.Object) == 0 {
+		return nil, nil, errors.New("event has no object")
+	}
+
+	var typeMeta metav1.TypeMeta
+	err = json.Unmarshal(event.Object, &typeMeta)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing event object")
+	}
+	if len(typeMeta.Kind) == 0 {
+		return nil, nil, errors.New("event object has no kind")
+	}
+
+	var body bytes.Buffer
+	err = json.Indent(&body, event.Object, "", "    ")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error formatting event object")
+	}
+	body.WriteRune('\n')
+
+	return body.Bytes(), map[string]string{
+		"Content-Type":             "application/json",
+		"X-Kubernetes-Event-Type":  string(event.Type),
+		"X-Kubernetes-Object-Type": typeMeta.Kind,
+	}, nil
+}
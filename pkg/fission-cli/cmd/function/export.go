@@ -0,0 +1,269 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type ExportSubCommand struct {
+	cmd.CommandActioner
+	function  *fv1.Function
+	outputDir string
+}
+
+// Export generates a Helm chart containing the function together with
+// its package, environment and all the triggers referencing it.
+func Export(input cli.Input) error {
+	return (&ExportSubCommand{}).do(input)
+}
+
+func (opts *ExportSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ExportSubCommand) complete(input cli.Input) error {
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	opts.function = fn
+
+	opts.outputDir = input.String(flagkey.FnExportOutput)
+	if len(opts.outputDir) == 0 {
+		opts.outputDir = fmt.Sprintf("%v-chart", fn.ObjectMeta.Name)
+	}
+
+	if _, err := os.Stat(opts.outputDir); err == nil {
+		return errors.Errorf("output directory '%v' already exists", opts.outputDir)
+	}
+
+	return nil
+}
+
+func (opts *ExportSubCommand) run(input cli.Input) error {
+	fn := opts.function
+	ns := fn.ObjectMeta.Namespace
+
+	resources := []interface{}{}
+
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+			Name:      fn.Spec.Environment.Name,
+			Namespace: fn.Spec.Environment.Namespace,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error getting environment '%v'", fn.Spec.Environment.Name)
+		}
+		resources = append(resources, *env)
+
+		pkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+			Name:      fn.Spec.Package.PackageRef.Name,
+			Namespace: fn.Spec.Package.PackageRef.Namespace,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error getting package '%v'", fn.Spec.Package.PackageRef.Name)
+		}
+		for _, ar := range []fv1.Archive{pkg.Spec.Source, pkg.Spec.Deployment} {
+			if ar.Type == fv1.ArchiveTypeUrl && len(ar.URL) > 0 {
+				console.Warn(fmt.Sprintf("Package '%v' references archive URL %v, make sure it is reachable from the target cluster",
+					pkg.ObjectMeta.Name, ar.URL))
+			}
+		}
+		resources = append(resources, *pkg)
+	}
+
+	resources = append(resources, *fn)
+
+	hts, err := opts.Client().V1().HTTPTrigger().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing HTTP triggers")
+	}
+	for _, t := range hts {
+		if referencesFunction(t.Spec.FunctionReference, fn.ObjectMeta.Name) {
+			resources = append(resources, t)
+		}
+	}
+
+	tts, err := opts.Client().V1().TimeTrigger().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing time triggers")
+	}
+	for _, t := range tts {
+		if referencesFunction(t.Spec.FunctionReference, fn.ObjectMeta.Name) {
+			resources = append(resources, t)
+		}
+	}
+
+	mqts, err := opts.Client().V1().MessageQueueTrigger().List("", ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+	for _, t := range mqts {
+		if t.ObjectMeta.Namespace == ns && referencesFunction(t.Spec.FunctionReference, fn.ObjectMeta.Name) {
+			resources = append(resources, t)
+		}
+	}
+
+	ws, err := opts.Client().V1().KubeWatcher().List(ns)
+	if err != nil {
+		return errors.Wrap(err, "error listing kubernetes watch triggers")
+	}
+	for _, t := range ws {
+		if referencesFunction(t.Spec.FunctionReference, fn.ObjectMeta.Name) {
+			resources = append(resources, t)
+		}
+	}
+
+	err = writeHelmChart(opts.outputDir, fn.ObjectMeta.Name, resources)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Helm chart for function '%v' with %v resources exported to '%v'\n", fn.ObjectMeta.Name, len(resources), opts.outputDir)
+
+	return nil
+}
+
+// referencesFunction checks whether a trigger invokes the given function,
+// either directly or as part of a weighted (canary) reference.
+func referencesFunction(ref fv1.FunctionReference, fnName string) bool {
+	if ref.Name == fnName {
+		return true
+	}
+	_, ok := ref.FunctionWeights[fnName]
+	return ok
+}
+
+// helmReleaseNamespace is rendered by helm to the namespace of the release.
+const helmReleaseNamespace = "{{ .Release.Namespace }}"
+
+// writeHelmChart writes Chart.yaml, values.yaml and one template per resource.
+// The namespace of the resources is replaced with the namespace of the
+// helm release.
+func writeHelmChart(dir string, fnName string, resources []interface{}) error {
+	templateDir := filepath.Join(dir, "templates")
+	err := os.MkdirAll(templateDir, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "error creating chart directory '%v'", dir)
+	}
+
+	chart := fmt.Sprintf(`apiVersion: v2
+name: %v
+description: Fission function %v and its dependencies
+type: application
+version: 0.1.0
+`, fnName, fnName)
+	err = os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chart), 0644)
+	if err != nil {
+		return errors.Wrap(err, "error writing Chart.yaml")
+	}
+
+	values := `# Resources in this chart are created in the namespace of the helm release,
+# e.g. helm install <release> <chart> --namespace <namespace>
+`
+	err = os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(values), 0644)
+	if err != nil {
+		return errors.Wrap(err, "error writing values.yaml")
+	}
+
+	for _, r := range resources {
+		var kind, name string
+
+		switch obj := r.(type) {
+		case fv1.Environment:
+			kind, name = "environment", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			r = obj
+		case fv1.Package:
+			kind, name = "package", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			obj.Spec.Environment.Namespace = helmReleaseNamespace
+			obj.Status = fv1.PackageStatus{BuildStatus: obj.Status.BuildStatus}
+			r = obj
+		case fv1.Function:
+			kind, name = "function", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			obj.Spec.Environment.Namespace = helmReleaseNamespace
+			obj.Spec.Package.PackageRef.Namespace = helmReleaseNamespace
+			obj.Spec.Package.PackageRef.ResourceVersion = ""
+			r = obj
+		case fv1.HTTPTrigger:
+			kind, name = "httptrigger", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			r = obj
+		case fv1.TimeTrigger:
+			kind, name = "timetrigger", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			r = obj
+		case fv1.MessageQueueTrigger:
+			kind, name = "mqtrigger", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			r = obj
+		case fv1.KubernetesWatchTrigger:
+			kind, name = "kubewatch", obj.ObjectMeta.Name
+			obj.ObjectMeta = exportObjectMeta(obj.ObjectMeta)
+			r = obj
+		default:
+			return errors.Errorf("unknown resource type %T", r)
+		}
+
+		data, err := spec.SpecToYaml(r)
+		if err != nil {
+			return errors.Wrapf(err, "error converting %v '%v' to yaml", kind, name)
+		}
+
+		file := filepath.Join(templateDir, fmt.Sprintf("%v-%v.yaml", kind, strings.ToLower(name)))
+		err = os.WriteFile(file, data, 0644)
+		if err != nil {
+			return errors.Wrapf(err, "error writing template '%v'", file)
+		}
+	}
+
+	return nil
+}
+
+// exportObjectMeta strips the cluster specific fields like UID and
+// resource version from the object metadata.
+func exportObjectMeta(m metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        m.Name,
+		Namespace:   helmReleaseNamespace,
+		Labels:      m.Labels,
+		Annotations: m.Annotations,
+	}
+}
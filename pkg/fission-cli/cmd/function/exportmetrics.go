@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+const (
+	metricsOutputPrometheus = "prometheus"
+
+	// metricsRateWindow is the window rates are computed over, long
+	// enough to span several scrapes at the default interval
+	metricsRateWindow = "5m"
+)
+
+type (
+	// recordingRules is the layout of a Prometheus rules file.
+	recordingRules struct {
+		Groups []recordingRuleGroup `json:"groups"`
+	}
+
+	recordingRuleGroup struct {
+		Name  string          `json:"name"`
+		Rules []recordingRule `json:"rules"`
+	}
+
+	recordingRule struct {
+		Record string `json:"record"`
+		Expr   string `json:"expr"`
+	}
+)
+
+type ExportMetricsSubCommand struct {
+	cmd.CommandActioner
+}
+
+// ExportMetrics prints Prometheus recording rules that pre-compute the
+// latency percentiles, error rate and throughput of a function from
+// the router metrics, ready to be loaded into Prometheus.
+func ExportMetrics(input cli.Input) error {
+	return (&ExportMetricsSubCommand{}).do(input)
+}
+
+func (opts *ExportMetricsSubCommand) do(input cli.Input) error {
+	output := input.String(flagkey.FnExportMetricsOutput)
+	if output != metricsOutputPrometheus {
+		return errors.Errorf("unsupported output '%v', must be %v", output, metricsOutputPrometheus)
+	}
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	bs, err := yaml.Marshal(functionRecordingRules(fn.ObjectMeta.Namespace, fn.ObjectMeta.Name))
+	if err != nil {
+		return errors.Wrap(err, "error marshaling recording rules")
+	}
+	fmt.Print(string(bs))
+	return nil
+}
+
+// functionRecordingRules returns a rule group for one function. The
+// latency percentiles come from the summary the routers export; a
+// summary can't be merged across routers, so the slowest one is used.
+func functionRecordingRules(namespace, name string) *recordingRules {
+	selector := fmt.Sprintf("{namespace=%q,name=%q}", namespace, name)
+	rate := func(metric string) string {
+		return fmt.Sprintf("sum by (namespace, name) (rate(%v%v[%v]))", metric, selector, metricsRateWindow)
+	}
+	quantile := func(q string) string {
+		return fmt.Sprintf("max by (namespace, name) (fission_function_duration_seconds{namespace=%q,name=%q,quantile=%q})", namespace, name, q)
+	}
+
+	return &recordingRules{
+		Groups: []recordingRuleGroup{
+			{
+				Name: fmt.Sprintf("fission-function-%v-%v", namespace, name),
+				Rules: []recordingRule{
+					{Record: "fission_function:duration_seconds:p50", Expr: quantile("0.5")},
+					{Record: "fission_function:duration_seconds:p99", Expr: quantile("0.99")},
+					{Record: "fission_function:calls:rate" + metricsRateWindow, Expr: rate("fission_function_calls_total")},
+					{Record: "fission_function:errors:rate" + metricsRateWindow, Expr: rate("fission_function_errors_total")},
+					{Record: "fission_function:error_ratio:rate" + metricsRateWindow,
+						Expr: rate("fission_function_errors_total") + " / " + rate("fission_function_calls_total")},
+				},
+			},
+		},
+	}
+}
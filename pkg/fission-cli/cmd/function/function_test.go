@@ -17,13 +17,28 @@ limitations under the License.
 package function
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	_package "github.com/fission/fission/pkg/fission-cli/cmd/package"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 )
 
@@ -383,3 +398,748 @@ func TestGetInvokeStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	result := summarize(latencies, 25, 5*time.Second)
+	assert.Equal(t, 125, result.Requests)
+	assert.Equal(t, 25, result.Errors)
+	assert.Equal(t, 0.2, result.ErrorRate)
+	assert.Equal(t, 25.0, result.ActualRPS)
+	assert.Equal(t, 50.0, result.P50)
+	assert.Equal(t, 90.0, result.P90)
+	assert.Equal(t, 99.0, result.P99)
+
+	empty := summarize(nil, 0, 0)
+	assert.Equal(t, 0, empty.Requests)
+	assert.Equal(t, 0.0, empty.P99)
+}
+
+func TestParseTrace(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "jaeger",
+			body: `{"data":[{"spans":[
+				{"spanID":"0102030405060708","operationName":"GET /fission-function/foo","references":[],"startTime":1000,"duration":5000,"processID":"p1"},
+				{"spanID":"0807060504030201","operationName":"specialize","references":[{"refType":"CHILD_OF","spanID":"0102030405060708"}],"startTime":2000,"duration":3000,"processID":"p2"}],
+				"processes":{"p1":{"serviceName":"router"},"p2":{"serviceName":"executor"}}}]}`,
+		},
+		{
+			name: "tempo",
+			body: `{"batches":[
+				{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"router"}}]},
+				 "instrumentationLibrarySpans":[{"spans":[{"spanId":"AQIDBAUGBwg=","name":"GET /fission-function/foo","startTimeUnixNano":"1000000","endTimeUnixNano":"6000000"}]}]},
+				{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"executor"}}]},
+				 "scopeSpans":[{"spans":[{"spanId":"CAcGBQQDAgE=","parentSpanId":"AQIDBAUGBwg=","name":"specialize","startTimeUnixNano":"2000000","endTimeUnixNano":"5000000"}]}]}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			spans, err := parseTrace([]byte(c.body))
+			assert.NoError(t, err)
+			assert.Len(t, spans, 2)
+			assert.Equal(t, "0102030405060708", spans[0].spanID)
+			assert.Equal(t, "router", spans[0].service)
+			assert.Equal(t, 5*time.Millisecond, spans[0].duration)
+			assert.Equal(t, "0102030405060708", spans[1].parentID)
+			assert.Equal(t, "executor", spans[1].service)
+			assert.Equal(t, time.Millisecond, spans[1].start.Sub(spans[0].start))
+		})
+	}
+}
+
+func TestGetRequestBody(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "payload.json")
+	err := os.WriteFile(jsonFile, []byte(`{"a":1}`), 0644)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name            string
+		args            map[string]interface{}
+		wantBody        string
+		wantContentType string
+		wantErr         bool
+	}{
+		{
+			name:     "inline body",
+			args:     map[string]interface{}{flagkey.FnTestBody: "hello"},
+			wantBody: "hello",
+		},
+		{
+			name:            "body file",
+			args:            map[string]interface{}{flagkey.FnTestBodyFile: jsonFile},
+			wantBody:        `{"a":1}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:            "explicit content type",
+			args:            map[string]interface{}{flagkey.FnTestBodyFile: jsonFile, flagkey.FnTestContentType: "text/plain"},
+			wantBody:        `{"a":1}`,
+			wantContentType: "text/plain",
+		},
+		{
+			name:    "body and body file",
+			args:    map[string]interface{}{flagkey.FnTestBody: "hello", flagkey.FnTestBodyFile: jsonFile},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			args:    map[string]interface{}{flagkey.FnTestBodyFile: filepath.Join(dir, "missing.json")},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			flags := dummy.TestFlagSet()
+			for k, v := range c.args {
+				flags.Set(k, v)
+			}
+
+			body, contentType, err := getRequestBody(flags)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantBody, body)
+			assert.Equal(t, c.wantContentType, contentType)
+		})
+	}
+}
+
+func TestFindArchiveFile(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range map[string]string{
+		"src/handler.js": "module.exports = 1\n",
+		"a/util.js":      "a\n",
+		"b/util.js":      "b\n",
+	} {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	name, contents, err := findArchiveFile(buf.Bytes(), "handler.js")
+	assert.NoError(t, err)
+	assert.Equal(t, "src/handler.js", name)
+	assert.Equal(t, "module.exports = 1\n", string(contents))
+
+	_, _, err = findArchiveFile(buf.Bytes(), "util.js")
+	assert.Error(t, err)
+	_, _, err = findArchiveFile(buf.Bytes(), "missing.js")
+	assert.Error(t, err)
+
+	// single file archives are not zipped
+	name, contents, err = findArchiveFile([]byte("print(1)\n"), "hello.py")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello.py", name)
+	assert.Equal(t, "print(1)\n", string(contents))
+}
+
+func TestInferEnvironment(t *testing.T) {
+	envs := []fv1.Environment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "nodejs"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "Python3"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "python-fast"}},
+	}
+
+	cases := []struct {
+		code    string
+		wantEnv string
+		wantErr bool
+	}{
+		{code: "hello.js", wantEnv: "nodejs"},
+		{code: "hello.txt", wantEnv: ""},
+		{code: "hello.py", wantErr: true},
+		{code: "hello.go", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			env, err := inferEnvironment(envs, c.code)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantEnv, env)
+		})
+	}
+}
+
+func TestExitCodeForStatus(t *testing.T) {
+	for status, want := range map[int]int{
+		200: 0,
+		204: 0,
+		304: 1,
+		404: 2,
+		429: 2,
+		500: 3,
+		503: 3,
+		600: 1,
+	} {
+		assert.Equal(t, want, exitCodeForStatus(status), "status %v", status)
+	}
+}
+
+func TestWatchEventRequest(t *testing.T) {
+	body, headers, err := watchEventRequest([]byte(`{"type":"MODIFIED","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", headers["Content-Type"])
+	assert.Equal(t, "MODIFIED", headers["X-Kubernetes-Event-Type"])
+	assert.Equal(t, "Pod", headers["X-Kubernetes-Object-Type"])
+	assert.Contains(t, string(body), `"name": "foo"`)
+
+	for _, event := range []string{
+		`{"type":"ERROR","object":{"kind":"Status"}}`,
+		`{"type":"ADDED"}`,
+		`{"type":"ADDED","object":{"metadata":{"name":"foo"}}}`,
+		`not json`,
+	} {
+		_, _, err = watchEventRequest([]byte(event))
+		assert.Error(t, err, event)
+	}
+}
+
+func TestParseFunctionManifest(t *testing.T) {
+	manifest := `apiVersion: fission.io/v1
+kind: Function
+metadata:
+  name: hello
+  namespace: prod
+  resourceVersion: "12345"
+  uid: 0b7a4c2e-6f0e-4c8b-9c3d-2f1e5a6b7c8d
+  creationTimestamp: "2021-10-01T00:00:00Z"
+  labels:
+    app: hello
+    fission.io/spec-managed: "true"
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+    fission-name: hello-deployment
+    fission-uid: 5e2f0c1a-3b4d-4e6f-8a9b-0c1d2e3f4a5b
+    team: backend
+spec:
+  environment:
+    name: nodejs
+  package:
+    packageref:
+      name: hello-pkg
+      resourceversion: "678"
+`
+	fn, err := parseFunctionManifest([]byte(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", fn.ObjectMeta.Name)
+	assert.Equal(t, "prod", fn.ObjectMeta.Namespace)
+	assert.Empty(t, fn.ObjectMeta.ResourceVersion)
+	assert.Empty(t, fn.ObjectMeta.UID)
+	assert.True(t, fn.ObjectMeta.CreationTimestamp.IsZero())
+	assert.Equal(t, map[string]string{"app": "hello"}, fn.ObjectMeta.Labels)
+	assert.Equal(t, map[string]string{"team": "backend"}, fn.ObjectMeta.Annotations)
+	assert.Equal(t, "hello-pkg", fn.Spec.Package.PackageRef.Name)
+
+	_, err = parseFunctionManifest([]byte("kind: Environment\nmetadata:\n  name: nodejs\n"))
+	assert.Error(t, err)
+	_, err = parseFunctionManifest([]byte("kind: Function\n"))
+	assert.Error(t, err)
+}
+
+func TestAnnotationPatch(t *testing.T) {
+	value := "backend"
+	patch, err := annotationPatch("team", &value)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"metadata":{"annotations":{"team":"backend"}}}`, string(patch))
+
+	patch, err = annotationPatch("team", nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"metadata":{"annotations":{"team":null}}}`, string(patch))
+
+	_, err = annotationPatch("", &value)
+	assert.Error(t, err)
+}
+
+func TestParseHeader(t *testing.T) {
+	cases := []struct {
+		header    string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{header: "Authorization: Bearer abc", wantKey: "Authorization", wantValue: "Bearer abc"},
+		{header: "X-Forwarded-Host:example.com:8080", wantKey: "X-Forwarded-Host", wantValue: "example.com:8080"},
+		{header: "X-Empty:", wantKey: "X-Empty", wantValue: ""},
+		{header: "Authorization", wantErr: true},
+		{header: ": value", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.header, func(t *testing.T) {
+			key, value, err := parseHeader(c.header)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.wantKey, key)
+			assert.Equal(t, c.wantValue, value)
+		})
+	}
+}
+
+func TestWithContentType(t *testing.T) {
+	assert.Equal(t, []string{"X-Id: 1", "Content-Type:application/json"},
+		withContentType([]string{"X-Id: 1"}, "application/json"))
+	assert.Equal(t, []string{"content-type: text/plain"},
+		withContentType([]string{"content-type: text/plain"}, "application/json"))
+	assert.Empty(t, withContentType(nil, ""))
+}
+
+func TestDelveContainer(t *testing.T) {
+	container := apiv1.Container{
+		Name:    "hello",
+		Command: []string{"/app/server", "--verbose"},
+		Args:    []string{"--addr", ":8888"},
+	}
+
+	debug, err := delveContainer(container, "", 2345)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dlv", "exec", "/app/server", "--headless", "--listen=:2345", "--api-version=2",
+		"--accept-multiclient", "--continue", "--"}, debug.Command)
+	assert.Equal(t, []string{"--verbose", "--addr", ":8888"}, debug.Args)
+	assert.Equal(t, int32(2345), debug.Ports[0].ContainerPort)
+	// the original container is left untouched
+	assert.Equal(t, "/app/server", container.Command[0])
+	assert.Empty(t, container.Ports)
+
+	debug, err = delveContainer(container, "/app/server-debug", 2345)
+	assert.NoError(t, err)
+	assert.Equal(t, "/app/server-debug", debug.Command[2])
+	assert.Equal(t, []string{"--verbose", "--addr", ":8888"}, debug.Args)
+
+	debug, err = delveContainer(apiv1.Container{Name: "hello"}, "/app/server", 4000)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dlv", "exec", "/app/server", "--headless", "--listen=:4000", "--api-version=2",
+		"--accept-multiclient", "--continue"}, debug.Command)
+	assert.Empty(t, debug.Args)
+
+	_, err = delveContainer(apiv1.Container{Name: "hello"}, "", 2345)
+	assert.Error(t, err)
+}
+
+func TestPprofPort(t *testing.T) {
+	containerFn := &fv1.Function{
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeContainer},
+			},
+			PodSpec: &apiv1.PodSpec{
+				Containers: []apiv1.Container{{Name: "hello", Ports: []apiv1.ContainerPort{{ContainerPort: 8080}}}},
+			},
+		},
+	}
+	poolmgrFn := &fv1.Function{
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypePoolmgr},
+			},
+		},
+	}
+
+	assert.Equal(t, 8080, pprofPort(containerFn, 8888, false))
+	assert.Equal(t, 6060, pprofPort(containerFn, 6060, true))
+	assert.Equal(t, 8888, pprofPort(poolmgrFn, 8888, false))
+}
+
+func TestGetFunctionTimeout(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     map[string]interface{}
+		fallback int
+		want     int
+		wantErr  bool
+	}{
+		{name: "fallback", fallback: 60, want: 60},
+		{name: "flag", args: map[string]interface{}{flagkey.FnExecutionTimeout: 15}, fallback: 60, want: 15},
+		{name: "zero", args: map[string]interface{}{flagkey.FnExecutionTimeout: 0}, fallback: 60, wantErr: true},
+		{name: "negative fallback", fallback: -1, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			flags := dummy.TestFlagSet()
+			for k, v := range c.args {
+				flags.Set(k, v)
+			}
+
+			timeout, err := getFunctionTimeout(flags, c.fallback)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, timeout)
+		})
+	}
+}
+
+func TestApplyFunctionTimeout(t *testing.T) {
+	cases := []struct {
+		name    string
+		args    map[string]interface{}
+		current int
+		want    int
+		wantErr bool
+	}{
+		{name: "keep", current: 30, want: 30},
+		{name: "flag", args: map[string]interface{}{flagkey.FnExecutionTimeout: 15}, current: 60, want: 15},
+		{name: "invalid flag", args: map[string]interface{}{flagkey.FnExecutionTimeout: -1}, current: 60, want: 60, wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			flags := dummy.TestFlagSet()
+			for k, v := range c.args {
+				flags.Set(k, v)
+			}
+
+			spec := fv1.FunctionSpec{FunctionTimeout: c.current}
+			err := applyFunctionTimeout(flags, &spec)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.want, spec.FunctionTimeout)
+		})
+	}
+}
+
+func TestZipDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"index.js":            "module.exports = {}",
+		"index.test.js":       "test()",
+		"lib/util.js":         "util()",
+		"node_modules/a/a.js": "a()",
+	} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	opts := &WatchSubCommand{dir: dir, filters: []string{"*.test.js", "node_modules"}}
+	target := filepath.Join(t.TempDir(), "fn.zip")
+	assert.NoError(t, zipDirectory(dir, target, opts.ignored))
+
+	r, err := zip.OpenReader(target)
+	assert.NoError(t, err)
+	defer r.Close()
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"index.js", "lib/util.js"}, names)
+}
+
+func TestSortFunctions(t *testing.T) {
+	now := time.Now()
+	fn := func(name, namespace string, age time.Duration) fv1.Function {
+		return fv1.Function{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		}}
+	}
+	invocations := map[string]float64{
+		"default/a": 10,
+		"default/b": 1,
+		"other/a":   5,
+	}
+
+	cases := []struct {
+		name     string
+		sortBy   string
+		reverse  bool
+		expected []string
+	}{
+		{name: "name", sortBy: sortByName, expected: []string{"default/a", "other/a", "default/b", "default/c"}},
+		{name: "name reversed", sortBy: sortByName, reverse: true, expected: []string{"default/c", "default/b", "other/a", "default/a"}},
+		{name: "age", sortBy: sortByAge, expected: []string{"default/b", "other/a", "default/c", "default/a"}},
+		{name: "invocations", sortBy: sortByInvocations, expected: []string{"default/c", "default/b", "other/a", "default/a"}},
+		{name: "invocations reversed", sortBy: sortByInvocations, reverse: true, expected: []string{"default/a", "other/a", "default/b", "default/c"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fns := []fv1.Function{
+				fn("c", "default", time.Hour),
+				fn("a", "other", time.Hour),
+				fn("b", "default", time.Minute),
+				fn("a", "default", 2*time.Hour),
+			}
+			sortFunctions(fns, c.sortBy, invocations, c.reverse)
+			var names []string
+			for i := range fns {
+				names = append(names, functionKey(&fns[i]))
+			}
+			assert.Equal(t, c.expected, names)
+		})
+	}
+}
+
+func TestAggregatePodMetrics(t *testing.T) {
+	pod := func(ns, name string, cpu, memory string) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				fv1.FUNCTION_NAMESPACE: ns,
+				fv1.FUNCTION_NAME:      name,
+			}},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Usage: apiv1.ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse(cpu),
+					apiv1.ResourceMemory: resource.MustParse(memory),
+				},
+			}},
+		}
+	}
+
+	usage := aggregatePodMetrics([]metricsv1beta1.PodMetrics{
+		pod("default", "hello", "100m", "64Mi"),
+		pod("default", "echo", "5m", "16Mi"),
+		pod("default", "hello", "250m", "32Mi"),
+	})
+	assert.Equal(t, []functionUsage{
+		{namespace: "default", name: "echo", pods: 1, cpu: 5, memory: 16 * 1024 * 1024},
+		{namespace: "default", name: "hello", pods: 2, cpu: 350, memory: 96 * 1024 * 1024},
+	}, usage)
+
+	assert.Equal(t, "+3Mi", formatDelta(3*1024*1024, "Mi", 1024*1024))
+	assert.Equal(t, "-20m", formatDelta(-20, "m", 1))
+	assert.Equal(t, "0m", formatDelta(0, "m", 1))
+}
+
+func TestGrepArchive(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range map[string]string{
+		"main.py":          "import os\nimport md5\n\ndef main():\n    return md5.new()\n",
+		"lib/util.py":      "def helper():\n    pass\n",
+		"lib/native.so":    "md5\x00\x01",
+		"requirements.txt": "requests\n",
+	} {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	re := regexp.MustCompile(`md5`)
+	matches, err := grepArchive(buf.Bytes(), "hello", re)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []grepMatch{
+		{file: "main.py", line: 2, text: "import md5"},
+		{file: "main.py", line: 5, text: "    return md5.new()"},
+	}, matches)
+
+	matches, err = grepArchive([]byte("const crypto = require('md5')\n"), "hello", re)
+	assert.NoError(t, err)
+	assert.Equal(t, []grepMatch{{file: "hello", line: 1, text: "const crypto = require('md5')"}}, matches)
+}
+
+func TestCodeFromStdin(t *testing.T) {
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	assert.NoError(t, err)
+	_, err = stdin.WriteString("module.exports = async () => 'hello'\n")
+	assert.NoError(t, err)
+	_, err = stdin.Seek(0, 0)
+	assert.NoError(t, err)
+	defer stdin.Close()
+
+	path, err := codeFromStdin(stdin)
+	assert.NoError(t, err)
+	defer os.Remove(path)
+	code, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "module.exports = async () => 'hello'\n", string(code))
+}
+
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		previous, current float64
+		want              string
+	}{
+		{100, 150, "+50.0%"},
+		{200, 50, "-75.0%"},
+		{10, 10, "+0.0%"},
+		{0, 0, "0%"},
+		{0, 5, "-"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, percentChange(test.previous, test.current))
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for bytes, want := range map[float64]string{
+		0:                      "0B",
+		1023:                   "1023B",
+		1536:                   "1.5KiB",
+		5 * 1024 * 1024:        "5.0MiB",
+		3 * 1024 * 1024 * 1024: "3.0GiB",
+	} {
+		assert.Equal(t, want, formatBytes(bytes))
+	}
+}
+
+func TestComputeChecksum(t *testing.T) {
+	sum, err := computeChecksum(fv1.Checksum{Type: fv1.ChecksumTypeSHA256}, strings.NewReader("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+
+	_, err = computeChecksum(fv1.Checksum{Type: "md5"}, strings.NewReader("hello"))
+	assert.Error(t, err)
+}
+
+func TestFunctionRecordingRules(t *testing.T) {
+	rules := functionRecordingRules("default", "hello")
+	assert.Len(t, rules.Groups, 1)
+	assert.Equal(t, "fission-function-default-hello", rules.Groups[0].Name)
+
+	exprs := make(map[string]string)
+	for _, r := range rules.Groups[0].Rules {
+		exprs[r.Record] = r.Expr
+	}
+	assert.Equal(t, `max by (namespace, name) (fission_function_duration_seconds{namespace="default",name="hello",quantile="0.99"})`,
+		exprs["fission_function:duration_seconds:p99"])
+	assert.Equal(t, `sum by (namespace, name) (rate(fission_function_calls_total{namespace="default",name="hello"}[5m]))`,
+		exprs["fission_function:calls:rate5m"])
+	assert.Contains(t, exprs["fission_function:error_ratio:rate5m"], " / ")
+}
+
+func TestSetEnvVar(t *testing.T) {
+	value := "postgres://db"
+	env := []apiv1.EnvVar{{Name: "A", Value: "1"}, {Name: "DB_URL", Value: "old"}}
+
+	result, found := setEnvVar(env, "DB_URL", &value)
+	assert.True(t, found)
+	assert.Equal(t, []apiv1.EnvVar{{Name: "A", Value: "1"}, {Name: "DB_URL", Value: value}}, result)
+
+	result, found = setEnvVar(env, "B", &value)
+	assert.False(t, found)
+	assert.Equal(t, []apiv1.EnvVar{{Name: "A", Value: "1"}, {Name: "DB_URL", Value: "old"}, {Name: "B", Value: value}}, result)
+
+	result, found = setEnvVar(env, "DB_URL", nil)
+	assert.True(t, found)
+	assert.Equal(t, []apiv1.EnvVar{{Name: "A", Value: "1"}}, result)
+
+	result, found = setEnvVar([]apiv1.EnvVar{{Name: "A", Value: "1"}}, "A", nil)
+	assert.True(t, found)
+	assert.Empty(t, result)
+}
+
+func TestGetInvocationTiming(t *testing.T) {
+	sent := time.Now()
+	received := sent.Add(3 * time.Second)
+
+	timing, ok := getInvocationTiming(sent, received, http.Header{})
+	assert.False(t, ok)
+	assert.Equal(t, 3*time.Second, timing.total)
+
+	header := http.Header{}
+	header.Set(fv1.HeaderRouterTime, sent.Format(time.RFC3339Nano))
+	header.Set(fv1.HeaderColdStartDuration, "2s")
+	timing, ok = getInvocationTiming(sent, received, header)
+	assert.True(t, ok)
+	assert.Equal(t, invocationTiming{total: 3 * time.Second, coldStart: 2 * time.Second, execution: time.Second}, timing)
+
+	header.Set(fv1.HeaderColdStartDuration, "5s")
+	timing, ok = getInvocationTiming(sent, received, header)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, timing.coldStart)
+	assert.Zero(t, timing.execution)
+}
+
+func TestRevisionPackage(t *testing.T) {
+	fn := &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"}}
+	oldURL := "http://storagesvc.fission/v1/archive?id=old"
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello-pkg", Namespace: "default", ResourceVersion: "1"},
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: "default"},
+			Deployment:  fv1.Archive{Type: fv1.ArchiveTypeUrl, URL: oldURL, Checksum: fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: "abc"}},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusSucceeded},
+	}
+
+	rev := revisionPackage(fn, pkg, 3)
+	assert.NotEqual(t, pkg.ObjectMeta.Name, rev.ObjectMeta.Name)
+	assert.True(t, strings.HasPrefix(rev.ObjectMeta.Name, "hello-rev3-"))
+	assert.Equal(t, "default", rev.ObjectMeta.Namespace)
+	assert.Empty(t, rev.ObjectMeta.ResourceVersion)
+	assert.Equal(t, "hello", rev.ObjectMeta.Labels[_package.REVISION_FUNCTION_LABEL])
+	assert.Equal(t, fv1.BuildStatusSucceeded, rev.Status.BuildStatus)
+
+	// fn update changes the package in place, the revision package still
+	// references the old archive so that it isn't pruned
+	pkg.Spec.Deployment.URL = "http://storagesvc.fission/v1/archive?id=new"
+	pkg.Spec.Deployment.Checksum.Sum = "def"
+	assert.Equal(t, oldURL, rev.Spec.Deployment.URL)
+	assert.Equal(t, "abc", rev.Spec.Deployment.Checksum.Sum)
+	assert.Equal(t, pkg.Spec.Environment, rev.Spec.Environment)
+}
+
+func TestLambdaRuntimeEnvironment(t *testing.T) {
+	cases := []struct {
+		runtime string
+		wantEnv string
+		wantOk  bool
+	}{
+		{runtime: "nodejs16.x", wantEnv: "nodejs", wantOk: true},
+		{runtime: "python3.9", wantEnv: "python3", wantOk: true},
+		{runtime: "python2.7", wantEnv: "python", wantOk: true},
+		{runtime: "go1.x", wantEnv: "go", wantOk: true},
+		{runtime: "java11", wantEnv: "jvm", wantOk: true},
+		{runtime: "dotnetcore3.1", wantEnv: "dotnet", wantOk: true},
+		{runtime: "provided.al2", wantOk: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.runtime, func(t *testing.T) {
+			env, ok := lambdaRuntimeEnvironment(c.runtime)
+			assert.Equal(t, c.wantOk, ok)
+			assert.Equal(t, c.wantEnv, env)
+		})
+	}
+}
+
+func TestUnmappedLambdaSettings(t *testing.T) {
+	out := &lambda.GetFunctionOutput{
+		Configuration: &lambda.FunctionConfiguration{
+			FunctionName:  aws.String("my-lambda"),
+			Handler:       aws.String("index.handler"),
+			Layers:        []*lambda.Layer{{Arn: aws.String("arn:aws:lambda:us-east-1:123:layer:deps:1")}},
+			VpcConfig:     &lambda.VpcConfigResponse{},
+			TracingConfig: &lambda.TracingConfigResponse{Mode: aws.String(lambda.TracingModePassThrough)},
+		},
+		Tags: map[string]*string{"team": aws.String("backend"), "app": aws.String("shop")},
+	}
+
+	settings := unmappedLambdaSettings(out)
+	assert.Len(t, settings, 3)
+	assert.Contains(t, settings[0], "index.handler")
+	assert.Contains(t, settings[1], "layer:deps:1")
+	assert.Equal(t, "Tags: app=shop,team=backend", settings[2])
+}
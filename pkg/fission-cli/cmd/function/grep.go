@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+// binaryCheckSize is how much of a file is checked for NUL bytes to
+// tell binary files apart from source code, like grep does.
+const binaryCheckSize = 8000
+
+type GrepSubCommand struct {
+	cmd.CommandActioner
+}
+
+type grepMatch struct {
+	file string
+	line int
+	text string
+}
+
+// Grep searches the deployment archives of all functions in a namespace
+// for a regular expression and prints the matching lines.
+func Grep(input cli.Input) error {
+	return (&GrepSubCommand{}).do(input)
+}
+
+func (opts *GrepSubCommand) do(input cli.Input) error {
+	pattern := input.String(flagkey.FnGrepPattern)
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid pattern '%v'", pattern)
+	}
+
+	fns, err := opts.Client().V1().Function().List(input.String(flagkey.NamespaceFunction))
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
+	}
+
+	envName := input.String(flagkey.FnGrepEnv)
+	for _, fn := range fns {
+		if len(envName) > 0 && fn.Spec.Environment.Name != envName {
+			continue
+		}
+		if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+			console.Verbose(2, "Skipping function '%v', it runs a container image", fn.ObjectMeta.Name)
+			continue
+		}
+
+		matches, err := opts.grepFunction(&fn, re)
+		if err != nil {
+			console.Warn(fmt.Sprintf("Skipping function '%v': %v", fn.ObjectMeta.Name, err))
+			continue
+		}
+		for _, m := range matches {
+			fmt.Printf("%v:%v:%v:%v\n", fn.ObjectMeta.Name, m.file, m.line, m.text)
+		}
+	}
+
+	return nil
+}
+
+func (opts *GrepSubCommand) grepFunction(fn *fv1.Function, re *regexp.Regexp) ([]grepMatch, error) {
+	reader, err := openDeployArchive(opts.Client(), &metav1.ObjectMeta{
+		Name:      fn.ObjectMeta.Name,
+		Namespace: fn.ObjectMeta.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	archive, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading archive")
+	}
+	return grepArchive(archive, fn.ObjectMeta.Name, re)
+}
+
+// grepArchive returns the lines of the text files in a zip archive
+// which match the regular expression. Archives that are not zipped
+// hold the single source file of a function, which is reported under
+// the given name.
+func grepArchive(archive []byte, name string, re *regexp.Regexp) ([]grepMatch, error) {
+	if !bytes.HasPrefix(archive, zipMagic) {
+		return grepFile(bytes.NewReader(archive), name, re)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading zip archive")
+	}
+
+	var matches []grepMatch
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error opening '%v'", f.Name)
+		}
+		fileMatches, err := grepFile(rc, f.Name, re)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, fileMatches...)
+	}
+	return matches, nil
+}
+
+func grepFile(r io.Reader, name string, re *regexp.Regexp) ([]grepMatch, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(binaryCheckSize)
+	if bytes.IndexByte(head, 0) >= 0 {
+		return nil, nil
+	}
+
+	var matches []grepMatch
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if re.Match(scanner.Bytes()) {
+			matches = append(matches, grepMatch{file: name, line: line, text: scanner.Text()})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "error reading '%v'", name)
+	}
+	return matches, nil
+}
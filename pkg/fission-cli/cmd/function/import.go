@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type ImportSubCommand struct {
+	cmd.CommandActioner
+	function *fv1.Function
+}
+
+// Import creates a function from a Function manifest, e.g. the output
+// of 'kubectl get function <name> -o yaml' in another cluster.
+func Import(input cli.Input) error {
+	return (&ImportSubCommand{}).do(input)
+}
+
+func (opts *ImportSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ImportSubCommand) complete(input cli.Input) error {
+	file := input.String(flagkey.FnImportFile)
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "error reading '%v'", file)
+	}
+	fn, err := parseFunctionManifest(contents)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing function manifest '%v'", file)
+	}
+
+	if input.IsSet(flagkey.NamespaceFunction) || len(fn.ObjectMeta.Namespace) == 0 {
+		fn.ObjectMeta.Namespace = input.String(flagkey.NamespaceFunction)
+	}
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		// packages are always created in the namespace of the function
+		fn.Spec.Package.PackageRef.Namespace = fn.ObjectMeta.Namespace
+		if len(fn.Spec.Environment.Namespace) == 0 {
+			fn.Spec.Environment.Namespace = fn.ObjectMeta.Namespace
+		}
+	}
+
+	existing, err := opts.Client().V1().Function().Get(&fn.ObjectMeta)
+	if err != nil && !ferror.IsNotFound(err) {
+		return err
+	} else if existing != nil {
+		return errors.Errorf("function '%v' already exists in namespace '%v'", fn.ObjectMeta.Name, fn.ObjectMeta.Namespace)
+	}
+
+	opts.function = fn
+	return nil
+}
+
+func (opts *ImportSubCommand) run(input cli.Input) error {
+	fn := opts.function
+
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		pkgMeta, err := opts.ensurePackage(input)
+		if err != nil {
+			return err
+		}
+		// the resource version in the manifest belongs to the package
+		// of the source cluster
+		fn.Spec.Package.PackageRef.ResourceVersion = pkgMeta.ResourceVersion
+	}
+
+	_, err := opts.Client().V1().Function().Create(fn)
+	if err != nil {
+		return errors.Wrap(err, "error creating function")
+	}
+
+	fmt.Printf("function '%v' imported\n", fn.ObjectMeta.Name)
+	return nil
+}
+
+// ensurePackage returns the package the function references. If it
+// doesn't exist, the package is created from the archive given with
+// --package-file.
+func (opts *ImportSubCommand) ensurePackage(input cli.Input) (*metav1.ObjectMeta, error) {
+	fn := opts.function
+	pkgRef := &metav1.ObjectMeta{
+		Name:      fn.Spec.Package.PackageRef.Name,
+		Namespace: fn.Spec.Package.PackageRef.Namespace,
+	}
+
+	pkg, err := opts.Client().V1().Package().Get(pkgRef)
+	if err == nil {
+		return &pkg.ObjectMeta, nil
+	}
+	if !ferror.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "error getting package '%v'", pkgRef.Name)
+	}
+
+	if !input.IsSet(flagkey.FnImportPackageFile) {
+		return nil, errors.Errorf("package '%v' doesn't exist in namespace '%v', use --%v to create it from an archive",
+			pkgRef.Name, pkgRef.Namespace, flagkey.FnImportPackageFile)
+	}
+
+	archive, err := pkgutil.UploadArchiveFile(context.Background(), opts.Client(), input.String(flagkey.FnImportPackageFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "error uploading package archive")
+	}
+
+	pkgMeta, err := opts.Client().V1().Package().Create(&fv1.Package{
+		ObjectMeta: *pkgRef,
+		Spec: fv1.PackageSpec{
+			Environment: fn.Spec.Environment,
+			Deployment:  *archive,
+		},
+		Status: fv1.PackageStatus{
+			BuildStatus:         fv1.BuildStatusSucceeded,
+			LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating package")
+	}
+	fmt.Printf("Package '%v' created\n", pkgMeta.Name)
+
+	return pkgMeta, nil
+}
+
+// parseFunctionManifest parses a Function manifest and strips the
+// fields managed by the API server and by spec apply, so that it can
+// be created again.
+func parseFunctionManifest(contents []byte) (*fv1.Function, error) {
+	var fn fv1.Function
+	err := yaml.Unmarshal(contents, &fn)
+	if err != nil {
+		return nil, err
+	}
+	if len(fn.TypeMeta.Kind) > 0 && fn.TypeMeta.Kind != "Function" {
+		return nil, errors.Errorf("manifest is a %v, not a Function", fn.TypeMeta.Kind)
+	}
+	if len(fn.ObjectMeta.Name) == 0 {
+		return nil, errors.New("manifest has no name")
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      fn.ObjectMeta.Name,
+		Namespace: fn.ObjectMeta.Namespace,
+	}
+	// a function applied from a spec would otherwise be pruned by the
+	// next spec apply in the source directory
+	spec.CopyUnmanagedMeta(&meta, &fn.ObjectMeta)
+	fn.ObjectMeta = meta
+	// kubectl keeps the last applied manifest of the source cluster
+	delete(fn.ObjectMeta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+
+	return &fn, nil
+}
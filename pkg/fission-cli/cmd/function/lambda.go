@@ -0,0 +1,364 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// lambdaRuntimes maps the prefix of a Lambda runtime identifier to the
+// name of the Fission environment running the same language.
+var lambdaRuntimes = []struct {
+	prefix string
+	env    string
+}{
+	{prefix: "nodejs", env: "nodejs"},
+	{prefix: "python3", env: "python3"},
+	{prefix: "python2", env: "python"},
+	{prefix: "go", env: "go"},
+	{prefix: "java", env: "jvm"},
+	{prefix: "ruby", env: "ruby"},
+	{prefix: "dotnet", env: "dotnet"},
+}
+
+type LambdaImportSubCommand struct {
+	cmd.CommandActioner
+	lambdaFn   *lambda.GetFunctionOutput
+	function   *fv1.Function
+	kubeClient kubernetes.Interface
+	createdPkg *metav1.ObjectMeta
+	createdCfg *metav1.ObjectMeta
+}
+
+// ImportFromLambda creates a function from the deployment package
+// and configuration of an AWS Lambda function.
+func ImportFromLambda(input cli.Input) error {
+	return (&LambdaImportSubCommand{}).do(input)
+}
+
+func (opts *LambdaImportSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *LambdaImportSubCommand) complete(input cli.Input) error {
+	lambdaName := input.String(flagkey.FnLambdaFunctionName)
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(input.String(flagkey.FnLambdaRegion))},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating AWS session")
+	}
+	out, err := lambda.New(sess).GetFunction(&lambda.GetFunctionInput{
+		FunctionName: aws.String(lambdaName),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error getting Lambda function '%v'", lambdaName)
+	}
+	cfg := out.Configuration
+	if aws.StringValue(cfg.PackageType) == lambda.PackageTypeImage {
+		return errors.Errorf("Lambda function '%v' is deployed as a container image, use 'fission fn run-container' with an image serving HTTP instead", lambdaName)
+	}
+	opts.lambdaFn = out
+
+	envName := input.String(flagkey.FnEnvironmentName)
+	if len(envName) == 0 {
+		var ok bool
+		envName, ok = lambdaRuntimeEnvironment(aws.StringValue(cfg.Runtime))
+		if !ok {
+			return errors.Errorf("no Fission environment is known for Lambda runtime '%v', use --%v to choose one",
+				aws.StringValue(cfg.Runtime), flagkey.FnEnvironmentName)
+		}
+	}
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+	envNamespace := input.String(flagkey.NamespaceEnvironment)
+
+	_, err = opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      envName,
+		Namespace: envNamespace,
+	})
+	if err != nil {
+		if ferror.IsNotFound(err) {
+			return errors.Errorf("environment '%v' doesn't exist in namespace '%v', create it with 'fission env create' or use --%v",
+				envName, envNamespace, flagkey.FnEnvironmentName)
+		}
+		return errors.Wrapf(err, "error getting environment '%v'", envName)
+	}
+
+	fnName := input.String(flagkey.FnName)
+	if len(fnName) == 0 {
+		fnName = util.KubifyName(aws.StringValue(cfg.FunctionName))
+	}
+	existing, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      fnName,
+		Namespace: fnNamespace,
+	})
+	if err != nil && !ferror.IsNotFound(err) {
+		return err
+	} else if existing != nil {
+		return errors.Errorf("function '%v' already exists in namespace '%v', use --%v to choose another name", fnName, fnNamespace, flagkey.FnName)
+	}
+
+	opts.function = &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fnName,
+			Namespace: fnNamespace,
+		},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{
+				Name:      envName,
+				Namespace: envNamespace,
+			},
+			Package: fv1.FunctionPackageRef{
+				FunctionName: aws.StringValue(cfg.Handler),
+			},
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType: fv1.ExecutorTypePoolmgr,
+				},
+				StrategyType: fv1.StrategyTypeExecution,
+			},
+			FunctionTimeout: int(aws.Int64Value(cfg.Timeout)),
+		},
+	}
+	return nil
+}
+
+func (opts *LambdaImportSubCommand) run(input cli.Input) error {
+	fn := opts.function
+	cfg := opts.lambdaFn.Configuration
+
+	if cfg.Environment != nil && len(cfg.Environment.Variables) > 0 {
+		_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+		if err != nil {
+			return err
+		}
+		opts.kubeClient = kubeClient
+
+		cfgMap, err := opts.createConfigMap(aws.StringValueMap(cfg.Environment.Variables))
+		if err != nil {
+			return err
+		}
+		fn.Spec.ConfigMaps = []fv1.ConfigMapReference{{
+			Name:      cfgMap.Name,
+			Namespace: cfgMap.Namespace,
+		}}
+	}
+
+	pkgMeta, err := opts.createPackage()
+	if err != nil {
+		return opts.cleanup(err)
+	}
+	fn.Spec.Package.PackageRef = fv1.PackageRef{
+		Namespace:       pkgMeta.Namespace,
+		Name:            pkgMeta.Name,
+		ResourceVersion: pkgMeta.ResourceVersion,
+	}
+
+	_, err = opts.Client().V1().Function().Create(fn)
+	if err != nil {
+		return opts.cleanup(errors.Wrap(err, "error creating function"))
+	}
+	fmt.Printf("function '%v' imported from Lambda function '%v'\n", fn.ObjectMeta.Name, aws.StringValue(cfg.FunctionName))
+
+	if opts.createdCfg != nil {
+		fmt.Printf("The Lambda environment variables are in configmap '%v', mounted at /configs/%v/%v/<variable name>; "+
+			"read them from there instead of the process environment\n",
+			opts.createdCfg.Name, opts.createdCfg.Namespace, opts.createdCfg.Name)
+	}
+
+	unmapped := unmappedLambdaSettings(opts.lambdaFn)
+	if len(unmapped) > 0 {
+		fmt.Println("These Lambda settings have no Fission equivalent and were not imported:")
+		for _, s := range unmapped {
+			fmt.Printf("  %v\n", s)
+		}
+	}
+	return nil
+}
+
+// createConfigMap stores the Lambda environment variables in a
+// configmap named after the function.
+func (opts *LambdaImportSubCommand) createConfigMap(vars map[string]string) (*metav1.ObjectMeta, error) {
+	cfgMap := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v-env", opts.function.ObjectMeta.Name),
+			Namespace: opts.function.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				util.LABEL_MANAGED: "true",
+			},
+		},
+		Data: vars,
+	}
+	created, err := opts.kubeClient.CoreV1().ConfigMaps(cfgMap.Namespace).Create(context.Background(), cfgMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating configmap '%v'", cfgMap.Name)
+	}
+	opts.createdCfg = &created.ObjectMeta
+	fmt.Printf("configmap '%v' created\n", created.Name)
+	return opts.createdCfg, nil
+}
+
+// createPackage downloads the deployment package of the Lambda
+// function and creates a Fission package from it.
+func (opts *LambdaImportSubCommand) createPackage() (*metav1.ObjectMeta, error) {
+	fn := opts.function
+
+	archivePath, err := pkgutil.DownloadToTempFile(aws.StringValue(opts.lambdaFn.Code.Location))
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading Lambda deployment package")
+	}
+	defer os.Remove(archivePath)
+
+	archive, err := pkgutil.UploadArchiveFile(context.Background(), opts.Client(), archivePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error uploading deployment package")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating uuid")
+	}
+	pkgMeta, err := opts.Client().V1().Package().Create(&fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v-%v", fn.ObjectMeta.Name, id.String()),
+			Namespace: fn.ObjectMeta.Namespace,
+		},
+		Spec: fv1.PackageSpec{
+			Environment: fn.Spec.Environment,
+			Deployment:  *archive,
+		},
+		Status: fv1.PackageStatus{
+			BuildStatus:         fv1.BuildStatusSucceeded,
+			LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating package")
+	}
+	opts.createdPkg = pkgMeta
+	fmt.Printf("Package '%v' created\n", pkgMeta.Name)
+	return pkgMeta, nil
+}
+
+// cleanup deletes the package and configmap created for the function
+// when the function couldn't be created, and returns err along with
+// the resources it failed to delete.
+func (opts *LambdaImportSubCommand) cleanup(err error) error {
+	result := multierror.Append(nil, err)
+	if opts.createdPkg != nil {
+		e := opts.Client().V1().Package().Delete(opts.createdPkg)
+		if e != nil {
+			result = multierror.Append(result, errors.Wrapf(e, "error deleting package '%v'", opts.createdPkg.Name))
+		}
+	}
+	if opts.createdCfg != nil {
+		e := opts.kubeClient.CoreV1().ConfigMaps(opts.createdCfg.Namespace).Delete(context.Background(), opts.createdCfg.Name, metav1.DeleteOptions{})
+		if e != nil {
+			result = multierror.Append(result, errors.Wrapf(e, "error deleting configmap '%v'", opts.createdCfg.Name))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// lambdaRuntimeEnvironment returns the name of the Fission environment
+// for a Lambda runtime identifier, ex: nodejs16.x -> nodejs.
+func lambdaRuntimeEnvironment(runtime string) (string, bool) {
+	for _, r := range lambdaRuntimes {
+		if strings.HasPrefix(runtime, r.prefix) {
+			return r.env, true
+		}
+	}
+	return "", false
+}
+
+// unmappedLambdaSettings describes the settings of a Lambda function
+// that can't be expressed in a Fission function.
+func unmappedLambdaSettings(out *lambda.GetFunctionOutput) []string {
+	var settings []string
+	cfg := out.Configuration
+
+	if len(aws.StringValue(cfg.Handler)) > 0 {
+		settings = append(settings, fmt.Sprintf("Handler: %v (used as the entrypoint, the handler must be adapted to the function signature of the environment)", aws.StringValue(cfg.Handler)))
+	}
+	if cfg.MemorySize != nil {
+		settings = append(settings, fmt.Sprintf("MemorySize: %v MB (set memory limits on the environment or use --executortype newdeploy)", aws.Int64Value(cfg.MemorySize)))
+	}
+	if len(aws.StringValue(cfg.Role)) > 0 {
+		settings = append(settings, fmt.Sprintf("Role: %v", aws.StringValue(cfg.Role)))
+	}
+	for _, l := range cfg.Layers {
+		settings = append(settings, fmt.Sprintf("Layer: %v (include its contents in the package or the environment image)", aws.StringValue(l.Arn)))
+	}
+	if cfg.VpcConfig != nil && len(cfg.VpcConfig.SubnetIds) > 0 {
+		settings = append(settings, fmt.Sprintf("VpcConfig: subnets %v, security groups %v",
+			strings.Join(aws.StringValueSlice(cfg.VpcConfig.SubnetIds), ","),
+			strings.Join(aws.StringValueSlice(cfg.VpcConfig.SecurityGroupIds), ",")))
+	}
+	if cfg.DeadLetterConfig != nil && len(aws.StringValue(cfg.DeadLetterConfig.TargetArn)) > 0 {
+		settings = append(settings, fmt.Sprintf("DeadLetterConfig: %v", aws.StringValue(cfg.DeadLetterConfig.TargetArn)))
+	}
+	if cfg.TracingConfig != nil && aws.StringValue(cfg.TracingConfig.Mode) == lambda.TracingModeActive {
+		settings = append(settings, "TracingConfig: X-Ray active tracing")
+	}
+	if len(aws.StringValue(cfg.KMSKeyArn)) > 0 {
+		settings = append(settings, fmt.Sprintf("KMSKeyArn: %v", aws.StringValue(cfg.KMSKeyArn)))
+	}
+	for _, fs := range cfg.FileSystemConfigs {
+		settings = append(settings, fmt.Sprintf("FileSystemConfig: %v mounted at %v", aws.StringValue(fs.Arn), aws.StringValue(fs.LocalMountPath)))
+	}
+	if out.Concurrency != nil && out.Concurrency.ReservedConcurrentExecutions != nil {
+		settings = append(settings, fmt.Sprintf("ReservedConcurrentExecutions: %v", aws.Int64Value(out.Concurrency.ReservedConcurrentExecutions)))
+	}
+	if len(out.Tags) > 0 {
+		tags := make([]string, 0, len(out.Tags))
+		for k, v := range out.Tags {
+			tags = append(tags, fmt.Sprintf("%v=%v", k, aws.StringValue(v)))
+		}
+		sort.Strings(tags)
+		settings = append(settings, fmt.Sprintf("Tags: %v", strings.Join(tags, ",")))
+	}
+	return settings
+}
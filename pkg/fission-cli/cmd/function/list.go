@@ -17,18 +17,42 @@ limitations under the License.
 package function
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
+	prometheus "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/labels"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
+const (
+	ENV_PROMETHEUS_URL = "FISSION_PROMETHEUS_URL"
+
+	sortByName        = "name"
+	sortByAge         = "age"
+	sortByInvocations = "invocations"
+
+	prometheusQueryTimeout = 10 * time.Second
+)
+
+var errPrometheusNotConfigured = errors.Errorf("Prometheus is not configured, set --%v, the %v environment variable or run 'fission config init'",
+	flagkey.FnListPrometheus, ENV_PROMETHEUS_URL)
+
 type ListSubCommand struct {
 	cmd.CommandActioner
 }
@@ -40,14 +64,67 @@ func List(input cli.Input) error {
 func (opts *ListSubCommand) do(input cli.Input) error {
 	ns := input.String(flagkey.NamespaceFunction)
 
-	fns, err := opts.Client().V1().Function().List(ns)
+	formatter, err := util.GetOutputFormatter(input)
+	if err != nil {
+		return err
+	}
+
+	selector := input.String(flagkey.FnListLabelSelector)
+	if len(selector) > 0 {
+		_, err = labels.Parse(selector)
+		if err != nil {
+			return errors.Wrapf(err, "invalid label selector '%v'", selector)
+		}
+	}
+
+	sortBy := input.String(flagkey.FnListSortBy)
+	switch sortBy {
+	case "", sortByName, sortByAge, sortByInvocations:
+	default:
+		return errors.Errorf("invalid sort field '%v', must be one of: %v|%v|%v", sortBy, sortByName, sortByAge, sortByInvocations)
+	}
+	reverse := input.Bool(flagkey.FnListReverse)
+	if reverse && len(sortBy) == 0 {
+		sortBy = sortByName
+	}
+
+	fns, err := opts.Client().V1().Function().ListWithLabelSelector(ns, selector)
 	if err != nil {
 		return errors.Wrap(err, "error listing functions")
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	var invocations map[string]float64
+	if sortBy == sortByInvocations {
+		invocations, err = functionInvocations(prometheusURL(input, flagkey.FnListPrometheus))
+		if err != nil {
+			console.Warn(fmt.Sprintf("Sorting by name instead of invocations: %v", err))
+			sortBy = sortByName
+		}
+	}
+	if len(sortBy) > 0 {
+		sortFunctions(fns, sortBy, invocations, reverse)
+	}
+
+	if formatter.IsStructured() {
+		return formatter.Print(fns)
+	}
+
+	printFunctionList(os.Stdout, fns, formatter.IsWide())
+
+	return nil
+}
+
+// printFunctionList prints functions as a table. The wide table
+// additionally contains the package and the creation timestamp.
+func printFunctionList(writer io.Writer, fns []fv1.Function, wide bool) {
+	w := tabwriter.NewWriter(writer, 0, 0, 1, ' ', 0)
+
+	header := []string{"NAME", "ENV", "EXECUTORTYPE", "MINSCALE", "MAXSCALE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "TARGETCPU", "SECRETS", "CONFIGMAPS"}
+	if wide {
+		header = append(header, "PACKAGE", "CREATED")
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
 
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "ENV", "EXECUTORTYPE", "MINSCALE", "MAXSCALE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "TARGETCPU", "SECRETS", "CONFIGMAPS")
 	for _, f := range fns {
 		secrets := f.Spec.Secrets
 		configMaps := f.Spec.ConfigMaps
@@ -59,7 +136,7 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 			configMapList = append(configMapList, configMap.Name)
 		}
 
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v",
 			f.ObjectMeta.Name, f.Spec.Environment.Name,
 			f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
 			f.Spec.InvokeStrategy.ExecutionStrategy.MinScale,
@@ -71,8 +148,100 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 			f.Spec.InvokeStrategy.ExecutionStrategy.TargetCPUPercent,
 			strings.Join(secretsList, ","),
 			strings.Join(configMapList, ","))
+		if wide {
+			fmt.Fprintf(w, "\t%v\t%v", f.Spec.Package.PackageRef.Name,
+				f.ObjectMeta.CreationTimestamp.Format(time.RFC3339))
+		}
+		fmt.Fprintln(w)
 	}
 	w.Flush()
+}
 
-	return nil
+// sortFunctions sorts functions by name, age (newest first) or number
+// of invocations (least called first). Functions that compare equal
+// are ordered by namespace and name.
+func sortFunctions(fns []fv1.Function, sortBy string, invocations map[string]float64, reverse bool) {
+	byName := func(a, b *fv1.Function) bool {
+		if a.ObjectMeta.Name != b.ObjectMeta.Name {
+			return a.ObjectMeta.Name < b.ObjectMeta.Name
+		}
+		return a.ObjectMeta.Namespace < b.ObjectMeta.Namespace
+	}
+
+	less := func(i, j int) bool {
+		a, b := &fns[i], &fns[j]
+		switch sortBy {
+		case sortByAge:
+			ta, tb := a.ObjectMeta.CreationTimestamp.Time, b.ObjectMeta.CreationTimestamp.Time
+			if !ta.Equal(tb) {
+				return ta.After(tb)
+			}
+		case sortByInvocations:
+			ca, cb := invocations[functionKey(a)], invocations[functionKey(b)]
+			if ca != cb {
+				return ca < cb
+			}
+		}
+		return byName(a, b)
+	}
+
+	if reverse {
+		sort.SliceStable(fns, func(i, j int) bool { return less(j, i) })
+		return
+	}
+	sort.SliceStable(fns, less)
+}
+
+func functionKey(fn *fv1.Function) string {
+	return fn.ObjectMeta.Namespace + "/" + fn.ObjectMeta.Name
+}
+
+// prometheusURL returns the Prometheus URL given with the flag, the
+// FISSION_PROMETHEUS_URL environment variable or the CLI config.
+func prometheusURL(input cli.Input, key string) string {
+	if url := input.String(key); len(url) > 0 {
+		return url
+	}
+	if url := os.Getenv(ENV_PROMETHEUS_URL); len(url) > 0 {
+		return url
+	}
+	config, err := util.LoadConfig()
+	if err != nil {
+		console.Verbose(2, "Error loading CLI config: %v", err)
+		return ""
+	}
+	return config.PrometheusURL
+}
+
+// functionInvocations returns the total number of calls of each
+// function recorded by the router, keyed by namespace/name.
+func functionInvocations(promURL string) (map[string]float64, error) {
+	if len(promURL) == 0 {
+		return nil, errPrometheusNotConfigured
+	}
+
+	client, err := prometheus.NewClient(prometheus.Config{Address: promURL})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating Prometheus client for %v", promURL)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusQueryTimeout)
+	defer cancel()
+
+	query := "sum by (namespace, name) (fission_function_calls_total)"
+	val, _, err := prometheusv1.NewAPI(client).Query(ctx, query, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying Prometheus")
+	}
+	vector, ok := val.(model.Vector)
+	if !ok {
+		return nil, errors.Errorf("unexpected result type '%v' of Prometheus query", val.Type())
+	}
+
+	invocations := make(map[string]float64, len(vector))
+	for _, sample := range vector {
+		key := string(sample.Metric["namespace"]) + "/" + string(sample.Metric["name"])
+		invocations[key] = float64(sample.Value)
+	}
+	return invocations, nil
 }
@@ -19,6 +19,7 @@ package function
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -51,6 +52,33 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 		recordLimit = 1000
 	}
 
+	level := strings.ToLower(input.String(flagkey.FnLogLevel))
+	if len(level) > 0 && !logdb.ValidLevel(level) {
+		return errors.Errorf("--%v must be one of %v, %v, %v or %v", flagkey.FnLogLevel,
+			logdb.LevelDebug, logdb.LevelInfo, logdb.LevelWarn, logdb.LevelError)
+	}
+
+	now := time.Now()
+	since := time.Unix(0, 0*int64(time.Millisecond))
+	if input.IsSet(flagkey.FnLogSince) {
+		t, err := parseLogTime(input.String(flagkey.FnLogSince), now)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing --%v", flagkey.FnLogSince)
+		}
+		since = t
+	}
+	var until time.Time
+	if input.IsSet(flagkey.FnLogUntil) {
+		t, err := parseLogTime(input.String(flagkey.FnLogUntil), now)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing --%v", flagkey.FnLogUntil)
+		}
+		until = t
+		if !until.After(since) {
+			return errors.Errorf("--%v must be after --%v", flagkey.FnLogUntil, flagkey.FnLogSince)
+		}
+	}
+
 	f, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
 		Name:      input.String(flagkey.FnName),
 		Namespace: input.String(flagkey.NamespaceFunction),
@@ -75,17 +103,20 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 	ctx := context.Background()
 
 	go func(ctx context.Context, requestChan, responseChan chan struct{}) {
-		t := time.Unix(0, 0*int64(time.Millisecond))
+		t := since
 		for {
 			select {
 			case <-requestChan:
 				logFilter := logdb.LogFilter{
-					Pod:         fnPod,
-					Function:    f.ObjectMeta.Name,
-					FuncUid:     string(f.ObjectMeta.UID),
-					Since:       t,
-					Reverse:     logReverseQuery,
-					RecordLimit: recordLimit,
+					Pod:          fnPod,
+					Function:     f.ObjectMeta.Name,
+					FuncUid:      string(f.ObjectMeta.UID),
+					Since:        t,
+					Until:        until,
+					Reverse:      logReverseQuery,
+					RecordLimit:  recordLimit,
+					InvocationID: input.String(flagkey.FnLogInvocationID),
+					Level:        level,
 				}
 				logEntries, err := logDB.GetLogs(logFilter)
 				if err != nil {
@@ -122,3 +153,13 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 
 	return nil
 }
+
+// parseLogTime parses either a duration relative to now, like "5m",
+// or an RFC3339 timestamp.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
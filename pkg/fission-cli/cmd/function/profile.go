@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	pprofProfilePath = "/debug/pprof/profile"
+	// time left to the pod on top of the profile duration to
+	// write out the profile
+	profileGracePeriod = 30 * time.Second
+)
+
+type CPUProfileSubCommand struct {
+	cmd.CommandActioner
+	function *fv1.Function
+	pod      *apiv1.Pod
+	port     int
+	output   string
+}
+
+// CPUProfile collects a CPU profile from the net/http/pprof endpoint
+// of a running Go function through the API server proxy and saves it
+// to a file, optionally opening it with 'go tool pprof'.
+func CPUProfile(input cli.Input) error {
+	return (&CPUProfileSubCommand{}).do(input)
+}
+
+func (opts *CPUProfileSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CPUProfileSubCommand) complete(input cli.Input) (err error) {
+	fnMeta := &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+	opts.function, err = opts.Client().V1().Function().Get(fnMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	if input.Duration(flagkey.FnProfileDuration) < time.Second {
+		return errors.Errorf("--%v must be at least 1s", flagkey.FnProfileDuration)
+	}
+
+	opts.port = pprofPort(opts.function, input.Int(flagkey.FnPort), input.IsSet(flagkey.FnPort))
+
+	opts.output = input.String(flagkey.FnProfileOutput)
+	if len(opts.output) == 0 {
+		opts.output = fnMeta.Name + "-cpu.pprof"
+	}
+
+	pods, err := opts.Client().V1().Function().ListPods(fnMeta)
+	if err != nil {
+		return errors.Wrap(err, "error listing function pods")
+	}
+	for i := range pods {
+		if pods[i].ObjectMeta.DeletionTimestamp == nil && isPodReady(&pods[i]) {
+			opts.pod = &pods[i]
+			break
+		}
+	}
+	if opts.pod == nil {
+		return errors.Errorf("function '%v' has no running pod to profile, invoke it first", fnMeta.Name)
+	}
+
+	return nil
+}
+
+func (opts *CPUProfileSubCommand) run(input cli.Input) error {
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	duration := input.Duration(flagkey.FnProfileDuration)
+	fmt.Printf("Collecting a %v CPU profile from pod %v/%v\n", duration, opts.pod.ObjectMeta.Namespace, opts.pod.ObjectMeta.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration+profileGracePeriod)
+	defer cancel()
+
+	seconds := strconv.Itoa(int(duration.Seconds()))
+	profile, err := kubeClient.CoreV1().Pods(opts.pod.ObjectMeta.Namespace).
+		ProxyGet("http", opts.pod.ObjectMeta.Name, strconv.Itoa(opts.port), pprofProfilePath, map[string]string{"seconds": seconds}).
+		DoRaw(ctx)
+	if err != nil {
+		if statusErr, ok := err.(*k8serrors.StatusError); ok && statusErr.Status().Code == http.StatusNotFound {
+			return errors.Errorf("pod %v doesn't serve %v on port %v, CPU profiles can only be collected from Go functions importing net/http/pprof",
+				opts.pod.ObjectMeta.Name, pprofProfilePath, opts.port)
+		}
+		return errors.Wrap(err, "error collecting CPU profile")
+	}
+
+	err = os.WriteFile(opts.output, profile, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error writing profile to %v", opts.output)
+	}
+	fmt.Printf("CPU profile of function '%v' saved to %v\n", opts.function.ObjectMeta.Name, opts.output)
+
+	if !input.Bool(flagkey.FnProfileInteractive) {
+		return nil
+	}
+
+	pprof := exec.Command("go", "tool", "pprof", opts.output)
+	pprof.Stdin = os.Stdin
+	pprof.Stdout = os.Stdout
+	pprof.Stderr = os.Stderr
+	err = pprof.Run()
+	if err != nil {
+		return errors.Wrap(err, "error running 'go tool pprof'")
+	}
+	return nil
+}
+
+// pprofPort returns the port the pprof endpoint is served on. Unless
+// given, container functions default to the first port of their
+// container, other functions to the port of the environment runtime.
+func pprofPort(fn *fv1.Function, port int, isSet bool) int {
+	if isSet || fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		return port
+	}
+	if fn.Spec.PodSpec != nil && len(fn.Spec.PodSpec.Containers) > 0 && len(fn.Spec.PodSpec.Containers[0].Ports) > 0 {
+		return int(fn.Spec.PodSpec.Containers[0].Ports[0].ContainerPort)
+	}
+	return port
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type RateLimitSubCommand struct {
+	cmd.CommandActioner
+}
+
+// RateLimit sets the rate limit of a function, leaving the rest of
+// the function spec untouched.
+func RateLimit(input cli.Input) error {
+	return (&RateLimitSubCommand{}).do(input)
+}
+
+func (opts *RateLimitSubCommand) do(input cli.Input) error {
+	rps := input.Int(flagkey.FnRateLimitRPS)
+	if rps < 0 {
+		return errors.Errorf("--%v must not be negative", flagkey.FnRateLimitRPS)
+	}
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	fn.Spec.RateLimit = rps
+	_, err = opts.Client().V1().Function().Update(fn)
+	if err != nil {
+		return errors.Wrap(err, "error updating function")
+	}
+
+	if rps == 0 {
+		fmt.Printf("Rate limit of function '%v' removed\n", fn.ObjectMeta.Name)
+	} else {
+		fmt.Printf("Function '%v' limited to %v requests per second\n", fn.ObjectMeta.Name, rps)
+	}
+	return nil
+}
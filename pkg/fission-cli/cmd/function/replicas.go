@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ReplicasSubCommand struct {
+	cmd.CommandActioner
+	function   *fv1.Function
+	kubeClient kubernetes.Interface
+	selector   string
+}
+
+// Replicas prints the replica counts of the deployments serving a
+// newdeploy or container function together with its autoscaler, or the
+// pool pods specialized for a poolmgr function.
+func Replicas(input cli.Input) error {
+	return (&ReplicasSubCommand{}).do(input)
+}
+
+func (opts *ReplicasSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ReplicasSubCommand) complete(input cli.Input) (err error) {
+	opts.function, err = opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	opts.selector = labels.Set{fv1.FUNCTION_UID: string(opts.function.ObjectMeta.UID)}.AsSelector().String()
+
+	_, opts.kubeClient, err = util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	return err
+}
+
+func (opts *ReplicasSubCommand) run(input cli.Input) error {
+	if opts.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypePoolmgr {
+		return opts.printPoolPods()
+	}
+	return opts.printDeployments()
+}
+
+func (opts *ReplicasSubCommand) printDeployments() error {
+	ctx := context.Background()
+	listOptions := metav1.ListOptions{LabelSelector: opts.selector}
+
+	deployments, err := opts.kubeClient.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return errors.Wrap(err, "error listing function deployments")
+	}
+	if len(deployments.Items) == 0 {
+		fmt.Printf("Function '%v' has no deployment yet, it is created on the first invocation\n", opts.function.ObjectMeta.Name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "DEPLOYMENT", "DESIRED", "READY", "AVAILABLE", "UNAVAILABLE")
+	for _, d := range deployments.Items {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", d.ObjectMeta.Name, desired, d.Status.ReadyReplicas,
+			d.Status.AvailableReplicas, d.Status.UnavailableReplicas)
+	}
+	w.Flush()
+
+	hpas, err := opts.kubeClient.AutoscalingV1().HorizontalPodAutoscalers(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return errors.Wrap(err, "error listing function autoscalers")
+	}
+	if len(hpas.Items) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "HPA", "MIN", "MAX", "CURRENT", "CPU (CURRENT/TARGET)")
+	for _, hpa := range hpas.Items {
+		minReplicas := int32(1)
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = *hpa.Spec.MinReplicas
+		}
+		current, target := "<unknown>", "<unknown>"
+		if hpa.Status.CurrentCPUUtilizationPercentage != nil {
+			current = fmt.Sprintf("%v%%", *hpa.Status.CurrentCPUUtilizationPercentage)
+		}
+		if hpa.Spec.TargetCPUUtilizationPercentage != nil {
+			target = fmt.Sprintf("%v%%", *hpa.Spec.TargetCPUUtilizationPercentage)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v/%v\n", hpa.ObjectMeta.Name, minReplicas, hpa.Spec.MaxReplicas,
+			hpa.Status.CurrentReplicas, current, target)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func (opts *ReplicasSubCommand) printPoolPods() error {
+	ctx := context.Background()
+	fn := opts.function
+
+	env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Environment.Name,
+		Namespace: fn.Spec.Environment.Namespace,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error getting environment '%v'", fn.Spec.Environment.Name)
+	}
+
+	pods, err := opts.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{
+			fv1.ENVIRONMENT_UID: string(env.ObjectMeta.UID),
+			fv1.EXECUTOR_TYPE:   string(fv1.ExecutorTypePoolmgr),
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error listing pool pods")
+	}
+
+	specialized, ready, idle := 0, 0, 0
+	for _, pod := range pods.Items {
+		if pod.ObjectMeta.DeletionTimestamp != nil {
+			continue
+		}
+		fnUID, ok := pod.ObjectMeta.Labels[fv1.FUNCTION_UID]
+		if !ok {
+			idle++
+			continue
+		}
+		if fnUID != string(fn.ObjectMeta.UID) {
+			continue
+		}
+		specialized++
+		if isPodReady(&pod) {
+			ready++
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "ENVIRONMENT", "SPECIALIZED", "READY", "IDLE POOL PODS")
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", env.ObjectMeta.Name, specialized, ready, idle)
+	w.Flush()
+
+	return nil
+}
+
+func isPodReady(pod *apiv1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == apiv1.PodReady {
+			return c.Status == apiv1.ConditionTrue
+		}
+	}
+	return false
+}
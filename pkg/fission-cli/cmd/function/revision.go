@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
+	_package "github.com/fission/fission/pkg/fission-cli/cmd/package"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// REVISION_HISTORY_ANNOTATION stores the revisions of a function as a
+// JSON list of FunctionRevision.
+const REVISION_HISTORY_ANNOTATION = "fission.io/revision-history"
+
+// FunctionRevision references a copy of the package a function used
+// before the package was updated. The copy is never updated, so the
+// archives it references are not removed by the archive pruner.
+type FunctionRevision struct {
+	Revision    int                      `json:"revision"`
+	PackageRef  fv1.PackageRef           `json:"packageRef"`
+	Environment fv1.EnvironmentReference `json:"environment"`
+	Timestamp   metav1.Time              `json:"timestamp"`
+}
+
+// getRevisionHistory returns the revision history of the function,
+// oldest revision first.
+func getRevisionHistory(fn *fv1.Function) ([]FunctionRevision, error) {
+	history := []FunctionRevision{}
+	val, ok := fn.ObjectMeta.Annotations[REVISION_HISTORY_ANNOTATION]
+	if !ok || len(val) == 0 {
+		return history, nil
+	}
+	err := json.Unmarshal([]byte(val), &history)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing annotation '%v' of function '%v'", REVISION_HISTORY_ANNOTATION, fn.ObjectMeta.Name)
+	}
+	return history, nil
+}
+
+// revisionPackage returns a copy of the package to keep as a revision
+// of the function.
+func revisionPackage(fn *fv1.Function, pkg *fv1.Package, revision int) *fv1.Package {
+	return &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.KubifyName(fmt.Sprintf("%v-rev%v-%v", fn.ObjectMeta.Name, revision, uniuri.NewLen(4))),
+			Namespace: pkg.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				_package.REVISION_FUNCTION_LABEL: fn.ObjectMeta.Name,
+			},
+		},
+		Spec:   *pkg.Spec.DeepCopy(),
+		Status: *pkg.Status.DeepCopy(),
+	}
+}
+
+// recordRevision copies the given package, appends the copy to the
+// revision history of the function and keeps only the last limit
+// revisions. The revisions dropped from the history are returned, their
+// packages are deleted with deleteRevisions once the function is saved.
+func recordRevision(client client.Interface, fn *fv1.Function, pkg *fv1.Package, limit int) ([]FunctionRevision, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	history, err := getRevisionHistory(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 1
+	if len(history) > 0 {
+		next = history[len(history)-1].Revision + 1
+	}
+
+	pkgMeta, err := client.V1().Package().Create(revisionPackage(fn, pkg, next))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating revision package")
+	}
+
+	history = append(history, FunctionRevision{
+		Revision: next,
+		PackageRef: fv1.PackageRef{
+			Namespace:       pkgMeta.Namespace,
+			Name:            pkgMeta.Name,
+			ResourceVersion: pkgMeta.ResourceVersion,
+		},
+		Environment: pkg.Spec.Environment,
+		Timestamp:   metav1.Time{Time: time.Now().UTC()},
+	})
+	var dropped []FunctionRevision
+	if len(history) > limit {
+		dropped = history[:len(history)-limit]
+		history = history[len(history)-limit:]
+	}
+
+	bs, err := json.Marshal(history)
+	if err != nil {
+		return nil, errors.Wrap(err, "error serializing revision history")
+	}
+
+	if fn.ObjectMeta.Annotations == nil {
+		fn.ObjectMeta.Annotations = make(map[string]string)
+	}
+	fn.ObjectMeta.Annotations[REVISION_HISTORY_ANNOTATION] = string(bs)
+
+	return dropped, nil
+}
+
+// deleteRevisions deletes the packages of revisions dropped from the
+// revision history. Failures are only reported, the packages are left
+// to 'fission pkg prune'.
+func deleteRevisions(client client.Interface, revisions []FunctionRevision) {
+	for _, rev := range revisions {
+		err := client.V1().Package().Delete(&metav1.ObjectMeta{
+			Name:      rev.PackageRef.Name,
+			Namespace: rev.PackageRef.Namespace,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			console.Warn(fmt.Sprintf("Error deleting package '%v' of revision %v: %v", rev.PackageRef.Name, rev.Revision, err))
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type RollbackSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Rollback copies the package of a previous revision and points the
+// function to the copy, the revision package itself is kept unchanged.
+func Rollback(input cli.Input) error {
+	return (&RollbackSubCommand{}).do(input)
+}
+
+func (opts *RollbackSubCommand) do(input cli.Input) error {
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	history, err := getRevisionHistory(fn)
+	if err != nil {
+		return err
+	}
+
+	revision := input.Int(flagkey.FnRevision)
+	var rev *FunctionRevision
+	for i := range history {
+		if history[i].Revision == revision {
+			rev = &history[i]
+			break
+		}
+	}
+	if rev == nil {
+		return errors.Errorf("revision %v of function '%v' not found, see 'fission fn history'", revision, fn.ObjectMeta.Name)
+	}
+
+	revPkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      rev.PackageRef.Name,
+		Namespace: rev.PackageRef.Namespace,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error getting package of revision %v", rev.Revision)
+	}
+	current, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Package.PackageRef.Name,
+		Namespace: fn.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting current function package")
+	}
+
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.KubifyName(fmt.Sprintf("%v-%v", fn.ObjectMeta.Name, uniuri.NewLen(4))),
+			Namespace: fn.ObjectMeta.Namespace,
+		},
+		Spec:   *revPkg.Spec.DeepCopy(),
+		Status: *revPkg.Status.DeepCopy(),
+	}
+	pkg.Status.LastUpdateTimestamp = metav1.Time{Time: time.Now().UTC()}
+	pkgMeta, err := opts.Client().V1().Package().Create(pkg)
+	if err != nil {
+		return errors.Wrap(err, "error creating package")
+	}
+
+	// record the current package so that the rollback can be reverted,
+	// after copying the revision package which may be dropped here
+	dropped, err := recordRevision(opts.Client(), fn, current, input.Int(flagkey.FnRevisionHistoryLimit))
+	if err != nil {
+		return err
+	}
+
+	fn.Spec.Package.PackageRef = fv1.PackageRef{
+		Namespace:       pkgMeta.Namespace,
+		Name:            pkgMeta.Name,
+		ResourceVersion: pkgMeta.ResourceVersion,
+	}
+	fn.Spec.Environment = revPkg.Spec.Environment
+
+	_, err = opts.Client().V1().Function().Update(fn)
+	if err != nil {
+		return errors.Wrap(err, "error updating function")
+	}
+	deleteRevisions(opts.Client(), dropped)
+
+	fmt.Printf("Function '%v' rolled back to revision %v with package '%v'\n", fn.ObjectMeta.Name, rev.Revision, pkgMeta.Name)
+	return nil
+}
+
+type HistorySubCommand struct {
+	cmd.CommandActioner
+}
+
+// History prints the revisions recorded for a function.
+func History(input cli.Input) error {
+	return (&HistorySubCommand{}).do(input)
+}
+
+func (opts *HistorySubCommand) do(input cli.Input) error {
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	history, err := getRevisionHistory(fn)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "REVISION", "PACKAGE", "ENV", "RECORDED")
+	for _, rev := range history {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", rev.Revision, rev.PackageRef.Name, rev.Environment.Name,
+			rev.Timestamp.Format(time.RFC3339))
+	}
+	w.Flush()
+
+	return nil
+}
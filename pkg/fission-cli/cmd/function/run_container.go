@@ -77,9 +77,9 @@ func (opts *RunContainerSubCommand) complete(input cli.Input) error {
 		}
 	}
 
-	fnTimeout := input.Int(flagkey.FnExecutionTimeout)
-	if fnTimeout <= 0 {
-		return errors.Errorf("--%v must be greater than 0", flagkey.FnExecutionTimeout)
+	fnTimeout, err := getFunctionTimeout(input, input.Int(flagkey.FnExecutionTimeout))
+	if err != nil {
+		return err
 	}
 
 	fnIdleTimeout := input.Int(flagkey.FnIdleTimeout)
@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ScaleSubCommand struct {
+	cmd.CommandActioner
+	function *fv1.Function
+}
+
+// Scale updates the min/max scale of a newdeploy or container function.
+func Scale(input cli.Input) error {
+	return (&ScaleSubCommand{}).do(input)
+}
+
+func (opts *ScaleSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ScaleSubCommand) complete(input cli.Input) error {
+	if !input.IsSet(flagkey.FnScaleMin) && !input.IsSet(flagkey.FnScaleMax) {
+		return errors.Errorf("need --%v or --%v argument", flagkey.FnScaleMin, flagkey.FnScaleMax)
+	}
+
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	strategy := &fn.Spec.InvokeStrategy.ExecutionStrategy
+	if strategy.ExecutorType == fv1.ExecutorTypePoolmgr {
+		return errors.Errorf("function '%v' uses executor type '%v', scaling is only supported for '%v' and '%v' functions",
+			fn.ObjectMeta.Name, fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer)
+	}
+
+	minScale, maxScale := strategy.MinScale, strategy.MaxScale
+	if input.IsSet(flagkey.FnScaleMin) {
+		minScale = input.Int(flagkey.FnScaleMin)
+	}
+	if input.IsSet(flagkey.FnScaleMax) {
+		maxScale = input.Int(flagkey.FnScaleMax)
+	}
+
+	if minScale < 0 {
+		return errors.Errorf("--%v must not be negative", flagkey.FnScaleMin)
+	}
+	if maxScale <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.FnScaleMax)
+	}
+	if minScale > maxScale {
+		return errors.Errorf("min scale (%v) must not be greater than max scale (%v)", minScale, maxScale)
+	}
+
+	strategy.MinScale = minScale
+	strategy.MaxScale = maxScale
+	opts.function = fn
+
+	err = opts.checkResourceQuota(input)
+	if err != nil {
+		// the quota check is best effort, don't block scaling
+		console.Verbose(2, "Unable to check resource quota: %v", err)
+	}
+
+	return nil
+}
+
+// checkResourceQuota warns if max scale pods with the container limits
+// of the function (or its environment) exceed a resource quota of the
+// function namespace.
+func (opts *ScaleSubCommand) checkResourceQuota(input cli.Input) error {
+	fn := opts.function
+
+	limits := fn.Spec.Resources.Limits
+	if len(limits) == 0 && fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeContainer {
+		env, err := opts.Client().V1().Environment().Get(&metav1.ObjectMeta{
+			Name:      fn.Spec.Environment.Name,
+			Namespace: fn.Spec.Environment.Namespace,
+		})
+		if err != nil {
+			return err
+		}
+		limits = env.Spec.Resources.Limits
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	quotas, err := kubeClient.CoreV1().ResourceQuotas(fn.ObjectMeta.Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	maxScale := int64(fn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale)
+	for _, quota := range quotas.Items {
+		for quotaResource, podResource := range map[apiv1.ResourceName]apiv1.ResourceName{
+			apiv1.ResourceLimitsCPU:    apiv1.ResourceCPU,
+			apiv1.ResourceLimitsMemory: apiv1.ResourceMemory,
+		} {
+			hard, ok := quota.Spec.Hard[quotaResource]
+			if !ok {
+				continue
+			}
+			perPod, ok := limits[podResource]
+			if !ok {
+				continue
+			}
+			var needed *resource.Quantity
+			if podResource == apiv1.ResourceCPU {
+				needed = resource.NewMilliQuantity(perPod.MilliValue()*maxScale, perPod.Format)
+			} else {
+				needed = resource.NewQuantity(perPod.Value()*maxScale, perPod.Format)
+			}
+			if needed.Cmp(hard) > 0 {
+				console.Warn(fmt.Sprintf("%v pods with %v limit %v need %v, which exceeds the '%v' of %v in resource quota '%v'",
+					maxScale, podResource, perPod.String(), needed.String(), quotaResource, hard.String(), quota.ObjectMeta.Name))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (opts *ScaleSubCommand) run(input cli.Input) error {
+	_, err := opts.Client().V1().Function().Update(opts.function)
+	if err != nil {
+		return errors.Wrap(err, "error updating function")
+	}
+
+	strategy := opts.function.Spec.InvokeStrategy.ExecutionStrategy
+	fmt.Printf("Function '%v' scaled to min %v, max %v\n", opts.function.ObjectMeta.Name, strategy.MinScale, strategy.MaxScale)
+	return nil
+}
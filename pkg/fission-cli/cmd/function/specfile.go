@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+)
+
+// functionSpecExample is printed by 'fission fn spec-example' and
+// documents the file format accepted by --spec-file.
+const functionSpecExample = `# Function spec for 'fission fn create/update --spec-file'.
+# Any flag given on the command line takes precedence over the file.
+
+# Environment the function runs in (required unless --env or --pkgname is given).
+environment:
+  name: nodejs
+  namespace: default
+
+# Package holding the function code (optional if --code, --deploy or --src is given).
+package:
+  functionName: handler
+  packageRef:
+    name: hello-pkg
+    namespace: default
+
+# Secrets and config maps mounted into the function pod.
+secrets:
+- name: my-secret
+  namespace: default
+configmaps:
+- name: my-config
+  namespace: default
+
+# Resource requests and limits (only used by newdeploy and container functions).
+resources:
+  requests:
+    cpu: 100m
+    memory: 128Mi
+  limits:
+    cpu: 200m
+    memory: 256Mi
+
+InvokeStrategy:
+  StrategyType: execution
+  ExecutionStrategy:
+    ExecutorType: newdeploy   # poolmgr, newdeploy or container
+    MinScale: 1
+    MaxScale: 3
+    TargetCPUPercent: 80
+    SpecializationTimeout: 120
+
+functionTimeout: 60   # seconds
+idletimeout: 120      # seconds
+concurrency: 500
+requestsPerPod: 1
+onceOnly: false
+`
+
+// SpecExample prints an example function spec file.
+func SpecExample(input cli.Input) error {
+	fmt.Print(functionSpecExample)
+	return nil
+}
+
+// readFunctionSpecFile reads a YAML file holding a function spec.
+func readFunctionSpecFile(path string) (*fv1.FunctionSpec, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading function spec file '%v'", path)
+	}
+	fnSpec := &fv1.FunctionSpec{}
+	err = yaml.Unmarshal(bs, fnSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing function spec file '%v'", path)
+	}
+	return fnSpec, nil
+}
+
+// mergeFunctionSpec copies the fields set in src over dst.
+func mergeFunctionSpec(dst, src *fv1.FunctionSpec) {
+	if len(src.Environment.Name) > 0 {
+		dst.Environment = src.Environment
+	}
+	if len(src.Package.FunctionName) > 0 {
+		dst.Package.FunctionName = src.Package.FunctionName
+	}
+	if len(src.Package.PackageRef.Name) > 0 {
+		dst.Package.PackageRef = src.Package.PackageRef
+	}
+	if len(src.Secrets) > 0 {
+		dst.Secrets = src.Secrets
+	}
+	if len(src.ConfigMaps) > 0 {
+		dst.ConfigMaps = src.ConfigMaps
+	}
+	if len(src.Resources.Requests) > 0 {
+		dst.Resources.Requests = src.Resources.Requests
+	}
+	if len(src.Resources.Limits) > 0 {
+		dst.Resources.Limits = src.Resources.Limits
+	}
+	if len(src.InvokeStrategy.ExecutionStrategy.ExecutorType) > 0 {
+		dst.InvokeStrategy = src.InvokeStrategy
+		dst.InvokeStrategy.StrategyType = fv1.StrategyTypeExecution
+	}
+	if src.FunctionTimeout > 0 {
+		dst.FunctionTimeout = src.FunctionTimeout
+	}
+	if src.IdleTimeout != nil {
+		dst.IdleTimeout = src.IdleTimeout
+	}
+	if src.Concurrency > 0 {
+		dst.Concurrency = src.Concurrency
+	}
+	if src.RequestsPerPod > 0 {
+		dst.RequestsPerPod = src.RequestsPerPod
+	}
+	if src.OnceOnly {
+		dst.OnceOnly = src.OnceOnly
+	}
+	if src.PodSpec != nil {
+		dst.PodSpec = src.PodSpec
+	}
+	if len(src.ConcurrencyModel) > 0 {
+		dst.ConcurrencyModel = src.ConcurrencyModel
+	}
+}
+
+// validateFunction checks the required fields of a function built
+// from a spec file before it is submitted.
+func validateFunction(fn *fv1.Function) error {
+	err := fn.Validate()
+	if err != nil {
+		return fv1.AggregateValidationErrors("Function", err)
+	}
+	return nil
+}
@@ -18,10 +18,13 @@ package function
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -30,6 +33,7 @@ import (
 	"go.opentelemetry.io/otel"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/controller/client"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
@@ -59,12 +63,21 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 		console.Warn("The environment variable FISSION_ROUTER is no longer supported for this command")
 	}
 
+	// With one Fission install per tenant, the function is only served
+	// by the router of the tenant's install.
+	routerNamespace := input.String(flagkey.FnTestRouterNamespace)
+	if len(routerNamespace) == 0 {
+		routerNamespace = util.GetFissionNamespace()
+	}
+
 	// Portforward to the fission router
-	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", kubeContext)
+	localRouterPort, err := util.SetupPortForward(routerNamespace, "application=fission-router", kubeContext)
 	if err != nil {
 		return err
 	}
+	// the router resolves the function by the namespace in the URL path
 	fnURL := "http://127.0.0.1:" + localRouterPort + util.UrlForFunction(m.Name, m.Namespace)
+	console.Verbose(2, "Invoking function '%v' in namespace '%v' through the router in namespace '%v'", m.Name, m.Namespace, routerNamespace)
 	if input.IsSet(flagkey.FnSubPath) {
 		subPath := input.String(flagkey.FnSubPath)
 		if !strings.HasPrefix(subPath, "/") {
@@ -122,22 +135,51 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 	if err != nil {
 		return err
 	}
+	reqBody, contentType, err := getRequestBody(input)
+	if err != nil {
+		return err
+	}
+	headers := withContentType(input.StringSlice(flagkey.FnTestHeader), contentType)
+	headers, err = withAuthToken(headers)
+	if err != nil {
+		return err
+	}
+	if input.Bool(flagkey.FnTestWebSocket) {
+		return testWebSocket(ctx, os.Stdout, functionUrl, headers, reqBody)
+	}
+	// the router echoes the invoke time and reports the cold start
+	timing := input.Bool(flagkey.FnTestTiming)
+	invokeSent := time.Now()
+	if timing {
+		headers = append(headers, fv1.HeaderInvokeTime+":"+invokeSent.Format(time.RFC3339Nano))
+	}
 	resp, err := doHTTPRequest(ctx, functionUrl.String(),
-		input.StringSlice(flagkey.FnTestHeader),
+		headers,
 		method,
-		input.String(flagkey.FnTestBody))
+		reqBody)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
+	if input.Bool(flagkey.FnTestStream) && resp.StatusCode < 400 {
+		return streamResponse(ctx, os.Stdout, resp.Body)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return errors.Wrap(err, "error reading response from function")
 	}
+	if timing {
+		printInvocationTiming(invokeSent, time.Now(), resp.Header)
+	}
 
 	if resp.StatusCode < 400 {
 		os.Stdout.Write(body)
+		if code := exitCodeForStatus(resp.StatusCode); code != 0 && !input.Bool(flagkey.FnTestIgnoreError) {
+			fmt.Fprintf(os.Stderr, "HTTP status code: %v\n", resp.StatusCode)
+			return cmd.ExitError{Code: code}
+		}
 		return nil
 	}
 
@@ -152,7 +194,169 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 	} else {
 		console.Info(log)
 	}
-	return errors.New("error getting function response")
+	if input.Bool(flagkey.FnTestIgnoreError) {
+		return nil
+	}
+	// the status code is mapped to the exit code, so that scripts can
+	// tell client errors from function failures
+	return cmd.ExitError{Code: exitCodeForStatus(resp.StatusCode)}
+}
+
+// exitCodeForStatus maps the HTTP status code of the function response
+// to the exit code of 'fn test': 0 for 2xx, 2 for 4xx, 3 for 5xx and 1
+// for anything else.
+func exitCodeForStatus(status int) int {
+	switch {
+	case status >= 200 && status < 300:
+		return 0
+	case status >= 400 && status < 500:
+		return 2
+	case status >= 500 && status < 600:
+		return 3
+	default:
+		return 1
+	}
+}
+
+type invocationTiming struct {
+	total     time.Duration
+	coldStart time.Duration
+	execution time.Duration
+}
+
+// getInvocationTiming splits the time between sending the request and
+// receiving the response into the cold start reported by the router and
+// the execution time. It returns false if the router didn't echo the
+// invoke time, e.g. a router of an older release.
+func getInvocationTiming(sent, received time.Time, header http.Header) (invocationTiming, bool) {
+	timing := invocationTiming{total: received.Sub(sent)}
+	if header.Get(fv1.HeaderRouterTime) != sent.Format(time.RFC3339Nano) {
+		return timing, false
+	}
+	coldStart, err := time.ParseDuration(header.Get(fv1.HeaderColdStartDuration))
+	if err != nil {
+		return timing, false
+	}
+	// the cold start is measured by the router, it can't take longer
+	// than the whole round trip
+	if coldStart > timing.total {
+		coldStart = timing.total
+	}
+	timing.coldStart = coldStart
+	timing.execution = timing.total - coldStart
+	return timing, true
+}
+
+// printInvocationTiming prints the timing to stderr, which keeps the
+// function response on stdout untouched.
+func printInvocationTiming(sent, received time.Time, header http.Header) {
+	timing, ok := getInvocationTiming(sent, received, header)
+	if !ok {
+		console.Verbose(2, "Router didn't report the cold start duration")
+		fmt.Fprintf(os.Stderr, "Total: %v\n", timing.total.Round(time.Millisecond))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Total: %v, cold start: %v, execution: %v\n", timing.total.Round(time.Millisecond),
+		timing.coldStart.Round(time.Millisecond), timing.execution.Round(time.Millisecond))
+}
+
+// getRequestBody returns the request body given with --body or read from
+// --body-file, and the Content-Type to send it with. The type is detected
+// from the file extension unless --content-type is given.
+func getRequestBody(input cli.Input) (string, string, error) {
+	contentType := input.String(flagkey.FnTestContentType)
+	if !input.IsSet(flagkey.FnTestBodyFile) {
+		return input.String(flagkey.FnTestBody), contentType, nil
+	}
+	if input.IsSet(flagkey.FnTestBody) {
+		return "", "", errors.Errorf("--%v and --%v cannot be used together", flagkey.FnTestBody, flagkey.FnTestBodyFile)
+	}
+
+	file := input.String(flagkey.FnTestBodyFile)
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "error reading request body from '%v'", file)
+	}
+	if len(contentType) == 0 {
+		contentType = contentTypeForFile(file)
+	}
+	return string(body), contentType, nil
+}
+
+func contentTypeForFile(file string) string {
+	ext := strings.ToLower(filepath.Ext(file))
+	switch ext {
+	case ".json":
+		return "application/json"
+	case ".xml":
+		return "application/xml"
+	}
+	return mime.TypeByExtension(ext)
+}
+
+// hasHeader reports whether a header of the given name is in headers,
+// given as "Key: value" like curl -H.
+func hasHeader(headers []string, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(strings.TrimSpace(strings.SplitN(header, ":", 2)[0]), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// withContentType adds the Content-Type header, unless it's empty or
+// a Content-Type header is given with -H already.
+func withContentType(headers []string, contentType string) []string {
+	if len(contentType) == 0 || hasHeader(headers, "Content-Type") {
+		return headers
+	}
+	return append(headers, "Content-Type:"+contentType)
+}
+
+// withAuthToken adds the token cached by 'fission auth token' as a
+// Bearer token, unless an Authorization header is given already.
+func withAuthToken(headers []string) ([]string, error) {
+	if hasHeader(headers, "Authorization") {
+		return headers, nil
+	}
+	token, err := util.LoadAuthToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return withConfigAuthToken(headers)
+	}
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		console.Warn("Cached auth token expired, run 'fission auth token' to get a new one")
+		return withConfigAuthToken(headers)
+	}
+	// stderr keeps the function response on stdout untouched
+	fmt.Fprintf(os.Stderr, "Using cached auth token, expires in %v\n", ttl.Round(time.Second))
+	return append(headers, "Authorization:Bearer "+token.Token), nil
+}
+
+// withConfigAuthToken adds the auth token of the CLI config, if any.
+func withConfigAuthToken(headers []string) ([]string, error) {
+	config, err := util.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.AuthToken) == 0 {
+		return headers, nil
+	}
+	return append(headers, "Authorization:Bearer "+config.AuthToken), nil
+}
+
+// parseHeader splits a header given as "Key: value", like curl -H, on
+// the first colon. Values may contain colons themselves.
+func parseHeader(header string) (string, string, error) {
+	kv := strings.SplitN(header, ":", 2)
+	if len(kv) != 2 || len(strings.TrimSpace(kv[0])) == 0 {
+		return "", "", errors.Errorf("invalid header '%v', must be of the form 'Key: value'", header)
+	}
+	return strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]), nil
 }
 
 func doHTTPRequest(ctx context.Context, url string, headers []string, method, body string) (*http.Response, error) {
@@ -179,11 +383,11 @@ func doHTTPRequest(ctx context.Context, url string, headers []string, method, bo
 	}
 
 	for _, header := range headers {
-		headerKeyValue := strings.SplitN(header, ":", 2)
-		if len(headerKeyValue) != 2 {
-			return nil, errors.New("failed to create request without appropriate headers")
+		key, value, err := parseHeader(header)
+		if err != nil {
+			return nil, err
 		}
-		req.Header.Set(headerKeyValue[0], headerKeyValue[1])
+		req.Header.Add(key, value)
 	}
 	hc := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
 	resp, err := hc.Do(req.WithContext(ctx))
@@ -194,6 +398,31 @@ func doHTTPRequest(ctx context.Context, url string, headers []string, method, bo
 	return resp, nil
 }
 
+// streamResponse copies the response body to writer chunk by chunk as
+// it arrives. Reaching the --timeout deadline ends the stream without
+// returning an error.
+func streamResponse(ctx context.Context, writer io.Writer, body io.Reader) error {
+	buf := make([]byte, 4096)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, werr := writer.Write(buf[:n]); werr != nil {
+				return errors.Wrap(werr, "error writing function response")
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				console.Verbose(2, "Timeout reached, closing response stream")
+				return nil
+			}
+			return errors.Wrap(err, "error streaming response from function")
+		}
+	}
+}
+
 func printPodLogs(client client.Interface, fnMeta *metav1.ObjectMeta) (string, error) {
 	reader, statusCode, err := client.V1().Misc().PodLogs(fnMeta)
 	if err != nil {
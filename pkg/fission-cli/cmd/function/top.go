@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+type TopSubCommand struct {
+	cmd.CommandActioner
+	metrics  metricsclient.Interface
+	selector string
+	interval time.Duration
+}
+
+// functionUsage is the resource usage of all pods of a function. CPU
+// is in millicores and memory in bytes.
+type functionUsage struct {
+	namespace string
+	name      string
+	pods      int
+	cpu       int64
+	memory    int64
+}
+
+// Top shows the CPU and memory usage of function pods, summed per
+// function, and refreshes it until interrupted.
+func Top(input cli.Input) error {
+	return (&TopSubCommand{}).do(input)
+}
+
+func (opts *TopSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *TopSubCommand) complete(input cli.Input) error {
+	opts.interval = input.Duration(flagkey.FnTopInterval)
+	if opts.interval <= 0 {
+		return errors.Errorf("--%v must be greater than 0", flagkey.FnTopInterval)
+	}
+
+	selector := labels.NewSelector()
+	req, err := labels.NewRequirement(fv1.FUNCTION_NAME, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector = selector.Add(*req)
+	// the namespace flag has a default, only filter if it's given
+	if input.IsSet(flagkey.NamespaceFunction) {
+		ns := input.String(flagkey.NamespaceFunction)
+		req, err = labels.NewRequirement(fv1.FUNCTION_NAMESPACE, selection.Equals, []string{ns})
+		if err != nil {
+			return err
+		}
+		selector = selector.Add(*req)
+	}
+	opts.selector = selector.String()
+
+	restConfig, _, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	opts.metrics, err = metricsclient.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "error creating metrics client")
+	}
+
+	return nil
+}
+
+func (opts *TopSubCommand) run(input cli.Input) error {
+	refresh := isTerminal(os.Stdout)
+	var previous map[string]functionUsage
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for {
+		usage, err := opts.sample()
+		if err != nil {
+			return err
+		}
+		if refresh {
+			fmt.Print(clearScreen)
+		}
+		printFunctionUsage(os.Stdout, usage, previous)
+
+		previous = make(map[string]functionUsage, len(usage))
+		for _, u := range usage {
+			previous[u.namespace+"/"+u.name] = u
+		}
+		<-ticker.C
+	}
+}
+
+// sample fetches the current usage of function pods from the metrics
+// API, which is served by metrics-server.
+func (opts *TopSubCommand) sample() ([]functionUsage, error) {
+	// function pods run in the fission-function namespace or, for
+	// newdeploy and container functions, in the namespace of the
+	// function, so query them across namespaces
+	podMetrics, err := opts.metrics.MetricsV1beta1().PodMetricses(metav1.NamespaceAll).List(context.Background(),
+		metav1.ListOptions{LabelSelector: opts.selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting pod metrics, is metrics-server installed?")
+	}
+	return aggregatePodMetrics(podMetrics.Items), nil
+}
+
+// aggregatePodMetrics sums the usage of the containers of each pod by
+// the function the pod serves, sorted by namespace and name.
+func aggregatePodMetrics(podMetrics []metricsv1beta1.PodMetrics) []functionUsage {
+	byFunction := make(map[string]*functionUsage)
+	for _, pm := range podMetrics {
+		ns := pm.ObjectMeta.Labels[fv1.FUNCTION_NAMESPACE]
+		name := pm.ObjectMeta.Labels[fv1.FUNCTION_NAME]
+		key := ns + "/" + name
+		u, ok := byFunction[key]
+		if !ok {
+			u = &functionUsage{namespace: ns, name: name}
+			byFunction[key] = u
+		}
+		u.pods++
+		for _, c := range pm.Containers {
+			u.cpu += c.Usage.Cpu().MilliValue()
+			u.memory += c.Usage.Memory().Value()
+		}
+	}
+
+	usage := make([]functionUsage, 0, len(byFunction))
+	for _, u := range byFunction {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].namespace != usage[j].namespace {
+			return usage[i].namespace < usage[j].namespace
+		}
+		return usage[i].name < usage[j].name
+	})
+	return usage
+}
+
+// printFunctionUsage prints the usage table with the change since the
+// previous sample, if there is one.
+func printFunctionUsage(writer io.Writer, usage []functionUsage, previous map[string]functionUsage) {
+	w := tabwriter.NewWriter(writer, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, strings.Join([]string{"NAMESPACE", "NAME", "PODS", "CPU(cores)", "CPU DELTA", "MEMORY(bytes)", "MEMORY DELTA"}, "\t"))
+	for _, u := range usage {
+		cpuDelta, memDelta := "-", "-"
+		if prev, ok := previous[u.namespace+"/"+u.name]; ok {
+			cpuDelta = formatDelta(u.cpu-prev.cpu, "m", 1)
+			memDelta = formatDelta(u.memory-prev.memory, "Mi", 1024*1024)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%vm\t%v\t%vMi\t%v\n",
+			u.namespace, u.name, u.pods, u.cpu, cpuDelta, u.memory/(1024*1024), memDelta)
+	}
+	w.Flush()
+}
+
+func formatDelta(delta int64, unit string, divisor int64) string {
+	delta /= divisor
+	if delta > 0 {
+		return fmt.Sprintf("+%v%v", delta, unit)
+	}
+	return fmt.Sprintf("%v%v", delta, unit)
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/httptrigger"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	ENV_TRACE_BACKEND = "FISSION_TRACE_BACKEND"
+
+	traceWaitTimeout  = 30 * time.Second
+	tracePollInterval = time.Second
+)
+
+type TraceSubCommand struct {
+	cmd.CommandActioner
+	meta    *metav1.ObjectMeta
+	backend string
+}
+
+// traceSpan is a span as returned by either Jaeger or Tempo.
+type traceSpan struct {
+	spanID    string
+	parentID  string
+	service   string
+	operation string
+	start     time.Time
+	duration  time.Duration
+}
+
+// Trace invokes a function with a trace context injected by the CLI, then
+// fetches the resulting trace from Jaeger or Tempo and prints the span tree.
+func Trace(input cli.Input) error {
+	return (&TraceSubCommand{}).do(input)
+}
+
+func (opts *TraceSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *TraceSubCommand) complete(input cli.Input) error {
+	opts.meta = &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	opts.backend = input.String(flagkey.FnTraceBackend)
+	if len(opts.backend) == 0 {
+		opts.backend = os.Getenv(ENV_TRACE_BACKEND)
+	}
+	if len(opts.backend) == 0 {
+		return errors.Errorf("need --%v or the %v environment variable to query traces", flagkey.FnTraceBackend, ENV_TRACE_BACKEND)
+	}
+	opts.backend = strings.TrimSuffix(opts.backend, "/")
+
+	return nil
+}
+
+func (opts *TraceSubCommand) run(input cli.Input) error {
+	traceID, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	spanID, err := randomHex(8)
+	if err != nil {
+		return err
+	}
+
+	localRouterPort, err := util.SetupPortForward(util.GetFissionNamespace(), "application=fission-router", input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+	fnURL := "http://127.0.0.1:" + localRouterPort + util.UrlForFunction(opts.meta.Name, opts.meta.Namespace)
+	if input.IsSet(flagkey.FnSubPath) {
+		fnURL = fnURL + "/" + strings.TrimPrefix(input.String(flagkey.FnSubPath), "/")
+	}
+
+	methods := input.StringSlice(flagkey.HtMethod)
+	if len(methods) != 1 {
+		return errors.New("exactly one HTTP method must be given")
+	}
+	method, err := httptrigger.GetMethod(methods[0])
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, fnURL, strings.NewReader(input.String(flagkey.FnTestBody)))
+	if err != nil {
+		return errors.Wrap(err, "error creating HTTP request")
+	}
+	for _, header := range input.StringSlice(flagkey.FnTestHeader) {
+		kv := strings.SplitN(header, ":", 2)
+		if len(kv) != 2 {
+			return errors.Errorf("invalid header '%v', must be of the form key:value", header)
+		}
+		req.Header.Set(kv[0], kv[1])
+	}
+	// both W3C and B3 propagation, whichever the router is configured with
+	req.Header.Set("traceparent", fmt.Sprintf("00-%v-%v-01", traceID, spanID))
+	req.Header.Set("X-B3-TraceId", traceID)
+	req.Header.Set("X-B3-SpanId", spanID)
+	req.Header.Set("X-B3-Sampled", "1")
+
+	hc := &http.Client{Timeout: input.Duration(flagkey.FnTestTimeout)}
+	if hc.Timeout < 0 {
+		hc.Timeout = 0
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error executing HTTP request")
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return errors.Wrap(err, "error reading function response")
+	}
+
+	fmt.Printf("Function '%v' returned %v, trace ID %v\n", opts.meta.Name, resp.StatusCode, traceID)
+	console.Verbose(2, "Waiting for trace to appear in %v", opts.backend)
+
+	spans, err := opts.waitForTrace(traceID, spanID)
+	if err != nil {
+		return err
+	}
+	printTraceTree(os.Stdout, spans)
+
+	return nil
+}
+
+// waitForTrace polls the backend until the trace is complete, since
+// spans are exported in batches some time after the request finished.
+// The trace is considered complete once the root span, the child of the
+// span made up by the CLI, has been exported and no new spans showed up
+// since the previous poll. A partial trace is returned on timeout.
+func (opts *TraceSubCommand) waitForTrace(traceID string, parentSpanID string) ([]traceSpan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), traceWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(tracePollInterval)
+	defer ticker.Stop()
+	var spans []traceSpan
+	for {
+		latest, err := opts.getTrace(ctx, traceID)
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) > 0 && len(latest) == len(spans) && hasChildOf(latest, parentSpanID) {
+			return latest, nil
+		}
+		if len(latest) > 0 || ctx.Err() == nil {
+			spans = latest
+		}
+		select {
+		case <-ctx.Done():
+			if len(spans) == 0 {
+				return nil, errors.Errorf("trace %v did not appear in %v within %v", traceID, opts.backend, traceWaitTimeout)
+			}
+			console.Warn(fmt.Sprintf("Trace %v may be incomplete, spans were still arriving after %v", traceID, traceWaitTimeout))
+			return spans, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func hasChildOf(spans []traceSpan, parentSpanID string) bool {
+	for _, s := range spans {
+		if s.parentID == parentSpanID {
+			return true
+		}
+	}
+	return false
+}
+
+// getTrace returns the spans of the trace, or nothing if the backend
+// doesn't know about the trace yet. Jaeger and Tempo both serve traces
+// at /api/traces/<id>, the format of the response tells them apart.
+func (opts *TraceSubCommand) getTrace(ctx context.Context, traceID string) ([]traceSpan, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.backend+"/api/traces/"+traceID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating trace request")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error querying trace backend %v", opts.backend)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading trace")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("error querying trace backend, status code: '%v': %v", resp.StatusCode, string(body))
+	}
+	return parseTrace(body)
+}
+
+func parseTrace(body []byte) ([]traceSpan, error) {
+	var probe struct {
+		Data    json.RawMessage `json:"data"`
+		Batches json.RawMessage `json:"batches"`
+	}
+	err := json.Unmarshal(body, &probe)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding trace")
+	}
+	if len(probe.Batches) > 0 {
+		return parseTempoTrace(body)
+	}
+	return parseJaegerTrace(body)
+}
+
+func parseJaegerTrace(body []byte) ([]traceSpan, error) {
+	var trace struct {
+		Data []struct {
+			Spans []struct {
+				SpanID        string `json:"spanID"`
+				OperationName string `json:"operationName"`
+				References    []struct {
+					RefType string `json:"refType"`
+					SpanID  string `json:"spanID"`
+				} `json:"references"`
+				StartTime int64  `json:"startTime"`
+				Duration  int64  `json:"duration"`
+				ProcessID string `json:"processID"`
+			} `json:"spans"`
+			Processes map[string]struct {
+				ServiceName string `json:"serviceName"`
+			} `json:"processes"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(body, &trace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding Jaeger trace")
+	}
+
+	var spans []traceSpan
+	for _, data := range trace.Data {
+		for _, s := range data.Spans {
+			span := traceSpan{
+				spanID:    s.SpanID,
+				service:   data.Processes[s.ProcessID].ServiceName,
+				operation: s.OperationName,
+				start:     time.Unix(0, s.StartTime*int64(time.Microsecond)),
+				duration:  time.Duration(s.Duration) * time.Microsecond,
+			}
+			for _, ref := range s.References {
+				if ref.RefType == "CHILD_OF" {
+					span.parentID = ref.SpanID
+				}
+			}
+			spans = append(spans, span)
+		}
+	}
+	return spans, nil
+}
+
+func parseTempoTrace(body []byte) ([]traceSpan, error) {
+	type otlpSpans struct {
+		Spans []struct {
+			SpanID            string `json:"spanId"`
+			ParentSpanID      string `json:"parentSpanId"`
+			Name              string `json:"name"`
+			StartTimeUnixNano string `json:"startTimeUnixNano"`
+			EndTimeUnixNano   string `json:"endTimeUnixNano"`
+		} `json:"spans"`
+	}
+	var trace struct {
+		Batches []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			// renamed to scopeSpans in later OTLP versions
+			InstrumentationLibrarySpans []otlpSpans `json:"instrumentationLibrarySpans"`
+			ScopeSpans                  []otlpSpans `json:"scopeSpans"`
+		} `json:"batches"`
+	}
+	err := json.Unmarshal(body, &trace)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding Tempo trace")
+	}
+
+	var spans []traceSpan
+	for _, batch := range trace.Batches {
+		service := ""
+		for _, attr := range batch.Resource.Attributes {
+			if attr.Key == "service.name" {
+				service = attr.Value.StringValue
+			}
+		}
+		for _, ss := range append(batch.InstrumentationLibrarySpans, batch.ScopeSpans...) {
+			for _, s := range ss.Spans {
+				start, err := strconv.ParseInt(s.StartTimeUnixNano, 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "error parsing start time of span %v", s.SpanID)
+				}
+				end, err := strconv.ParseInt(s.EndTimeUnixNano, 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "error parsing end time of span %v", s.SpanID)
+				}
+				spans = append(spans, traceSpan{
+					spanID:    tempoID(s.SpanID),
+					parentID:  tempoID(s.ParentSpanID),
+					service:   service,
+					operation: s.Name,
+					start:     time.Unix(0, start),
+					duration:  time.Duration(end - start),
+				})
+			}
+		}
+	}
+	return spans, nil
+}
+
+// tempoID converts the base64 encoded IDs of Tempo to the hex form
+// used by Jaeger and the propagation headers.
+func tempoID(id string) string {
+	bs, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return id
+	}
+	return hex.EncodeToString(bs)
+}
+
+// printTraceTree prints the spans indented below their parents, with
+// the offset from the start of the trace and the duration of each span.
+func printTraceTree(w io.Writer, spans []traceSpan) {
+	if len(spans) == 0 {
+		return
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	ids := make(map[string]bool, len(spans))
+	for _, s := range spans {
+		ids[s.spanID] = true
+	}
+	children := make(map[string][]traceSpan)
+	var roots []traceSpan
+	for _, s := range spans {
+		// the parent of the root is the span id made up by the CLI
+		if len(s.parentID) == 0 || !ids[s.parentID] {
+			roots = append(roots, s)
+			continue
+		}
+		children[s.parentID] = append(children[s.parentID], s)
+	}
+
+	traceStart := spans[0].start
+	var printSpan func(s traceSpan, depth int)
+	printSpan = func(s traceSpan, depth int) {
+		fmt.Fprintf(w, "%v%v %v  +%v %v\n", strings.Repeat("  ", depth), s.service, s.operation,
+			s.start.Sub(traceStart).Round(time.Microsecond), s.duration.Round(time.Microsecond))
+		for _, c := range children[s.spanID] {
+			printSpan(c, depth+1)
+		}
+	}
+	for _, r := range roots {
+		printSpan(r, 0)
+	}
+}
+
+func randomHex(n int) (string, error) {
+	bs := make([]byte, n)
+	_, err := rand.Read(bs)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating trace id")
+	}
+	return hex.EncodeToString(bs), nil
+}
@@ -35,6 +35,8 @@ import (
 type UpdateSubCommand struct {
 	cmd.CommandActioner
 	function *fv1.Function
+	// revisions dropped from the revision history
+	droppedRevisions []FunctionRevision
 }
 
 func Update(input cli.Input) error {
@@ -61,6 +63,15 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		return errors.Wrap(err, fmt.Sprintf("read function '%v'", fnName))
 	}
 
+	// apply the spec file first, flags given on the command line take precedence
+	if input.IsSet(flagkey.FnSpecFile) {
+		fileSpec, err := readFunctionSpecFile(input.String(flagkey.FnSpecFile))
+		if err != nil {
+			return err
+		}
+		mergeFunctionSpec(&function.Spec, fileSpec)
+	}
+
 	envName := input.String(flagkey.FnEnvironmentName)
 	envNamespace := input.String(flagkey.NamespaceEnvironment)
 	// if the new env specified is the same as the old one, no need to update package
@@ -143,11 +154,10 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	}
 
 	if input.IsSet(flagkey.FnExecutionTimeout) {
-		fnTimeout := input.Int(flagkey.FnExecutionTimeout)
-		if fnTimeout <= 0 {
-			return errors.Errorf("--%v must be greater than 0", flagkey.FnExecutionTimeout)
+		err := applyFunctionTimeout(input, &function.Spec)
+		if err != nil {
+			return err
 		}
-		function.Spec.FunctionTimeout = fnTimeout
 	}
 
 	if input.IsSet(flagkey.FnIdleTimeout) {
@@ -166,6 +176,16 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	if input.IsSet(flagkey.FnOnceOnly) {
 		function.Spec.OnceOnly = input.Bool(flagkey.FnOnceOnly)
 	}
+
+	if input.IsSet(flagkey.FnConcurrencyModel) {
+		function.Spec.ConcurrencyModel = fv1.ConcurrencyModel(input.String(flagkey.FnConcurrencyModel))
+		if len(function.Spec.ConcurrencyModel) > 0 {
+			err = function.Spec.ConcurrencyModel.Validate()
+			if err != nil {
+				return err
+			}
+		}
+	}
 	if len(pkgName) == 0 {
 		pkgName = function.Spec.Package.PackageRef.Name
 	}
@@ -202,6 +222,8 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		return errors.Errorf("Package is used by multiple functions, use --%v to force update", flagkey.PkgForce)
 	}
 
+	oldPkg := pkg.DeepCopy()
+
 	newPkgMeta, err := _package.UpdatePackage(input, opts.Client(), pkg)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("error updating package '%v'", pkgName))
@@ -211,6 +233,12 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	// we need to update function resource version to prevent conflict.
 	// TODO: remove this block when deprecating pkg flags of function command.
 	if pkg.ObjectMeta.ResourceVersion != newPkgMeta.ResourceVersion {
+		// keep the previous package in the revision history for rollback
+		opts.droppedRevisions, err = recordRevision(opts.Client(), function, oldPkg, input.Int(flagkey.FnRevisionHistoryLimit))
+		if err != nil {
+			return err
+		}
+
 		var fns []fv1.Function
 		// don't update the package resource version of the function we are currently
 		// updating to prevent update conflict.
@@ -243,11 +271,22 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 
 	opts.function = function
 
+	if len(function.Spec.ConcurrencyModel) > 0 {
+		err = checkConcurrencyModel(opts.Client(), function)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = util.ApplyLabelsAndAnnotations(input, &opts.function.ObjectMeta)
 	if err != nil {
 		return err
 	}
 
+	if input.IsSet(flagkey.FnSpecFile) {
+		return validateFunction(opts.function)
+	}
+
 	return nil
 }
 
@@ -256,6 +295,7 @@ func (opts *UpdateSubCommand) run(input cli.Input) error {
 	if err != nil {
 		return errors.Wrap(err, "error updating function")
 	}
+	deleteRevisions(opts.Client(), opts.droppedRevisions)
 
 	fmt.Printf("Function '%v' updated\n", opts.function.ObjectMeta.Name)
 	return nil
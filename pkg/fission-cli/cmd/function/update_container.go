@@ -124,9 +124,9 @@ func (opts *UpdateContainerSubCommand) complete(input cli.Input) error {
 	}
 
 	if input.IsSet(flagkey.FnExecutionTimeout) {
-		fnTimeout := input.Int(flagkey.FnExecutionTimeout)
-		if fnTimeout <= 0 {
-			return errors.Errorf("--%v must be greater than 0", flagkey.FnExecutionTimeout)
+		fnTimeout, err := getFunctionTimeout(input, function.Spec.FunctionTimeout)
+		if err != nil {
+			return err
 		}
 		function.Spec.FunctionTimeout = fnTimeout
 	}
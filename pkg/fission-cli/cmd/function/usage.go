@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	prometheus "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type UsageSubCommand struct {
+	cmd.CommandActioner
+	meta    *metav1.ObjectMeta
+	since   model.Duration
+	compare model.Duration
+	api     prometheusv1.API
+}
+
+// functionUsage is the usage of a function in a period, as recorded by
+// the router.
+type functionUsage struct {
+	calls  float64
+	errors float64
+	bytes  float64
+}
+
+// Usage reports the number of invocations, errors and the response
+// bytes of a function over a period, read from Prometheus.
+func Usage(input cli.Input) error {
+	return (&UsageSubCommand{}).do(input)
+}
+
+func (opts *UsageSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *UsageSubCommand) complete(input cli.Input) error {
+	opts.meta = &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	var err error
+	opts.since, err = model.ParseDuration(input.String(flagkey.FnUsageSince))
+	if err != nil || opts.since <= 0 {
+		return errors.Errorf("invalid --%v '%v', must be a duration like 12h or 7d", flagkey.FnUsageSince, input.String(flagkey.FnUsageSince))
+	}
+	if input.IsSet(flagkey.FnUsageCompare) {
+		opts.compare, err = model.ParseDuration(input.String(flagkey.FnUsageCompare))
+		if err != nil || opts.compare <= 0 {
+			return errors.Errorf("invalid --%v '%v', must be a duration like 12h or 7d", flagkey.FnUsageCompare, input.String(flagkey.FnUsageCompare))
+		}
+	}
+
+	fn, err := opts.Client().V1().Function().Get(opts.meta)
+	if err != nil {
+		return errors.Wrapf(err, "error getting function '%v'", opts.meta.Name)
+	}
+	opts.meta.Namespace = fn.ObjectMeta.Namespace
+
+	promURL := prometheusURL(input, flagkey.FnUsagePrometheus)
+	if len(promURL) == 0 {
+		return errPrometheusNotConfigured
+	}
+	client, err := prometheus.NewClient(prometheus.Config{Address: promURL})
+	if err != nil {
+		return errors.Wrapf(err, "error creating Prometheus client for %v", promURL)
+	}
+	opts.api = prometheusv1.NewAPI(client)
+
+	return nil
+}
+
+func (opts *UsageSubCommand) run(input cli.Input) error {
+	current, err := opts.query(0)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	if opts.compare == 0 {
+		fmt.Fprintf(w, "%v\t%v\n", "METRIC", "LAST "+opts.since.String())
+		fmt.Fprintf(w, "%v\t%.0f\n", "Invocations", current.calls)
+		fmt.Fprintf(w, "%v\t%.0f\n", "Errors", current.errors)
+		fmt.Fprintf(w, "%v\t%v\n", "Bandwidth", formatBytes(current.bytes))
+		w.Flush()
+		return nil
+	}
+
+	previous, err := opts.query(opts.compare)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "METRIC", "LAST "+opts.since.String(), opts.compare.String()+" BEFORE", "CHANGE")
+	fmt.Fprintf(w, "%v\t%.0f\t%.0f\t%v\n", "Invocations", current.calls, previous.calls, percentChange(previous.calls, current.calls))
+	fmt.Fprintf(w, "%v\t%.0f\t%.0f\t%v\n", "Errors", current.errors, previous.errors, percentChange(previous.errors, current.errors))
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "Bandwidth", formatBytes(current.bytes), formatBytes(previous.bytes), percentChange(previous.bytes, current.bytes))
+	w.Flush()
+
+	return nil
+}
+
+// query returns the usage of the function in the period of length
+// --since ending offset ago.
+func (opts *UsageSubCommand) query(offset model.Duration) (*functionUsage, error) {
+	usage := &functionUsage{}
+	for metric, value := range map[string]*float64{
+		"fission_function_calls_total":             &usage.calls,
+		"fission_function_errors_total":            &usage.errors,
+		"fission_function_response_size_bytes_sum": &usage.bytes,
+	} {
+		query := fmt.Sprintf("sum(increase(%v{namespace=%q,name=%q}[%v]", metric, opts.meta.Namespace, opts.meta.Name, opts.since)
+		if offset > 0 {
+			query += fmt.Sprintf(" offset %v", offset)
+		}
+		query += "))"
+
+		ctx, cancel := context.WithTimeout(context.Background(), prometheusQueryTimeout)
+		val, _, err := opts.api.Query(ctx, query, time.Now())
+		cancel()
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying Prometheus, check that it is reachable")
+		}
+		vector, ok := val.(model.Vector)
+		if !ok {
+			return nil, errors.Errorf("unexpected result type '%v' of Prometheus query", val.Type())
+		}
+		// no samples means the function wasn't called in the period
+		if len(vector) > 0 {
+			*value = float64(vector[0].Value)
+		}
+	}
+	return usage, nil
+}
+
+// percentChange formats the change from previous to current as a
+// percentage.
+func percentChange(previous, current float64) string {
+	if previous == 0 {
+		if current == 0 {
+			return "0%"
+		}
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (current-previous)/previous*100)
+}
+
+func formatBytes(bytes float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for bytes >= 1024 && i < len(units)-1 {
+		bytes /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f%v", bytes, units[i])
+	}
+	return fmt.Sprintf("%.1f%v", bytes, units[i])
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/utils"
+)
+
+type VerifySignatureSubCommand struct {
+	cmd.CommandActioner
+}
+
+// VerifySignature downloads the archives of the package of a function
+// and compares their SHA256 checksum with the one stored in the
+// package, to detect archives changed in the storage service.
+func VerifySignature(input cli.Input) error {
+	return (&VerifySignatureSubCommand{}).do(input)
+}
+
+func (opts *VerifySignatureSubCommand) do(input cli.Input) error {
+	fnName := input.String(flagkey.FnName)
+	fn, err := opts.Client().V1().Function().Get(&metav1.ObjectMeta{
+		Name:      fnName,
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+		return errors.Errorf("function '%v' runs a container image and has no archive", fnName)
+	}
+
+	pkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Package.PackageRef.Name,
+		Namespace: fn.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function package")
+	}
+
+	failed := 0
+	for _, a := range []struct {
+		name    string
+		archive *fv1.Archive
+	}{
+		{"deployment", &pkg.Spec.Deployment},
+		{"source", &pkg.Spec.Source},
+	} {
+		if a.archive.IsEmpty() {
+			continue
+		}
+		ok, err := opts.verifyArchive(a.name, a.archive)
+		if err != nil {
+			return errors.Wrapf(err, "error verifying %v archive of package '%v'", a.name, pkg.ObjectMeta.Name)
+		}
+		if !ok {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%v archive(s) of package '%v' failed verification", failed, pkg.ObjectMeta.Name)
+	}
+	return nil
+}
+
+// verifyArchive prints the result of checking one archive and reports
+// whether it matches its stored checksum. Archives at external URLs are
+// downloaded from there, the others from the storage service.
+func (opts *VerifySignatureSubCommand) verifyArchive(name string, archive *fv1.Archive) (bool, error) {
+	var content io.Reader
+	switch archive.Type {
+	case fv1.ArchiveTypeLiteral:
+		// literal archives are part of the package itself and usually
+		// don't carry a checksum
+		if len(archive.Checksum.Sum) == 0 {
+			fmt.Printf("%v archive: SKIP (literal archive without stored checksum)\n", name)
+			return true, nil
+		}
+		content = bytes.NewReader(archive.Literal)
+	case fv1.ArchiveTypeUrl:
+		if len(archive.Checksum.Sum) == 0 {
+			fmt.Printf("%v archive: NO CHECKSUM (nothing stored in the package to compare with)\n", name)
+			return false, nil
+		}
+		stored, err := pkgutil.IsStoragesvcURL(opts.Client(), archive.URL)
+		if err != nil {
+			return false, err
+		}
+		var reader io.ReadCloser
+		if stored {
+			reader, err = pkgutil.DownloadStoragesvcURL(opts.Client(), archive.URL)
+		} else {
+			reader, err = pkgutil.DownloadURL(archive.URL)
+		}
+		if err != nil {
+			return false, err
+		}
+		defer reader.Close()
+		content = reader
+	default:
+		return false, errors.Errorf("unknown archive type '%v'", archive.Type)
+	}
+
+	actual, err := computeChecksum(archive.Checksum, content)
+	if err != nil {
+		return false, err
+	}
+	if actual != archive.Checksum.Sum {
+		fmt.Printf("%v archive: MISMATCH\n", name)
+		fmt.Printf("  expected %v: %v\n", archive.Checksum.Type, archive.Checksum.Sum)
+		fmt.Printf("  actual %v:   %v\n", archive.Checksum.Type, actual)
+		return false, nil
+	}
+	fmt.Printf("%v archive: OK (%v %v)\n", name, archive.Checksum.Type, actual)
+	return true, nil
+}
+
+// computeChecksum returns the checksum of the content computed with the
+// algorithm of the stored checksum.
+func computeChecksum(stored fv1.Checksum, content io.Reader) (string, error) {
+	if stored.Type != fv1.ChecksumTypeSHA256 {
+		return "", errors.Errorf("unsupported checksum type '%v'", stored.Type)
+	}
+	csum, err := utils.GetChecksum(content)
+	if err != nil {
+		return "", errors.Wrap(err, "error computing checksum")
+	}
+	return csum.Sum, nil
+}
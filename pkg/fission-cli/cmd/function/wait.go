@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/controller/client"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const readyPollInterval = 2 * time.Second
+
+// waitForFunctionReady waits until a pod that can serve the function is
+// running. Pool manager functions are served by the pool pods of their
+// environment, other functions by pods of their own. When the timeout
+// expires, the events of the pods are printed to show why they don't run.
+func waitForFunctionReady(client client.Interface, kubeContext string, fnMeta *metav1.ObjectMeta, timeout time.Duration) error {
+	fn, err := client.V1().Function().Get(fnMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+
+	var selector labels.Set
+	strategy := fn.Spec.InvokeStrategy.ExecutionStrategy
+	if strategy.ExecutorType == fv1.ExecutorTypePoolmgr {
+		selector = labels.Set{
+			fv1.ENVIRONMENT_NAME:      fn.Spec.Environment.Name,
+			fv1.ENVIRONMENT_NAMESPACE: fn.Spec.Environment.Namespace,
+			fv1.EXECUTOR_TYPE:         string(fv1.ExecutorTypePoolmgr),
+		}
+	} else {
+		if strategy.MinScale == 0 {
+			console.Warn(fmt.Sprintf("Function '%v' has a min scale of 0, its pods are only created by the first request", fn.ObjectMeta.Name))
+			return nil
+		}
+		selector = labels.Set{fv1.FUNCTION_UID: string(fn.ObjectMeta.UID)}
+	}
+
+	_, kubeClient, err := util.GetKubernetesClient(kubeContext)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Waiting up to %v for function '%v' to be ready\n", timeout, fn.ObjectMeta.Name)
+
+	ctx := context.Background()
+	listOptions := metav1.ListOptions{LabelSelector: selector.AsSelector().String()}
+	deadline := time.Now().Add(timeout)
+	for {
+		pods, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOptions)
+		if err != nil {
+			return errors.Wrap(err, "error listing function pods")
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == apiv1.PodRunning && pod.ObjectMeta.DeletionTimestamp == nil {
+				fmt.Printf("function '%v' is ready\n", fn.ObjectMeta.Name)
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if len(pods.Items) == 0 {
+				return errors.Errorf("no pods of function '%v' were created within %v", fn.ObjectMeta.Name, timeout)
+			}
+			err = printPodEvents(ctx, kubeClient, pods.Items)
+			if err != nil {
+				console.Verbose(2, "Unable to get pod events: %v", err)
+			}
+			return errors.Errorf("function '%v' was not ready within %v", fn.ObjectMeta.Name, timeout)
+		}
+		time.Sleep(readyPollInterval)
+	}
+}
+
+func printPodEvents(ctx context.Context, kubeClient kubernetes.Interface, pods []apiv1.Pod) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "POD", "TYPE", "REASON", "AGE", "MESSAGE")
+	for _, pod := range pods {
+		events, err := kubeClient.CoreV1().Events(pod.ObjectMeta.Namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.Set{
+				"involvedObject.kind": "Pod",
+				"involvedObject.name": pod.ObjectMeta.Name,
+			}.AsSelector().String(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, e := range events.Items {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", pod.ObjectMeta.Name, e.Type, e.Reason,
+				time.Since(e.LastTimestamp.Time).Round(time.Second), e.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	_package "github.com/fission/fission/pkg/fission-cli/cmd/package"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/utils"
+)
+
+type WatchSubCommand struct {
+	cmd.CommandActioner
+	fnMeta  *metav1.ObjectMeta
+	dir     string
+	filters []string
+}
+
+// Watch watches a local directory and re-deploys the function with
+// the directory contents whenever a file changes.
+func Watch(input cli.Input) error {
+	return (&WatchSubCommand{}).do(input)
+}
+
+func (opts *WatchSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *WatchSubCommand) complete(input cli.Input) error {
+	opts.fnMeta = &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	opts.dir = input.String(flagkey.FnWatchDir)
+	info, err := os.Stat(opts.dir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading '%v'", opts.dir)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("'%v' is not a directory", opts.dir)
+	}
+
+	opts.filters = input.StringSlice(flagkey.FnWatchFilter)
+	for _, filter := range opts.filters {
+		if _, err := filepath.Match(filter, ""); err != nil {
+			return errors.Wrapf(err, "invalid filter '%v'", filter)
+		}
+	}
+
+	fn, err := opts.Client().V1().Function().Get(opts.fnMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+		return errors.Errorf("function '%v' runs a container image, rebuild and push the image instead", fn.ObjectMeta.Name)
+	}
+
+	return nil
+}
+
+func (opts *WatchSubCommand) run(input cli.Input) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "error creating file watcher")
+	}
+	defer watcher.Close()
+
+	// fsnotify doesn't watch recursively, add every directory
+	err = filepath.Walk(opts.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != opts.dir && opts.ignored(path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error scanning files to watch")
+	}
+
+	routerURL, err := portForwardRouter(input)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Function URL: %v%v\n", routerURL, util.UrlForFunction(opts.fnMeta.Name, opts.fnMeta.Namespace))
+	fmt.Printf("Watching %v for changes...\n", opts.dir)
+
+	for {
+		select {
+		case e := <-watcher.Events:
+			if opts.ignored(e.Name) {
+				continue
+			}
+			// directories created after start are watched too
+			if e.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(e.Name); err == nil && info.IsDir() {
+					_ = watcher.Add(e.Name)
+				}
+			}
+
+			err = waitForWatcherToSettle(watcher)
+			if err != nil {
+				return errors.Wrap(err, "error watching files")
+			}
+
+			fmt.Printf("Noticed a change of %v, updating function '%v'...\n", e.Name, opts.fnMeta.Name)
+			start := time.Now()
+			err = opts.redeploy()
+			if err != nil {
+				console.Error(err.Error())
+				continue
+			}
+			fmt.Printf("Function '%v' updated in %v\n", opts.fnMeta.Name, time.Since(start).Round(time.Millisecond))
+
+		case err := <-watcher.Errors:
+			return errors.Wrap(err, "error watching files")
+		}
+	}
+}
+
+// redeploy zips the directory, uploads the archive and points the
+// package of the function at it. Packages built from a source archive
+// get the new archive as source and are rebuilt.
+func (opts *WatchSubCommand) redeploy() error {
+	tmpDir, err := utils.GetTempDir()
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary archive directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := filepath.Join(tmpDir, opts.fnMeta.Name+".zip")
+	err = zipDirectory(opts.dir, archivePath, opts.ignored)
+	if err != nil {
+		return errors.Wrap(err, "error creating archive")
+	}
+
+	archive, err := pkgutil.UploadArchiveFile(context.Background(), opts.Client(), archivePath)
+	if err != nil {
+		return errors.Wrap(err, "error uploading archive")
+	}
+
+	fn, err := opts.Client().V1().Function().Get(opts.fnMeta)
+	if err != nil {
+		return errors.Wrap(err, "error getting function")
+	}
+	pkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
+		Name:      fn.Spec.Package.PackageRef.Name,
+		Namespace: fn.Spec.Package.PackageRef.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function package")
+	}
+
+	if len(pkg.Spec.Source.Type) > 0 {
+		pkg.Spec.Source = *archive
+		pkg.Status = fv1.PackageStatus{
+			BuildStatus:         fv1.BuildStatusPending,
+			LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+		}
+	} else {
+		pkg.Spec.Deployment = *archive
+	}
+
+	pkgMeta, err := opts.Client().V1().Package().Update(pkg)
+	if err != nil {
+		return errors.Wrap(err, "error updating package")
+	}
+
+	fns, err := _package.GetFunctionsByPackage(opts.Client(), pkgMeta.Name, pkgMeta.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "error getting functions of the package")
+	}
+	return _package.UpdateFunctionPackageResourceVersion(opts.Client(), pkgMeta, fns...)
+}
+
+// ignored returns whether a path matches one of the filters, either by
+// its base name or by its path relative to the watched directory.
+// Editor autosave and backup files are always ignored.
+func (opts *WatchSubCommand) ignored(path string) bool {
+	if strings.Contains(path, "/.#") || strings.HasSuffix(path, "~") {
+		return true
+	}
+	rel, err := filepath.Rel(opts.dir, path)
+	if err != nil {
+		rel = path
+	}
+	for _, filter := range opts.filters {
+		if ok, _ := filepath.Match(filter, filepath.Base(path)); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(filter, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// zipDirectory writes the files of dir to a zip archive, with paths
+// relative to dir. Files and directories for which ignored returns
+// true are left out.
+func zipDirectory(dir, target string, ignored func(path string) bool) error {
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if ignored(path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		header.Method = zip.Deflate
+
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// waitForWatcherToSettle drains the events of editors and tools that
+// write several files at once, so that they trigger a single update.
+func waitForWatcherToSettle(watcher *fsnotify.Watcher) error {
+	time.Sleep(500 * time.Millisecond)
+	for {
+		select {
+		case <-watcher.Events:
+			time.Sleep(200 * time.Millisecond)
+		case err := <-watcher.Errors:
+			return err
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/fission-cli/console"
+)
+
+// testWebSocket connects to the function over WebSocket, sends the body
+// as the first message and writes every message received to writer,
+// prefixed with the time it arrived, until the function closes the
+// connection or the context is done.
+func testWebSocket(ctx context.Context, writer io.Writer, fnURL *url.URL, headers []string, body string) error {
+	wsURL := *fnURL
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+
+	reqHeader := http.Header{}
+	for _, header := range headers {
+		key, value, err := parseHeader(header)
+		if err != nil {
+			return err
+		}
+		reqHeader.Add(key, value)
+	}
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL.String(), reqHeader)
+	if err != nil {
+		if resp != nil {
+			return errors.Wrapf(err, "error connecting to function, HTTP status code %v", resp.StatusCode)
+		}
+		return errors.Wrap(err, "error connecting to function")
+	}
+	defer conn.Close()
+	console.Verbose(2, "WebSocket connected to %v", wsURL.String())
+
+	// unblock ReadMessage once the timeout expires
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if len(body) > 0 {
+		err = conn.WriteMessage(websocket.TextMessage, []byte(body))
+		if err != nil {
+			return errors.Wrap(err, "error sending message to function")
+		}
+	}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			if ctx.Err() == context.DeadlineExceeded {
+				console.Verbose(2, "Timeout reached, closing WebSocket connection")
+				return nil
+			}
+			return errors.Wrap(err, "error reading message from function")
+		}
+		_, err = fmt.Fprintf(writer, "%v %s\n", time.Now().Format(time.RFC3339Nano), message)
+		if err != nil {
+			return errors.Wrap(err, "error writing function response")
+		}
+	}
+}
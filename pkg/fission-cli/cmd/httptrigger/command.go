@@ -32,7 +32,7 @@ func Commands() *cobra.Command {
 	wrapper.SetFlags(createCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.HtFnName},
 		Optional: []flag.Flag{flag.HtUrl, flag.HtName, flag.HtMethod, flag.HtIngress,
-			flag.HtIngressRule, flag.HtIngressAnnotation, flag.HtIngressTLS,
+			flag.HtIngressRule, flag.HtIngressAnnotation, flag.HtIngressTLS, flag.HtIngressClass,
 			flag.HtFnWeight, flag.HtHost, flag.NamespaceFunction, flag.SpecSave, flag.SpecDry,
 			flag.HtPrefix, flag.HtKeepPrefix},
 	})
@@ -57,8 +57,8 @@ func Commands() *cobra.Command {
 		Required: []flag.Flag{flag.HtName},
 		Optional: []flag.Flag{flag.HtUrl, flag.HtFnName,
 			flag.HtMethod, flag.HtIngress, flag.HtIngressRule, flag.HtIngressAnnotation,
-			flag.HtIngressTLS, flag.HtFnWeight, flag.HtHost, flag.NamespaceTrigger,
-			flag.HtPrefix, flag.HtKeepPrefix},
+			flag.HtIngressTLS, flag.HtIngressClass, flag.HtFnWeight, flag.HtHost, flag.NamespaceTrigger,
+			flag.HtPrefix, flag.HtKeepPrefix, flag.HtWeightFn},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -79,7 +79,7 @@ func Commands() *cobra.Command {
 		RunE:    wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceTrigger, flag.HtFnFilter},
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.HtFnFilter, flag.HtListOutput},
 	})
 
 	command := &cobra.Command{
@@ -22,6 +22,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,7 +39,7 @@ import (
 
 type CreateSubCommand struct {
 	cmd.CommandActioner
-	trigger *fv1.HTTPTrigger
+	triggers []*fv1.HTTPTrigger
 }
 
 func Create(input cli.Input) error {
@@ -78,19 +79,6 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	}
 	fnNamespace := input.String(flagkey.NamespaceFunction)
 
-	m := &metav1.ObjectMeta{
-		Name:      triggerName,
-		Namespace: fnNamespace,
-	}
-
-	htTrigger, err := opts.Client().V1().HTTPTrigger().Get(m)
-	if err != nil && !ferror.IsNotFound(err) {
-		return err
-	}
-	if htTrigger != nil {
-		return errors.New("duplicate trigger exists, choose a different name or leave it empty for fission to auto-generate it")
-	}
-
 	triggerUrl := input.String(flagkey.HtUrl)
 	prefix := input.String(flagkey.HtPrefix)
 	fallbackURL := ""
@@ -119,16 +107,31 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		fallbackURL = triggerUrl
 	}
 
-	methods := input.StringSlice(flagkey.HtMethod)
-	if len(methods) == 0 {
-		return errors.New("HTTP methods not mentioned")
+	methods, err := parseMethods(input.StringSlice(flagkey.HtMethod))
+	if err != nil {
+		return err
 	}
 
+	// One trigger is created per method, named <base>-<method>
+	// when more than one method is given.
+	triggerNames := map[string]string{}
 	for _, method := range methods {
-		_, err := GetMethod(method)
-		if err != nil {
+		name := triggerName
+		if len(methods) > 1 {
+			name = triggerNameForMethod(triggerName, method)
+		}
+		triggerNames[method] = name
+
+		htTrigger, err := opts.Client().V1().HTTPTrigger().Get(&metav1.ObjectMeta{
+			Name:      name,
+			Namespace: fnNamespace,
+		})
+		if err != nil && !ferror.IsNotFound(err) {
 			return err
 		}
+		if htTrigger != nil {
+			return errors.Errorf("duplicate trigger '%v' exists, choose a different name or leave it empty for fission to auto-generate it", name)
+		}
 	}
 
 	// For Specs, the spec validate checks for function reference
@@ -168,57 +171,171 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	if err != nil {
 		return errors.Wrap(err, "error parsing ingress configuration")
 	}
+	ingressConfig.IngressClass = input.String(flagkey.HtIngressClass)
 
 	host := input.String(flagkey.HtHost)
+	if len(host) > 0 && !input.IsSet(flagkey.HtIngressRule) {
+		// without an explicit rule the Ingress matches the same host
+		// as the trigger instead of all hosts
+		ingressConfig.Host = host
+	}
 
-	opts.trigger = &fv1.HTTPTrigger{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      triggerName,
-			Namespace: fnNamespace,
-		},
-		Spec: fv1.HTTPTriggerSpec{
-			Host:              host,
-			RelativeURL:       triggerUrl,
-			Methods:           methods,
-			FunctionReference: *functionRef,
-			CreateIngress:     createIngress,
-			IngressConfig:     *ingressConfig,
-			Prefix:            &prefix,
-			KeepPrefix:        input.Bool(flagkey.HtKeepPrefix),
-		},
+	// the triggers share the same path, so the ingress is only
+	// created for the first one and routes all methods
+	for i, method := range methods {
+		trigger := &fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      triggerNames[method],
+				Namespace: fnNamespace,
+			},
+			Spec: fv1.HTTPTriggerSpec{
+				Host:              host,
+				RelativeURL:       triggerUrl,
+				Methods:           []string{method},
+				FunctionReference: *functionRef,
+				CreateIngress:     createIngress && i == 0,
+				IngressConfig:     *ingressConfig,
+				Prefix:            &prefix,
+				KeepPrefix:        input.Bool(flagkey.HtKeepPrefix),
+			},
+		}
+		opts.triggers = append(opts.triggers, trigger)
 	}
 
-	if input.IsSet(flagkey.HtKeepPrefix) {
-		opts.trigger.Spec.KeepPrefix = input.Bool(flagkey.HtKeepPrefix)
+	// the router would reject the duplicate route with a generic
+	// conflict, so point at the existing trigger instead
+	if !input.Bool(flagkey.SpecSave) && !input.Bool(flagkey.SpecDry) {
+		existing, err := opts.Client().V1().HTTPTrigger().List(metav1.NamespaceAll)
+		if err != nil {
+			return errors.Wrap(err, "error listing HTTP triggers")
+		}
+		for _, trigger := range opts.triggers {
+			if conflict := findConflictingTrigger(existing, trigger); conflict != nil {
+				return errors.Errorf("HTTP trigger '%v' in namespace '%v' already routes %v %v, use 'fission httptrigger update --name %v' to change it",
+					conflict.ObjectMeta.Name, conflict.ObjectMeta.Namespace, trigger.Spec.Methods[0], triggerPath(trigger), conflict.ObjectMeta.Name)
+			}
+		}
 	}
 
 	return nil
 }
 
-func (opts *CreateSubCommand) run(input cli.Input) error {
-	// if we're writing a spec, don't call the API
-	// save to spec file or display the spec to console
-	if input.Bool(flagkey.SpecDry) {
-		return spec.SpecDry(*opts.trigger)
+// findConflictingTrigger returns the trigger that already serves the
+// same host, path and method as the given trigger, if any.
+func findConflictingTrigger(existing []fv1.HTTPTrigger, trigger *fv1.HTTPTrigger) *fv1.HTTPTrigger {
+	for i := range existing {
+		t := &existing[i]
+		if t.Spec.Host != trigger.Spec.Host || triggerPath(t) != triggerPath(trigger) {
+			continue
+		}
+		for _, m := range triggerMethods(t) {
+			for _, method := range triggerMethods(trigger) {
+				if m == method {
+					return t
+				}
+			}
+		}
 	}
+	return nil
+}
 
-	if input.Bool(flagkey.SpecSave) {
-		specFile := fmt.Sprintf("route-%v.yaml", opts.trigger.ObjectMeta.Name)
-		err := spec.SpecSave(*opts.trigger, specFile)
+// triggerPath returns the path a trigger is served at, the prefix
+// takes precedence over the relative URL.
+func triggerPath(trigger *fv1.HTTPTrigger) string {
+	if trigger.Spec.Prefix != nil && len(*trigger.Spec.Prefix) > 0 {
+		return *trigger.Spec.Prefix
+	}
+	return trigger.Spec.RelativeURL
+}
+
+func triggerMethods(trigger *fv1.HTTPTrigger) []string {
+	if len(trigger.Spec.Methods) > 0 {
+		return trigger.Spec.Methods
+	}
+	return []string{trigger.Spec.Method}
+}
+
+func (opts *CreateSubCommand) run(input cli.Input) error {
+	var created []*fv1.HTTPTrigger
+	for _, trigger := range opts.triggers {
+		// if we're writing a spec, don't call the API
+		// save to spec file or display the spec to console
+		if input.Bool(flagkey.SpecDry) {
+			err := spec.SpecDry(*trigger)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if input.Bool(flagkey.SpecSave) {
+			specFile := fmt.Sprintf("route-%v.yaml", trigger.ObjectMeta.Name)
+			err := spec.SpecSave(*trigger, specFile)
+			if err != nil {
+				return errors.Wrap(err, "error saving HTTP trigger spec")
+			}
+			continue
+		}
+
+		_, err := opts.Client().V1().HTTPTrigger().Create(trigger)
 		if err != nil {
-			return errors.Wrap(err, "error saving HTTP trigger spec")
+			err = errors.Wrapf(err, "create HTTP trigger '%v'", trigger.ObjectMeta.Name)
+			return opts.deleteCreated(created, err)
 		}
-		return nil
+		created = append(created, trigger)
+
+		fmt.Printf("trigger '%v' created for method %v\n", trigger.ObjectMeta.Name, trigger.Spec.Methods[0])
 	}
 
-	_, err := opts.Client().V1().HTTPTrigger().Create(opts.trigger)
-	if err != nil {
-		return errors.Wrap(err, "create HTTP trigger")
+	return nil
+}
+
+// deleteCreated removes the triggers created before err happened, so that
+// a failed command doesn't leave only some of the methods routed.
+func (opts *CreateSubCommand) deleteCreated(created []*fv1.HTTPTrigger, err error) error {
+	result := multierror.Append(nil, err)
+	for _, trigger := range created {
+		e := opts.Client().V1().HTTPTrigger().Delete(&trigger.ObjectMeta)
+		if e != nil {
+			result = multierror.Append(result, errors.Wrapf(e, "error deleting HTTP trigger '%v'", trigger.ObjectMeta.Name))
+			continue
+		}
+		fmt.Printf("trigger '%v' deleted\n", trigger.ObjectMeta.Name)
 	}
+	return result.ErrorOrNil()
+}
 
-	fmt.Printf("trigger '%v' created\n", opts.trigger.ObjectMeta.Name)
+// parseMethods accepts repeated and comma separated methods, e.g.
+// --method GET,POST --method PUT, and returns the deduplicated list.
+func parseMethods(values []string) ([]string, error) {
+	var methods []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, m := range strings.Split(value, ",") {
+			m = strings.TrimSpace(m)
+			if len(m) == 0 {
+				continue
+			}
+			method, err := GetMethod(m)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[method] {
+				seen[method] = true
+				methods = append(methods, method)
+			}
+		}
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("HTTP methods not mentioned")
+	}
+	return methods, nil
+}
 
-	return nil
+// triggerNameForMethod returns the name of the trigger created for the
+// given method when one command creates triggers for multiple methods.
+func triggerNameForMethod(base string, method string) string {
+	return fmt.Sprintf("%v-%v", base, strings.ToLower(method))
 }
 
 // GetMethod returns one of HTTP method
@@ -29,6 +29,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type GetSubCommand struct {
@@ -53,14 +54,20 @@ func (opts *GetSubCommand) run(input cli.Input) error {
 		return errors.Wrap(err, "error getting http trigger")
 	}
 
-	printHtSummary([]fv1.HTTPTrigger{*ht})
+	routerURL, err := util.GetRouterURL(input)
+	if err != nil {
+		return err
+	}
+	printHtSummary([]fv1.HTTPTrigger{*ht}, routerURL)
 
 	return nil
 }
 
-func printHtSummary(triggers []fv1.HTTPTrigger) {
+// printHtSummary prints HTTP triggers as a table. The URL column holds
+// the full URL of the trigger when the router URL is known.
+func printHtSummary(triggers []fv1.HTTPTrigger, routerURL string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "METHOD", "URL", "FUNCTION(s)", "INGRESS", "HOST", "PATH", "TLS", "ANNOTATIONS")
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "METHOD", "RELATIVE URL", "URL", "FUNCTION(s)", "INGRESS", "HOST", "PATH", "TLS", "ANNOTATIONS")
 	for _, trigger := range triggers {
 		function := ""
 		if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionName {
@@ -93,8 +100,13 @@ func printHtSummary(triggers []fv1.HTTPTrigger) {
 		if len(trigger.Spec.Methods) > 0 {
 			methods = trigger.Spec.Methods
 		}
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			trigger.ObjectMeta.Name, methods, trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
+		fullURL := "-"
+		if len(routerURL) > 0 && len(trigger.Spec.RelativeURL) > 0 {
+			fullURL = routerURL + trigger.Spec.RelativeURL
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			trigger.ObjectMeta.Name, methods, trigger.Spec.RelativeURL, fullURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
 	}
 	w.Flush()
 }
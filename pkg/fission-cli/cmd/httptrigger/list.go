@@ -23,6 +23,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type ListSubCommand struct {
@@ -38,6 +39,11 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) error {
+	formatter, err := util.NewOutputFormatter(input.String(flagkey.HtListOutput))
+	if err != nil {
+		return err
+	}
+
 	hts, err := opts.Client().V1().HTTPTrigger().List(input.String(flagkey.NamespaceTrigger))
 	if err != nil {
 		return errors.Wrap(err, "error listing HTTP triggers")
@@ -45,7 +51,7 @@ func (opts *ListSubCommand) run(input cli.Input) error {
 
 	filterFunctionName := input.String(flagkey.HtFnName)
 
-	var triggers []fv1.HTTPTrigger
+	triggers := []fv1.HTTPTrigger{}
 	for _, ht := range hts {
 		// TODO: list canary http triggers as well.
 		if len(filterFunctionName) == 0 ||
@@ -55,6 +61,15 @@ func (opts *ListSubCommand) run(input cli.Input) error {
 		}
 	}
 
-	printHtSummary(triggers)
-	return nil
+	if formatter.IsStructured() {
+		return formatter.Print(triggers)
+	}
+
+	routerURL, err := util.GetRouterURL(input)
+	if err != nil {
+		return err
+	}
+	printHtSummary(triggers, routerURL)
+
+	return util.SaveRouterURL(input)
 }
@@ -18,6 +18,7 @@ package httptrigger
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -136,3 +137,39 @@ func getIngressTLS(secret string) (remove bool, tls string) {
 		return false, secret
 	}
 }
+
+// getFunctionWeightDistribution parses weights given as "function:weight",
+// e.g. "foo:70" and "bar:30". The weights must sum to 100. "-" removes
+// the distribution.
+func getFunctionWeightDistribution(weights []string) (remove bool, distribution []fv1.FunctionWeight, err error) {
+	if len(weights) == 0 {
+		return false, nil, nil
+	}
+	if weights[0] == "-" {
+		return true, nil, nil
+	}
+
+	total := 0
+	seen := make(map[string]bool)
+	for _, w := range weights {
+		v := strings.SplitN(w, ":", 2)
+		if len(v) != 2 || len(v[0]) == 0 {
+			return false, nil, fmt.Errorf("illegal function weight: %v, expected function:weight", w)
+		}
+		weight, err := strconv.Atoi(v[1])
+		if err != nil || weight < 0 {
+			return false, nil, fmt.Errorf("illegal weight for function %v: %v", v[0], v[1])
+		}
+		if seen[v[0]] {
+			return false, nil, fmt.Errorf("function %v is given more than once", v[0])
+		}
+		seen[v[0]] = true
+		total += weight
+		distribution = append(distribution, fv1.FunctionWeight{Name: v[0], Weight: weight})
+	}
+	if total != 100 {
+		return false, nil, fmt.Errorf("the function weights should add up to 100, got %v", total)
+	}
+
+	return false, distribution, nil
+}
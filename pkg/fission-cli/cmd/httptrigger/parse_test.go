@@ -584,3 +584,66 @@ func Test_getIngressTLS(t *testing.T) {
 		})
 	}
 }
+
+func Test_getFunctionWeightDistribution(t *testing.T) {
+	tests := []struct {
+		name             string
+		weights          []string
+		wantRemove       bool
+		wantDistribution []fv1.FunctionWeight
+		wantErr          bool
+	}{
+		{
+			name:    "two-functions",
+			weights: []string{"foo:70", "bar:30"},
+			wantDistribution: []fv1.FunctionWeight{
+				{Name: "foo", Weight: 70},
+				{Name: "bar", Weight: 30},
+			},
+		},
+		{
+			name:       "remove",
+			weights:    []string{"-"},
+			wantRemove: true,
+		},
+		{
+			name:    "not-100",
+			weights: []string{"foo:70", "bar:20"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-weight",
+			weights: []string{"foo", "bar:30"},
+			wantErr: true,
+		},
+		{
+			name:    "negative-weight",
+			weights: []string{"foo:110", "bar:-10"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate-function",
+			weights: []string{"foo:50", "foo:50"},
+			wantErr: true,
+		},
+		{
+			name:    "zero-weights",
+			weights: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRemove, gotDistribution, err := getFunctionWeightDistribution(tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getFunctionWeightDistribution() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotRemove != tt.wantRemove {
+				t.Errorf("getFunctionWeightDistribution() gotRemove = %v, want %v", gotRemove, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(gotDistribution, tt.wantDistribution) {
+				t.Errorf("getFunctionWeightDistribution() gotDistribution = %v, want %v", gotDistribution, tt.wantDistribution)
+			}
+		})
+	}
+}
@@ -117,6 +117,26 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		ht.Spec.FunctionReference = *functionRef
 	}
 
+	if input.IsSet(flagkey.HtWeightFn) {
+		remove, distribution, err := getFunctionWeightDistribution(input.StringSlice(flagkey.HtWeightFn))
+		if err != nil {
+			return errors.Wrap(err, "error parsing function weights")
+		}
+		if remove {
+			ht.Spec.FunctionWeightDistribution = nil
+		} else {
+			var fnList []string
+			for _, fw := range distribution {
+				fnList = append(fnList, fw.Name)
+			}
+			err = util.CheckFunctionExistence(opts.Client(), fnList, triggerNamespace)
+			if err != nil {
+				console.Warn(err.Error())
+			}
+			ht.Spec.FunctionWeightDistribution = distribution
+		}
+	}
+
 	if input.IsSet(flagkey.HtIngress) {
 		ht.Spec.CreateIngress = input.Bool(flagkey.HtIngress)
 	}
@@ -141,6 +161,14 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		ht.Spec.IngressConfig = *ingress
 	}
 
+	if input.IsSet(flagkey.HtIngressClass) {
+		ingressClass := input.String(flagkey.HtIngressClass)
+		if ingressClass == "-" {
+			ingressClass = ""
+		}
+		ht.Spec.IngressConfig.IngressClass = ingressClass
+	}
+
 	opts.trigger = ht
 
 	return nil
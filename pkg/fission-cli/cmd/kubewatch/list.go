@@ -22,6 +22,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
@@ -30,7 +31,8 @@ import (
 
 type ListSubCommand struct {
 	cmd.CommandActioner
-	namespace string
+	namespace        string
+	watchedNamespace string
 }
 
 func List(input cli.Input) error {
@@ -47,6 +49,7 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 
 func (opts *ListSubCommand) complete(input cli.Input) error {
 	opts.namespace = input.String(flagkey.NamespaceTrigger)
+	opts.watchedNamespace = input.String(flagkey.KwWatchedNamespace)
 	return nil
 }
 
@@ -61,8 +64,11 @@ func (opts *ListSubCommand) run(input cli.Input) error {
 	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
 		"NAME", "NAMESPACE", "OBJTYPE", "LABELS", "FUNCTION_NAME")
 	for _, wa := range ws {
+		if len(opts.watchedNamespace) > 0 && wa.Spec.Namespace != opts.watchedNamespace {
+			continue
+		}
 		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			wa.ObjectMeta.Name, wa.Spec.Namespace, wa.Spec.Type, wa.Spec.LabelSelector, wa.Spec.FunctionReference.Name)
+			wa.ObjectMeta.Name, wa.Spec.Namespace, wa.Spec.Type, labels.SelectorFromSet(wa.Spec.LabelSelector).String(), wa.Spec.FunctionReference.Name)
 	}
 	w.Flush()
 
@@ -35,7 +35,7 @@ func Commands() *cobra.Command {
 			flag.MqtErrorTopic, flag.MqtMaxRetries, flag.MqtMsgContentType,
 			flag.NamespaceFunction, flag.SpecSave, flag.SpecDry, flag.MqtPollingInterval,
 			flag.MqtCooldownPeriod, flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtSecret,
-			flag.MqtMetadata, flag.MqtKind},
+			flag.MqtMetadata, flag.MqtKind, flag.MqtBrokers, flag.MqtConsumerGroup, flag.MqtForce},
 	})
 
 	updateCmd := &cobra.Command{
@@ -49,7 +49,7 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.MqtFnName, flag.MqtTopic, flag.MqtRespTopic, flag.MqtErrorTopic,
 			flag.MqtMaxRetries, flag.MqtMsgContentType, flag.NamespaceTrigger, flag.MqtPollingInterval,
 			flag.MqtCooldownPeriod, flag.MqtMinReplicaCount, flag.MqtMaxReplicaCount, flag.MqtMetadata,
-			flag.MqtSecret, flag.MqtKind},
+			flag.MqtSecret, flag.MqtKind, flag.MqtBrokers, flag.MqtConsumerGroup},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -63,6 +63,29 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.NamespaceTrigger},
 	})
 
+	pauseCmd := &cobra.Command{
+		Use:     "pause",
+		Aliases: []string{},
+		Short:   "Stop a message queue trigger from consuming messages",
+		Long:    "Stop a message queue trigger from consuming messages, e.g. during maintenance, without deleting it. Messages are consumed again after 'fission mqtrigger resume'.",
+		RunE:    wrapper.Wrapper(Pause),
+	}
+	wrapper.SetFlags(pauseCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.MqtName},
+		Optional: []flag.Flag{flag.NamespaceTrigger},
+	})
+
+	resumeCmd := &cobra.Command{
+		Use:     "resume",
+		Aliases: []string{},
+		Short:   "Resume a paused message queue trigger",
+		RunE:    wrapper.Wrapper(Resume),
+	}
+	wrapper.SetFlags(resumeCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.MqtName},
+		Optional: []flag.Flag{flag.NamespaceTrigger},
+	})
+
 	listCmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{},
@@ -80,7 +103,7 @@ func Commands() *cobra.Command {
 		Short:   "Create, update and manage message queue triggers",
 	}
 
-	command.AddCommand(createCmd, updateCmd, deleteCmd, listCmd)
+	command.AddCommand(createCmd, updateCmd, deleteCmd, listCmd, pauseCmd, resumeCmd)
 
 	return command
 }
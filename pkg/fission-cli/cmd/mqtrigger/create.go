@@ -18,6 +18,7 @@ package mqtrigger
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
@@ -75,6 +76,20 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.New("topic cannot be empty")
 	}
 
+	brokers := input.StringSlice(flagkey.MqtBrokers)
+	consumerGroup := input.String(flagkey.MqtConsumerGroup)
+	if mqType == fv1.MessageQueueTypeKafka {
+		if len(brokers) == 0 {
+			return errors.Errorf("--%v is required for message queue type %v", flagkey.MqtBrokers, mqType)
+		}
+		if len(consumerGroup) == 0 {
+			return errors.Errorf("--%v is required for message queue type %v", flagkey.MqtConsumerGroup, mqType)
+		}
+	} else if len(brokers) > 0 || len(consumerGroup) > 0 {
+		return errors.Errorf("--%v and --%v are only supported for message queue type %v",
+			flagkey.MqtBrokers, flagkey.MqtConsumerGroup, fv1.MessageQueueTypeKafka)
+	}
+
 	respTopic := input.String(flagkey.MqtRespTopic)
 	if topic == respTopic {
 		// TODO maybe this should just be a warning, perhaps
@@ -99,6 +114,26 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return err
 	}
 
+	var warnings []string
+	for _, t := range []string{topic, respTopic, errorTopic} {
+		if len(t) == 0 {
+			continue
+		}
+		w, err := checkTopicName(mqType, t)
+		if err != nil {
+			return err
+		}
+		warnings = append(warnings, w...)
+	}
+	if len(warnings) > 0 {
+		for _, w := range warnings {
+			console.Warn(w)
+		}
+		if !input.Bool(flagkey.MqtForce) {
+			return errors.Errorf("use --%v to create the trigger anyway", flagkey.MqtForce)
+		}
+	}
+
 	pollingInterval := int32(input.Int(flagkey.MqtPollingInterval))
 	if pollingInterval < 0 {
 		return errors.New("Polling interval must be greater than or equal to 0")
@@ -122,6 +157,9 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 	metadata := make(map[string]string)
 	metadataParams := input.StringSlice(flagkey.MqtMetadata)
 	_ = util.UpdateMapFromStringSlice(&metadata, metadataParams)
+	if mqType == fv1.MessageQueueTypeKafka {
+		setKafkaMetadata(metadata, brokers, consumerGroup, topic)
+	}
 
 	secret := input.String(flagkey.MqtSecret)
 
@@ -171,6 +209,8 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 			Metadata:         metadata,
 			Secret:           secret,
 			MqtKind:          mqtKind,
+			Brokers:          brokers,
+			ConsumerGroup:    consumerGroup,
 		},
 	}
 
@@ -210,3 +250,18 @@ func checkMQTopicAvailability(mqType fv1.MessageQueueType, mqtKind string, topic
 	}
 	return nil
 }
+
+// setKafkaMetadata fills in the scaler metadata keda needs to connect to
+// kafka, unless they were given with --metadata.
+func setKafkaMetadata(metadata map[string]string, brokers []string, consumerGroup, topic string) {
+	defaults := map[string]string{
+		"bootstrapServers": strings.Join(brokers, ","),
+		"consumerGroup":    consumerGroup,
+		"topic":            topic,
+	}
+	for k, v := range defaults {
+		if _, ok := metadata[k]; !ok && len(v) > 0 {
+			metadata[k] = v
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type PauseSubCommand struct {
+	cmd.CommandActioner
+	paused bool
+}
+
+// Pause stops a message queue trigger from consuming messages without
+// deleting it.
+func Pause(input cli.Input) error {
+	return (&PauseSubCommand{paused: true}).do(input)
+}
+
+// Resume makes a paused message queue trigger consume messages again.
+func Resume(input cli.Input) error {
+	return (&PauseSubCommand{paused: false}).do(input)
+}
+
+func (opts *PauseSubCommand) do(input cli.Input) error {
+	mqt, err := opts.Client().V1().MessageQueueTrigger().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.MqtName),
+		Namespace: input.String(flagkey.NamespaceTrigger),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting message queue trigger")
+	}
+
+	state := "paused"
+	if !opts.paused {
+		state = "resumed"
+	}
+	if mqt.Spec.Paused == opts.paused {
+		fmt.Printf("trigger '%v' is already %v\n", mqt.ObjectMeta.Name, state)
+		return nil
+	}
+
+	mqt.Spec.Paused = opts.paused
+	_, err = opts.Client().V1().MessageQueueTrigger().Update(mqt)
+	if err != nil {
+		return errors.Wrap(err, "error updating message queue trigger")
+	}
+
+	fmt.Printf("trigger '%v' %v\n", mqt.ObjectMeta.Name, state)
+	return nil
+}
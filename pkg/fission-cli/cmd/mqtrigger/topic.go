@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqtrigger
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// maxTopicLength is the longest topic name each broker accepts, in
+// bytes.
+var maxTopicLength = map[fv1.MessageQueueType]int{
+	fv1.MessageQueueTypeKafka: 249,
+	fv1.MessageQueueTypeNats:  256,
+}
+
+// kafkaInternalTopics are the topics kafka keeps its own state in.
+var kafkaInternalTopics = []string{"__consumer_offsets", "__transaction_state"}
+
+// checkTopicName rejects topic names no broker accepts and returns
+// warnings for names that are valid but most likely a mistake.
+func checkTopicName(mqType fv1.MessageQueueType, topic string) ([]string, error) {
+	for _, r := range topic {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return nil, errors.Errorf("topic '%v' must not contain spaces or control characters", topic)
+		}
+	}
+	if max, ok := maxTopicLength[mqType]; ok && len(topic) > max {
+		return nil, errors.Errorf("topic '%v' is %v bytes long, %v allows at most %v", topic, len(topic), mqType, max)
+	}
+
+	var warnings []string
+	if mqType == fv1.MessageQueueTypeKafka {
+		for _, internal := range kafkaInternalTopics {
+			if topic == internal {
+				warnings = append(warnings, fmt.Sprintf("topic '%v' is an internal kafka topic", topic))
+			}
+		}
+		if len(warnings) == 0 && strings.HasPrefix(topic, "__") {
+			warnings = append(warnings, fmt.Sprintf("topic '%v' starts with '__', which kafka reserves for internal topics", topic))
+		}
+	}
+	return warnings, nil
+}
@@ -70,7 +70,7 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	mqtKind := input.String(flagkey.MqtKind)
 	// TODO : Find out if we can make a call to checkIfFunctionExists, in the same ns more importantly.
 
-	err = checkMQTopicAvailability(mqt.Spec.MessageQueueType, topic, respTopic)
+	err = checkMQTopicAvailability(mqt.Spec.MessageQueueType, mqt.Spec.MqtKind, topic, respTopic)
 	if err != nil {
 		return err
 	}
@@ -125,6 +125,24 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		updated = true
 	}
 
+	if input.IsSet(flagkey.MqtBrokers) || input.IsSet(flagkey.MqtConsumerGroup) {
+		if mqt.Spec.MessageQueueType != fv1.MessageQueueTypeKafka {
+			return errors.Errorf("--%v and --%v are only supported for message queue type %v",
+				flagkey.MqtBrokers, flagkey.MqtConsumerGroup, fv1.MessageQueueTypeKafka)
+		}
+		if input.IsSet(flagkey.MqtBrokers) {
+			brokers := input.StringSlice(flagkey.MqtBrokers)
+			if len(brokers) == 0 {
+				return errors.Errorf("--%v cannot be empty", flagkey.MqtBrokers)
+			}
+			mqt.Spec.Brokers = brokers
+		}
+		if input.IsSet(flagkey.MqtConsumerGroup) {
+			mqt.Spec.ConsumerGroup = input.String(flagkey.MqtConsumerGroup)
+		}
+		updated = true
+	}
+
 	if input.IsSet(flagkey.MqtKind) {
 		mqt.Spec.MqtKind = mqtKind
 		updated = true
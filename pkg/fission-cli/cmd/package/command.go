@@ -32,8 +32,8 @@ func Commands() *cobra.Command {
 	wrapper.SetFlags(createCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgEnvironment},
 		Optional: []flag.Flag{flag.PkgName, flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd,
-			flag.NamespacePackage, flag.NamespaceEnvironment, flag.SpecSave, flag.SpecDry},
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd, flag.PkgSourceURL, flag.PkgChunkSize,
+			flag.PkgURL, flag.PkgURLChecksum, flag.NamespacePackage, flag.NamespaceEnvironment, flag.SpecSave, flag.SpecDry},
 	})
 
 	getSrcCmd := &cobra.Command{
@@ -64,8 +64,8 @@ func Commands() *cobra.Command {
 	wrapper.SetFlags(updateCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
 		Optional: []flag.Flag{flag.PkgEnvironment, flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
-			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd, flag.PkgForce,
-			flag.NamespacePackage, flag.NamespaceEnvironment},
+			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure, flag.PkgBuildCmd, flag.PkgForce, flag.PkgChunkSize,
+			flag.PkgRebuild, flag.PkgWait, flag.PkgWaitTimeout, flag.NamespacePackage, flag.NamespaceEnvironment},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -87,6 +87,17 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.PkgOrphan, flag.PkgStatus, flag.NamespacePackage},
 	})
 
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete orphaned packages",
+		Long:  "Delete the packages that aren't referenced by any function and were created more than a day ago, e.g. 'fission pkg prune --orphan --older-than 30d'. Packages used by a function are never pruned.",
+		RunE:  wrapper.Wrapper(Prune),
+	}
+	wrapper.SetFlags(pruneCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.PkgOrphan},
+		Optional: []flag.Flag{flag.PkgOlderThan, flag.PkgPruneYes, flag.NamespacePackage},
+	})
+
 	infoCmd := &cobra.Command{
 		Use:   "info",
 		Short: "Show package information",
@@ -94,7 +105,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(infoCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.PkgName},
-		Optional: []flag.Flag{flag.NamespacePackage},
+		Optional: []flag.Flag{flag.NamespacePackage, flag.PkgBuildLogTail},
 	})
 
 	rebuildCmd := &cobra.Command{
@@ -113,7 +124,7 @@ func Commands() *cobra.Command {
 		Short:   "Create, update and manage packages",
 	}
 
-	command.AddCommand(createCmd, getSrcCmd, getDeployCmd, updateCmd, deleteCmd, listCmd, infoCmd, rebuildCmd)
+	command.AddCommand(createCmd, getSrcCmd, getDeployCmd, updateCmd, deleteCmd, listCmd, pruneCmd, infoCmd, rebuildCmd)
 
 	return command
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package _package
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path"
 	"strings"
@@ -78,8 +80,43 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 		noZip = true
 	}
 
+	if input.IsSet(flagkey.PkgSourceURL) {
+		if input.Bool(flagkey.SpecSave) || input.Bool(flagkey.SpecDry) {
+			return errors.Errorf("--%v cannot be used together with --%v or --%v", flagkey.PkgSourceURL, flagkey.SpecSave, flagkey.SpecDry)
+		}
+		if len(srcArchiveFiles) > 0 {
+			return errors.Errorf("--%v and --%v are mutually exclusive", flagkey.PkgSourceURL, flagkey.PkgSrcArchive)
+		}
+		archive, err := archiveFromGitURL(input.String(flagkey.PkgSourceURL))
+		if err != nil {
+			return err
+		}
+		srcArchiveFiles = []string{archive}
+	}
+
+	if input.IsSet(flagkey.PkgURL) {
+		if len(deployArchiveFiles) > 0 {
+			return errors.Errorf("--%v cannot be used together with --%v or --%v", flagkey.PkgURL, flagkey.PkgDeployArchive, flagkey.PkgCode)
+		}
+		archiveURL := input.String(flagkey.PkgURL)
+		if !strings.HasPrefix(archiveURL, "http://") && !strings.HasPrefix(archiveURL, "https://") {
+			return errors.Errorf("--%v must be an http or https URL", flagkey.PkgURL)
+		}
+		deployArchiveFiles = []string{archiveURL}
+	}
+
+	if input.IsSet(flagkey.PkgURLChecksum) {
+		if !input.IsSet(flagkey.PkgURL) {
+			return errors.Errorf("--%v requires --%v", flagkey.PkgURLChecksum, flagkey.PkgURL)
+		}
+		err := validateSHA256(input.String(flagkey.PkgURLChecksum))
+		if err != nil {
+			return errors.Wrapf(err, "invalid --%v", flagkey.PkgURLChecksum)
+		}
+	}
+
 	if len(srcArchiveFiles) == 0 && len(deployArchiveFiles) == 0 {
-		return errors.Errorf("need --%v or --%v or --%v argument", flagkey.PkgCode, flagkey.PkgSrcArchive, flagkey.PkgDeployArchive)
+		return errors.Errorf("need --%v or --%v or --%v or --%v or --%v argument", flagkey.PkgCode, flagkey.PkgSrcArchive, flagkey.PkgDeployArchive, flagkey.PkgSourceURL, flagkey.PkgURL)
 	}
 
 	var specDir, specFile string
@@ -121,6 +158,10 @@ func CreatePackage(input cli.Input, client client.Interface, pkgName string, pkg
 
 	insecure := input.Bool(flagkey.PkgInsecure)
 	deployChecksum := input.String(flagkey.PkgDeployChecksum)
+	if input.IsSet(flagkey.PkgURLChecksum) {
+		// the fetcher compares against the lower case hex sum
+		deployChecksum = strings.ToLower(input.String(flagkey.PkgURLChecksum))
+	}
 	srcChecksum := input.String(flagkey.PkgSrcChecksum)
 
 	pkgSpec := fv1.PackageSpec{
@@ -212,3 +253,12 @@ func CreatePackage(input cli.Input, client client.Interface, pkgName string, pkg
 		return pkgMetadata, nil
 	}
 }
+
+// validateSHA256 checks that sum is a hex encoded SHA256 checksum.
+func validateSHA256(sum string) error {
+	b, err := hex.DecodeString(sum)
+	if err != nil || len(b) != sha256.Size {
+		return errors.Errorf("'%v' is not a hex encoded SHA256 checksum", sum)
+	}
+	return nil
+}
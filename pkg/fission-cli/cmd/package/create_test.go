@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import "testing"
+
+func Test_validateSHA256(t *testing.T) {
+	tests := []struct {
+		sum     string
+		wantErr bool
+	}{
+		{sum: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{sum: "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"},
+		{sum: "e3b0c442", wantErr: true},
+		{sum: "not-a-checksum", wantErr: true},
+		{sum: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sum, func(t *testing.T) {
+			err := validateSHA256(tt.sum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSHA256() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/utils"
+)
+
+const FISSION_IGNORE_FILE = ".fissionignore"
+
+// parseGitSourceURL splits a Git URL with an optional "#ref" or "@ref"
+// suffix into the repository URL and the ref, which may contain slashes
+// like feature/x. The user part of URLs like git@github.com:org/repo.git
+// is not treated as a ref.
+func parseGitSourceURL(sourceURL string) (repoURL string, ref string) {
+	if idx := strings.LastIndex(sourceURL, "#"); idx >= 0 {
+		return sourceURL[:idx], sourceURL[idx+1:]
+	}
+	idx := strings.LastIndex(sourceURL, "@")
+	if idx < 0 || !hasRepoPath(sourceURL[:idx]) {
+		return sourceURL, ""
+	}
+	return sourceURL[:idx], sourceURL[idx+1:]
+}
+
+// hasRepoPath returns true if the URL goes beyond the user part, i.e.
+// it contains the path of a repository on a host.
+func hasRepoPath(url string) bool {
+	if idx := strings.Index(url, "://"); idx >= 0 {
+		return strings.Contains(url[idx+3:], "/")
+	}
+	return strings.ContainsAny(url, "/:")
+}
+
+// archiveFromGitURL shallow clones the repository into a temporary
+// directory, removes files matched by .fissionignore and zips the
+// remaining content. It returns the path of the zip file.
+func archiveFromGitURL(sourceURL string) (string, error) {
+	repoURL, ref := parseGitSourceURL(sourceURL)
+
+	tmpDir, err := utils.GetTempDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error creating temporary directory")
+	}
+	cloneDir, err := os.MkdirTemp(tmpDir, "fission-git-")
+	if err != nil {
+		return "", errors.Wrap(err, "error creating clone directory")
+	}
+	defer os.RemoveAll(cloneDir)
+
+	console.Verbose(2, "Cloning %v (ref: '%v') into %v", repoURL, ref, cloneDir)
+
+	err = shallowClone(cloneDir, repoURL, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "error cloning git repository %v", sourceURL)
+	}
+
+	err = os.RemoveAll(filepath.Join(cloneDir, ".git"))
+	if err != nil {
+		return "", errors.Wrap(err, "error removing git metadata")
+	}
+
+	err = removeIgnoredFiles(cloneDir)
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(cloneDir)
+	if err != nil {
+		return "", errors.Wrap(err, "error reading cloned repository")
+	}
+	if len(entries) == 0 {
+		return "", errors.Errorf("no files left to archive in git repository %v", sourceURL)
+	}
+
+	includes := make([]string, 0, len(entries))
+	for _, e := range entries {
+		includes = append(includes, filepath.Join(cloneDir, e.Name()))
+	}
+
+	name := archiveName(filepath.Base(strings.TrimSuffix(repoURL, ".git")), nil)
+	archivePath, err := utils.MakeZipArchive(filepath.Join(tmpDir, fmt.Sprintf("%v.zip", name)), includes...)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating archive from git repository")
+	}
+
+	return archivePath, nil
+}
+
+// shallowClone clones the repo with depth 1. The ref is tried as a
+// branch first, then as a tag.
+func shallowClone(dir string, repoURL string, ref string) error {
+	opts := &git.CloneOptions{
+		URL:          repoURL,
+		Depth:        1,
+		SingleBranch: true,
+	}
+
+	if len(ref) == 0 {
+		_, err := git.PlainClone(dir, false, opts)
+		return err
+	}
+
+	opts.ReferenceName = plumbing.NewBranchReferenceName(ref)
+	_, err := git.PlainClone(dir, false, opts)
+	if err == nil {
+		return nil
+	}
+
+	// clean up the partial clone before retrying with a tag
+	os.RemoveAll(filepath.Join(dir, ".git"))
+
+	opts.ReferenceName = plumbing.NewTagReferenceName(ref)
+	_, tagErr := git.PlainClone(dir, false, opts)
+	if tagErr != nil {
+		return errors.Errorf("unable to find branch or tag '%v': %v", ref, err)
+	}
+	return nil
+}
+
+// removeIgnoredFiles deletes the files under dir that match the
+// .fissionignore patterns (same syntax as .gitignore).
+func removeIgnoredFiles(dir string) error {
+	ignoreFile := filepath.Join(dir, FISSION_IGNORE_FILE)
+	if _, err := os.Stat(ignoreFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	ignoreParser, err := ignore.CompileIgnoreFile(ignoreFile)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing %v", FISSION_IGNORE_FILE)
+	}
+
+	var ignored []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if rel == FISSION_IGNORE_FILE || ignoreParser.MatchesPath(rel) {
+			ignored = append(ignored, path)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error scanning cloned repository")
+	}
+
+	for _, path := range ignored {
+		console.Verbose(2, "Ignoring %v", path)
+		err = os.RemoveAll(path)
+		if err != nil {
+			return errors.Wrapf(err, "error removing ignored file %v", path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"testing"
+)
+
+func Test_parseGitSourceURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantURL string
+		wantRef string
+	}{
+		{
+			name:    "https-without-ref",
+			url:     "https://github.com/fission/examples.git",
+			wantURL: "https://github.com/fission/examples.git",
+		},
+		{
+			name:    "https-with-ref",
+			url:     "https://github.com/fission/examples.git@v1.0.0",
+			wantURL: "https://github.com/fission/examples.git",
+			wantRef: "v1.0.0",
+		},
+		{
+			name:    "ssh-without-ref",
+			url:     "git@github.com:fission/examples.git",
+			wantURL: "git@github.com:fission/examples.git",
+		},
+		{
+			name:    "ssh-with-ref",
+			url:     "git@github.com:fission/examples.git@main",
+			wantURL: "git@github.com:fission/examples.git",
+			wantRef: "main",
+		},
+		{
+			name:    "https-with-user-without-ref",
+			url:     "https://user@github.com/fission/examples.git",
+			wantURL: "https://user@github.com/fission/examples.git",
+		},
+		{
+			name:    "https-with-slash-ref",
+			url:     "https://github.com/fission/examples.git@feature/x",
+			wantURL: "https://github.com/fission/examples.git",
+			wantRef: "feature/x",
+		},
+		{
+			name:    "ssh-with-slash-ref",
+			url:     "git@github.com:fission/examples.git@feature/x",
+			wantURL: "git@github.com:fission/examples.git",
+			wantRef: "feature/x",
+		},
+		{
+			name:    "hash-ref",
+			url:     "https://github.com/fission/examples.git#feature/x",
+			wantURL: "https://github.com/fission/examples.git",
+			wantRef: "feature/x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotRef := parseGitSourceURL(tt.url)
+			if gotURL != tt.wantURL || gotRef != tt.wantRef {
+				t.Errorf("parseGitSourceURL() = (%v, %v), want (%v, %v)", gotURL, gotRef, tt.wantURL, tt.wantRef)
+			}
+		})
+	}
+}
@@ -17,14 +17,21 @@ limitations under the License.
 package _package
 
 import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
-	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 )
 
@@ -32,6 +39,7 @@ type InfoSubCommand struct {
 	cmd.CommandActioner
 	name      string
 	namespace string
+	tail      int
 }
 
 func Info(input cli.Input) error {
@@ -49,6 +57,10 @@ func (opts *InfoSubCommand) do(input cli.Input) error {
 func (opts *InfoSubCommand) complete(input cli.Input) error {
 	opts.name = input.String(flagkey.PkgName)
 	opts.namespace = input.String(flagkey.NamespacePackage)
+	opts.tail = input.Int(flagkey.PkgBuildLogTail)
+	if opts.tail < 0 {
+		return errors.Errorf("--%v must not be negative", flagkey.PkgBuildLogTail)
+	}
 	return nil
 }
 
@@ -60,6 +72,78 @@ func (opts *InfoSubCommand) run(input cli.Input) error {
 	if err != nil {
 		return errors.Wrapf(err, "error finding package %s", opts.name)
 	}
-	pkgutil.PrintPackageSummary(os.Stdout, pkg)
+
+	fns, err := GetFunctionsByPackage(opts.Client(), pkg.ObjectMeta.Name, pkg.ObjectMeta.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "error getting functions referencing the package")
+	}
+
+	printPackageInfo(os.Stdout, pkg, fns, opts.tail)
 	return nil
 }
+
+// printPackageInfo prints the archives, build status and referencing
+// functions of a package, followed by the build log or its last tail
+// lines if tail isn't 0.
+func printPackageInfo(writer io.Writer, pkg *fv1.Package, fns []fv1.Function, tail int) {
+	w := tabwriter.NewWriter(writer, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\n", "Name:", pkg.ObjectMeta.Name)
+	fmt.Fprintf(w, "%v\t%v\n", "Namespace:", pkg.ObjectMeta.Namespace)
+	fmt.Fprintf(w, "%v\t%v\n", "Environment:", pkg.Spec.Environment.Name)
+	fmt.Fprintf(w, "%v\t%v\n", "Source Archive:", describeArchive(pkg.Spec.Source))
+	fmt.Fprintf(w, "%v\t%v\n", "Deployment Archive:", describeArchive(pkg.Spec.Deployment))
+	fmt.Fprintf(w, "%v\t%v\n", "Build Command:", pkg.Spec.BuildCommand)
+	fmt.Fprintf(w, "%v\t%v\n", "Status:", pkg.Status.BuildStatus)
+	fmt.Fprintf(w, "%v\t%v\n", "Last Build:", pkg.Status.LastUpdateTimestamp.Format(time.RFC3339))
+
+	var fnNames []string
+	for _, fn := range fns {
+		fnNames = append(fnNames, fn.ObjectMeta.Name)
+	}
+	fmt.Fprintf(w, "%v\t%v\n", "Functions:", strings.Join(fnNames, ", "))
+	w.Flush()
+
+	// replace escaped line breaker character
+	buildlog := strings.ReplaceAll(pkg.Status.BuildLog, `\n`, "\n")
+	if tail == 0 {
+		fmt.Fprintf(writer, "%v\n%v", "Build Logs:", buildlog)
+		return
+	}
+	fmt.Fprintf(writer, "Build Logs (last %v lines):\n%v", tail, tailLines(buildlog, tail))
+}
+
+// describeArchive returns the URL of an URL archive, or the size of a
+// literal archive. The uncompressed size is added for zip archives.
+func describeArchive(archive fv1.Archive) string {
+	switch archive.Type {
+	case fv1.ArchiveTypeUrl:
+		if len(archive.URL) == 0 {
+			return "-"
+		}
+		return archive.URL
+	case fv1.ArchiveTypeLiteral:
+		if len(archive.Literal) == 0 {
+			return "-"
+		}
+		desc := fmt.Sprintf("literal, %v bytes", len(archive.Literal))
+		r, err := zip.NewReader(bytes.NewReader(archive.Literal), int64(len(archive.Literal)))
+		if err == nil {
+			var size uint64
+			for _, f := range r.File {
+				size += f.UncompressedSize64
+			}
+			desc += fmt.Sprintf(" (%v bytes uncompressed)", size)
+		}
+		return desc
+	}
+	return "-"
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
@@ -63,32 +64,33 @@ func (opts *ListSubCommand) run(input cli.Input) error {
 		return err
 	}
 
+	referenced, err := referencedPackages(opts.Client(), opts.pkgNamespace, pkgList)
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
+	}
+
 	// sort the package list by lastUpdatedTimestamp
 	sort.Slice(pkgList, func(i, j int) bool {
 		return pkgList[i].Status.LastUpdateTimestamp.After(pkgList[j].Status.LastUpdateTimestamp.Time)
 	})
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", "NAME", "BUILD_STATUS", "ENV", "LASTUPDATEDAT")
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "BUILD_STATUS", "ENV", "LASTUPDATEDAT", "AGE", "REFERENCED")
 
 	for _, pkg := range pkgList {
-		show := true
-		// TODO improve list speed when --orphan
-		if opts.listOrphans {
-			fnList, err := GetFunctionsByPackage(opts.Client(), pkg.ObjectMeta.Name, pkg.ObjectMeta.Namespace)
-			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("get functions sharing package %s", pkg.ObjectMeta.Name))
-			}
-			if len(fnList) > 0 {
-				show = false
-			}
+		isReferenced := referenced[pkgKey(pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name)]
+		if opts.listOrphans && isReferenced {
+			continue
 		}
 		if len(opts.status) > 0 && opts.status != string(pkg.Status.BuildStatus) {
-			show = false
+			continue
 		}
-		if show {
-			fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", pkg.ObjectMeta.Name, pkg.Status.BuildStatus, pkg.Spec.Environment.Name, pkg.Status.LastUpdateTimestamp.Format(time.RFC822))
+		refColumn := "no"
+		if isReferenced {
+			refColumn = "yes"
 		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", pkg.ObjectMeta.Name, pkg.Status.BuildStatus, pkg.Spec.Environment.Name,
+			pkg.Status.LastUpdateTimestamp.Format(time.RFC822), duration.HumanDuration(time.Since(pkg.ObjectMeta.CreationTimestamp.Time)), refColumn)
 	}
 
 	w.Flush()
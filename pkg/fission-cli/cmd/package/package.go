@@ -17,6 +17,7 @@ limitations under the License.
 package _package
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -193,9 +194,70 @@ func CreateArchive(client client.Interface, input cli.Input, includeFiles []stri
 	if err != nil {
 		return nil, err
 	}
+	return uploadArchive(client, input, archivePath)
+}
 
-	ctx := context.Background()
-	return pkgutil.UploadArchiveFile(ctx, client, archivePath)
+// uploadArchive uploads the archive file with the chunk size given by
+// --chunk-size.
+func uploadArchive(client client.Interface, input cli.Input, archivePath string) (*fv1.Archive, error) {
+	// chunk size is given in megabytes, 0 means the default chunk size
+	chunkSize := int64(input.Int(flagkey.PkgChunkSize)) * 1024 * 1024
+	return pkgutil.UploadArchiveFileWithChunkSize(context.Background(), client, archivePath, chunkSize)
+}
+
+// UpdateArchive creates an archive like CreateArchive, but skips the
+// upload if the local archive has the same SHA256 checksum as the
+// current archive of the package. It returns whether the archive changed.
+func UpdateArchive(client client.Interface, input cli.Input, includeFiles []string, noZip bool, insecure bool, checksum string, current *fv1.Archive) (*fv1.Archive, bool, error) {
+	// specs and URLs are not uploaded, nothing to skip
+	if input.Bool(flagkey.SpecSave) || input.Bool(flagkey.SpecDry) {
+		archive, err := CreateArchive(client, input, includeFiles, noZip, insecure, checksum, "", "")
+		return archive, true, err
+	}
+	for _, path := range includeFiles {
+		if utils.IsURL(path) {
+			archive, err := CreateArchive(client, input, includeFiles, noZip, insecure, checksum, "", "")
+			return archive, true, err
+		}
+	}
+
+	archivePath, err := makeArchiveFile("", includeFiles, noZip)
+	if err != nil {
+		return nil, false, err
+	}
+
+	csum, err := utils.GetFileChecksum(archivePath)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "error generating file SHA256 checksum")
+	}
+	if sum := archiveChecksum(current); len(sum) > 0 && sum == csum.Sum {
+		fmt.Println("package unchanged, skipping upload")
+		return current, false, nil
+	}
+
+	archive, err := uploadArchive(client, input, archivePath)
+	if err != nil {
+		return nil, false, err
+	}
+	return archive, true, nil
+}
+
+// archiveChecksum returns the SHA256 checksum of an archive, computed
+// from the content for literal archives which don't carry one.
+func archiveChecksum(archive *fv1.Archive) string {
+	if archive == nil {
+		return ""
+	}
+	if archive.Checksum.Type == fv1.ChecksumTypeSHA256 && len(archive.Checksum.Sum) > 0 {
+		return archive.Checksum.Sum
+	}
+	if archive.Type == fv1.ArchiveTypeLiteral && len(archive.Literal) > 0 {
+		csum, err := utils.GetChecksum(bytes.NewReader(archive.Literal))
+		if err == nil {
+			return csum.Sum
+		}
+	}
+	return ""
 }
 
 // makeArchiveFile creates a zip file from the given list of input files,
@@ -267,3 +329,43 @@ func GetFunctionsByPackage(client client.Interface, pkgName, pkgNamespace string
 	}
 	return fns, nil
 }
+
+// REVISION_FUNCTION_LABEL is set on the packages kept as revisions of a
+// function for rollback, its value is the name of the function.
+const REVISION_FUNCTION_LABEL = "fission.io/revision-of"
+
+// referencedPackages returns the packages of pkgList referenced by any
+// function in the namespace, or kept as revision of an existing
+// function, keyed by pkgKey. Functions are listed once, instead of once
+// per package as GetFunctionsByPackage does.
+func referencedPackages(client client.Interface, namespace string, pkgList []fv1.Package) (map[string]bool, error) {
+	fnList, err := client.V1().Function().List(namespace)
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(fnList))
+	functions := make(map[string]bool, len(fnList))
+	for _, fn := range fnList {
+		functions[pkgKey(fn.ObjectMeta.Namespace, fn.ObjectMeta.Name)] = true
+		ref := fn.Spec.Package.PackageRef
+		if len(ref.Name) == 0 {
+			continue
+		}
+		refNamespace := ref.Namespace
+		if len(refNamespace) == 0 {
+			refNamespace = fn.ObjectMeta.Namespace
+		}
+		referenced[pkgKey(refNamespace, ref.Name)] = true
+	}
+	for _, pkg := range pkgList {
+		fnName, ok := pkg.ObjectMeta.Labels[REVISION_FUNCTION_LABEL]
+		if ok && functions[pkgKey(pkg.ObjectMeta.Namespace, fnName)] {
+			referenced[pkgKey(pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name)] = true
+		}
+	}
+	return referenced, nil
+}
+
+func pkgKey(namespace, name string) string {
+	return namespace + "/" + name
+}
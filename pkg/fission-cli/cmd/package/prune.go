@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type PruneSubCommand struct {
+	cmd.CommandActioner
+	namespace string
+	olderThan time.Duration
+}
+
+// Prune deletes the packages that aren't referenced by any function and
+// are older than a given age, a day by default. Packages used by a
+// function or kept as its revision are never pruned.
+func Prune(input cli.Input) error {
+	return (&PruneSubCommand{}).do(input)
+}
+
+func (opts *PruneSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *PruneSubCommand) complete(input cli.Input) (err error) {
+	opts.namespace = input.String(flagkey.NamespacePackage)
+	if !input.Bool(flagkey.PkgOrphan) {
+		return errors.Errorf("only orphaned packages can be pruned, set --%v", flagkey.PkgOrphan)
+	}
+	opts.olderThan, err = parseAge(input.String(flagkey.PkgOlderThan))
+	return err
+}
+
+func (opts *PruneSubCommand) run(input cli.Input) error {
+	pkgList, err := opts.Client().V1().Package().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing packages")
+	}
+	referenced, err := referencedPackages(opts.Client(), opts.namespace, pkgList)
+	if err != nil {
+		return errors.Wrap(err, "error listing functions")
+	}
+
+	var candidates []fv1.Package
+	for _, pkg := range pkgList {
+		if referenced[pkgKey(pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name)] {
+			continue
+		}
+		if time.Since(pkg.ObjectMeta.CreationTimestamp.Time) < opts.olderThan {
+			continue
+		}
+		candidates = append(candidates, pkg)
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No orphaned packages found")
+		return nil
+	}
+
+	if !input.Bool(flagkey.PkgPruneYes) {
+		fmt.Println("The following packages are not used by any function and will be deleted:")
+		for _, pkg := range candidates {
+			fmt.Printf("  %v/%v, age %v\n", pkg.ObjectMeta.Namespace, pkg.ObjectMeta.Name,
+				duration.HumanDuration(time.Since(pkg.ObjectMeta.CreationTimestamp.Time)))
+		}
+		if !util.Confirm(os.Stdin, fmt.Sprintf("Delete %v packages?", len(candidates))) {
+			fmt.Println("Skipped pruning.")
+			return nil
+		}
+	}
+
+	pruned := 0
+	for _, pkg := range candidates {
+		err = deletePackage(opts.Client(), pkg.ObjectMeta.Name, pkg.ObjectMeta.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting package '%v'", pkg.ObjectMeta.Name)
+		}
+		pruned++
+		fmt.Printf("Package '%v' deleted\n", pkg.ObjectMeta.Name)
+	}
+	fmt.Printf("%v package(s) pruned\n", pruned)
+
+	return nil
+}
+
+// parseAge parses an age like time.ParseDuration, with the additional
+// unit "d" for days, e.g. 30d.
+func parseAge(age string) (time.Duration, error) {
+	if strings.HasSuffix(age, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(age, "d"))
+		if err != nil || days < 0 {
+			return 0, errors.Errorf("invalid age '%v', ex: 30d, 12h", age)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil || d < 0 {
+		return 0, errors.Errorf("invalid age '%v', ex: 30d, 12h", age)
+	}
+	return d, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package _package
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseAge(t *testing.T) {
+	tests := []struct {
+		age     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{age: "30d", want: 30 * 24 * time.Hour},
+		{age: "0d", want: 0},
+		{age: "12h", want: 12 * time.Hour},
+		{age: "1h30m", want: 90 * time.Minute},
+		{age: "d", wantErr: true},
+		{age: "-1d", wantErr: true},
+		{age: "30", wantErr: true},
+		{age: "1w", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			got, err := parseAge(tt.age)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package _package
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -89,6 +90,10 @@ func (opts *UpdateSubCommand) run(input cli.Input) error {
 		}
 	}
 
+	if input.Bool(flagkey.PkgWait) {
+		return waitForBuild(opts.Client(), newPkgMeta, input.Duration(flagkey.PkgWaitTimeout))
+	}
+
 	return nil
 }
 
@@ -131,14 +136,27 @@ func UpdatePackage(input cli.Input, client client.Interface, pkg *fv1.Package) (
 		needToUpdate = true
 	}
 
+	if input.Bool(flagkey.PkgRebuild) {
+		if input.IsSet(flagkey.PkgDeployArchive) || input.IsSet(flagkey.PkgCode) {
+			return nil, errors.Errorf("--%v cannot be used together with a new deploy archive", flagkey.PkgRebuild)
+		}
+		if len(pkg.Spec.Source.Type) == 0 && !input.IsSet(flagkey.PkgSrcArchive) {
+			return nil, errors.Errorf("package '%v' has no source archive to rebuild from", pkg.ObjectMeta.Name)
+		}
+		needToRebuild = true
+		needToUpdate = true
+	}
+
 	if input.IsSet(flagkey.PkgSrcArchive) {
-		srcArchive, err := CreateArchive(client, input, srcArchiveFiles, noZip, insecure, srcChecksum, "", "")
+		srcArchive, changed, err := UpdateArchive(client, input, srcArchiveFiles, noZip, insecure, srcChecksum, &pkg.Spec.Source)
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating source archive")
 		}
-		pkg.Spec.Source = *srcArchive
-		needToRebuild = true
-		needToUpdate = true
+		if changed {
+			pkg.Spec.Source = *srcArchive
+			needToRebuild = true
+			needToUpdate = true
+		}
 	} else if input.IsSet(flagkey.PkgSrcChecksum) {
 		pkg.Spec.Source.Checksum = fv1.Checksum{
 			Type: fv1.ChecksumTypeSHA256,
@@ -148,15 +166,17 @@ func UpdatePackage(input cli.Input, client client.Interface, pkg *fv1.Package) (
 	}
 
 	if input.IsSet(flagkey.PkgDeployArchive) || input.IsSet(flagkey.PkgCode) {
-		deployArchive, err := CreateArchive(client, input, deployArchiveFiles, noZip, insecure, deployChecksum, "", "")
+		deployArchive, changed, err := UpdateArchive(client, input, deployArchiveFiles, noZip, insecure, deployChecksum, &pkg.Spec.Deployment)
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating deploy archive")
 		}
-		pkg.Spec.Deployment = *deployArchive
 		// Users may update the env, envNS and deploy archive at the same time,
 		// but without the source archive. In this case, we should set needToBuild to false
 		needToRebuild = false
-		needToUpdate = true
+		if changed {
+			pkg.Spec.Deployment = *deployArchive
+			needToUpdate = true
+		}
 	} else if input.IsSet(flagkey.PkgDeployChecksum) {
 		pkg.Spec.Deployment.Checksum = fv1.Checksum{
 			Type: fv1.ChecksumTypeSHA256,
@@ -188,6 +208,46 @@ func UpdatePackage(input cli.Input, client client.Interface, pkg *fv1.Package) (
 	return newPkgMeta, err
 }
 
+// waitForBuild polls the package until its build finished, printing the
+// build log as it grows. A failed build, or one still running after
+// timeout, is returned as an error.
+func waitForBuild(client client.Interface, pkgMeta *metav1.ObjectMeta, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	printed := 0
+	for {
+		pkg, err := client.V1().Package().Get(&metav1.ObjectMeta{
+			Name:      pkgMeta.Name,
+			Namespace: pkgMeta.Namespace,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error getting package")
+		}
+
+		buildlog := strings.ReplaceAll(pkg.Status.BuildLog, `\n`, "\n")
+		if len(buildlog) > printed {
+			fmt.Print(buildlog[printed:])
+			printed = len(buildlog)
+		}
+
+		switch pkg.Status.BuildStatus {
+		case fv1.BuildStatusSucceeded:
+			fmt.Printf("\nPackage '%v' built successfully\n", pkg.ObjectMeta.Name)
+			return nil
+		case fv1.BuildStatusFailed:
+			return errors.Errorf("build of package '%v' failed", pkg.ObjectMeta.Name)
+		case fv1.BuildStatusNone:
+			// nothing to build, e.g. a package with a deploy archive only
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("package '%v' is still building after %v, check its status with 'fission pkg info --name %v'",
+				pkg.ObjectMeta.Name, timeout, pkg.ObjectMeta.Name)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
 func UpdateFunctionPackageResourceVersion(client client.Interface, pkgMeta *metav1.ObjectMeta, fnList ...fv1.Function) error {
 	errs := &multierror.Error{}
 
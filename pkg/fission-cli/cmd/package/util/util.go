@@ -35,14 +35,36 @@ import (
 	"github.com/fission/fission/pkg/utils"
 )
 
+// DefaultUploadChunkSize is the size of the chunks large archives are
+// split into when uploading them to the storage service.
+const DefaultUploadChunkSize int64 = 10 * 1024 * 1024
+
 func UploadArchiveFile(ctx context.Context, client client.Interface, fileName string) (*fv1.Archive, error) {
+	return UploadArchiveFileWithChunkSize(ctx, client, fileName, DefaultUploadChunkSize)
+}
+
+// UploadArchiveFileWithChunkSize uploads the archive like UploadArchiveFile,
+// archives larger than chunkSize are sent in chunks.
+func UploadArchiveFileWithChunkSize(ctx context.Context, client client.Interface, fileName string, chunkSize int64) (*fv1.Archive, error) {
 	var archive fv1.Archive
 
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+
 	size, err := utils.FileSize(fileName)
 	if err != nil {
 		return nil, err
 	}
 
+	// the checksum is computed before sending the archive, so the fetcher
+	// detects archives corrupted in transit or in storage
+	csum, err := utils.GetFileChecksum(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "calculate checksum for file %v", fileName)
+	}
+	archive.Checksum = *csum
+
 	if size < fv1.ArchiveLiteralSizeLimit {
 		archive.Type = fv1.ArchiveTypeLiteral
 		archive.Literal, err = GetContents(fileName)
@@ -54,7 +76,12 @@ func UploadArchiveFile(ctx context.Context, client client.Interface, fileName st
 		ssClient := storageSvcClient.MakeClient(u)
 
 		// TODO add a progress bar
-		id, err := ssClient.Upload(ctx, fileName, nil)
+		var id string
+		if size > chunkSize {
+			id, err = ssClient.UploadChunked(ctx, fileName, chunkSize)
+		} else {
+			id, err = ssClient.Upload(ctx, fileName, nil)
+		}
 		if err != nil {
 			return nil, errors.Wrapf(err, "error uploading file %v", fileName)
 		}
@@ -72,13 +99,6 @@ func UploadArchiveFile(ctx context.Context, client client.Interface, fileName st
 
 		archive.Type = fv1.ArchiveTypeUrl
 		archive.URL = archiveURL
-
-		csum, err := utils.GetFileChecksum(fileName)
-		if err != nil {
-			return nil, errors.Wrapf(err, "calculate checksum for file %v", fileName)
-		}
-
-		archive.Checksum = *csum
 	}
 
 	return &archive, nil
@@ -183,6 +203,20 @@ func DownloadStoragesvcURL(client client.Interface, fileUrl string) (io.ReadClos
 	return reader, nil
 }
 
+// IsStoragesvcURL returns true if the archive URL points to the storage
+// service of the cluster, as opposed to an external URL given by the user.
+func IsStoragesvcURL(client client.Interface, fileUrl string) (bool, error) {
+	u, err := url.Parse(fileUrl)
+	if err != nil {
+		return false, errors.Wrapf(err, "error parsing archive url %v", fileUrl)
+	}
+	storageSvc, err := client.V1().Misc().GetSvcURL("application=fission-storage")
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting fission storage service name")
+	}
+	return u.Host == storageSvc, nil
+}
+
 // PrintPackageSummary prints package information and build logs.
 func PrintPackageSummary(writer io.Writer, pkg *fv1.Package) {
 	// replace escaped line breaker character
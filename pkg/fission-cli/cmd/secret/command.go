@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+// Commands returns secret commands
+func Commands() *cobra.Command {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a secret for functions",
+		Long: `Create a Kubernetes secret that functions can reference with 'fission fn create/update --secret <name>'.
+
+The secret is mounted into the function pod, each key as a file at
+/secrets/<namespace>/<secret name>/<key>, e.g. a function in the default
+namespace reads the key 'password' of the secret 'db-creds' from
+/secrets/default/db-creds/password.`,
+		RunE: wrapper.Wrapper(Create),
+	}
+	wrapper.SetFlags(createCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.SecretName},
+		Optional: []flag.Flag{flag.SecretFromLiteral, flag.SecretFromFile, flag.NamespaceFunction},
+	})
+
+	command := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage secrets used by functions",
+	}
+
+	command.AddCommand(createCmd)
+
+	return command
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type CreateSubCommand struct {
+	cmd.CommandActioner
+	secret *apiv1.Secret
+}
+
+// Create creates a secret from literal values and files.
+func Create(input cli.Input) error {
+	return (&CreateSubCommand{}).do(input)
+}
+
+func (opts *CreateSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CreateSubCommand) complete(input cli.Input) error {
+	literals := input.StringSlice(flagkey.SecretFromLiteral)
+	files := input.StringSlice(flagkey.SecretFromFile)
+	if len(literals) == 0 && len(files) == 0 {
+		return errors.Errorf("need at least one --%v or --%v", flagkey.SecretFromLiteral, flagkey.SecretFromFile)
+	}
+
+	data, err := util.ReadKeyValueData(literals, files)
+	if err != nil {
+		return err
+	}
+
+	opts.secret = &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      input.String(flagkey.SecretName),
+			Namespace: input.String(flagkey.NamespaceFunction),
+			Labels: map[string]string{
+				util.LABEL_MANAGED: "true",
+			},
+		},
+		Type: apiv1.SecretTypeOpaque,
+		Data: data,
+	}
+
+	return nil
+}
+
+func (opts *CreateSubCommand) run(input cli.Input) error {
+	_, kubeClient, err := util.GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		return err
+	}
+
+	_, err = kubeClient.CoreV1().Secrets(opts.secret.ObjectMeta.Namespace).Create(context.Background(), opts.secret, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "error creating secret '%v'", opts.secret.ObjectMeta.Name)
+	}
+
+	fmt.Printf("secret '%v' created, use 'fission fn create --secret %v' to pass it to a function\n",
+		opts.secret.ObjectMeta.Name, opts.secret.ObjectMeta.Name)
+	return nil
+}
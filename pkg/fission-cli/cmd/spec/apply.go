@@ -69,10 +69,16 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 	specIgnore := util.GetSpecIgnore(input)
 
 	deleteResources := input.Bool(flagkey.SpecDelete)
+	pruneResourcesNotInSpecs := input.Bool(flagkey.SpecPrune)
 	watchResources := input.Bool(flagkey.SpecWatch)
 	waitForBuild := input.Bool(flagkey.SpecWait)
 	validateSpecs := util.GetValidationFlag(input)
 
+	// the specs are re-applied on every change, don't prompt each time
+	if pruneResourcesNotInSpecs && watchResources && !input.Bool(flagkey.SpecYes) {
+		return errors.Errorf("--%v with --%v requires --%v", flagkey.SpecPrune, flagkey.SpecWatch, flagkey.SpecYes)
+	}
+
 	var watcher *fsnotify.Watcher
 	var pbw *packageBuildWatcher
 
@@ -134,6 +140,12 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 		if err != nil {
 			return errors.Wrap(err, "error applying specs")
 		}
+		if pruneResourcesNotInSpecs {
+			err = pruneResources(opts.Client(), fr, as, input.Bool(flagkey.SpecYes), os.Stdin)
+			if err != nil {
+				return errors.Wrap(err, "error pruning resources")
+			}
+		}
 		printApplyStatus(as)
 
 		if watchResources || waitForBuild {
@@ -238,48 +250,43 @@ func waitForFileWatcherToSettleDown(watcher *fsnotify.Watcher) error {
 	}
 }
 
-// printApplyStatus prints a summary of what changed on the
-// cluster as the result of a spec apply operation.
+// applyStatusOrder is the order resources are applied and reported in.
+var applyStatusOrder = []string{"environment", "package", "function",
+	"HTTPTrigger", "KubernetesWatchTrigger", "TimeTrigger", "MessageQueueTrigger"}
+
+// printApplyStatus prints what happened to every resource as the
+// result of a spec apply operation (+created, ~updated, =unchanged,
+// -deleted), followed by a summary.
 func printApplyStatus(applyStatus map[string]ResourceApplyStatus) {
-	changed := false
-	for typ, ras := range applyStatus {
-		n := len(ras.Created)
-		if n > 0 {
-			changed = true
-			fmt.Printf("%v %v created: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Created), ", "))
+	var created, updated, unchanged, deleted int
+	for _, typ := range applyStatusOrder {
+		ras, ok := applyStatus[typ]
+		if !ok {
+			continue
+		}
+		for _, m := range ras.Created {
+			fmt.Printf("+ %v %v/%v created\n", typ, m.Namespace, m.Name)
 		}
-		n = len(ras.Updated)
-		if n > 0 {
-			changed = true
-			fmt.Printf("%v %v updated: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Updated), ", "))
+		for _, m := range ras.Updated {
+			fmt.Printf("~ %v %v/%v updated\n", typ, m.Namespace, m.Name)
 		}
-		n = len(ras.Deleted)
-		if n > 0 {
-			changed = true
-			fmt.Printf("%v %v deleted: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Deleted), ", "))
+		for _, m := range ras.Unchanged {
+			fmt.Printf("= %v %v/%v unchanged\n", typ, m.Namespace, m.Name)
 		}
+		for _, m := range ras.Deleted {
+			fmt.Printf("- %v %v/%v deleted\n", typ, m.Namespace, m.Name)
+		}
+		created += len(ras.Created)
+		updated += len(ras.Updated)
+		unchanged += len(ras.Unchanged)
+		deleted += len(ras.Deleted)
 	}
 
-	if !changed {
+	if created+updated+deleted == 0 {
 		fmt.Println("Everything up to date.")
+		return
 	}
-}
-
-// metadataNames extracts a slice of names from a slice of object metadata.
-func metadataNames(ms []*metav1.ObjectMeta) []string {
-	s := make([]string, len(ms))
-	for i, m := range ms {
-		s[i] = m.Name
-	}
-	return s
-}
-
-// pluralize returns the plural of word if num is zero or more than one.
-func pluralize(num int, word string) string {
-	if num == 1 {
-		return word
-	}
-	return word + "s"
+	fmt.Printf("%v created, %v updated, %v unchanged, %v deleted\n", created, updated, unchanged, deleted)
 }
 
 // applyArchives figures out the set of archives that need to be uploaded, and uploads them.
@@ -542,6 +549,7 @@ func applyDeploymentConfig(m *metav1.ObjectMeta, fr *FissionResources) {
 	}
 	m.Annotations[FISSION_DEPLOYMENT_NAME_KEY] = fr.DeploymentConfig.Name
 	m.Annotations[FISSION_DEPLOYMENT_UID_KEY] = fr.DeploymentConfig.UID
+	setSpecManaged(m)
 }
 
 func hasDeploymentConfig(m *metav1.ObjectMeta, fr *FissionResources) bool {
@@ -626,9 +634,10 @@ func applyPackages(fclient client.Interface, fr *FissionResources, delete bool)
 				keep = true
 			}
 
-			if keep && existingObj.Status.BuildStatus == fv1.BuildStatusSucceeded {
+			if keep && existingObj.Status.BuildStatus == fv1.BuildStatusSucceeded && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -726,9 +735,10 @@ func applyFunctions(fclient client.Interface, fr *FissionResources, delete bool)
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -809,9 +819,10 @@ func applyEnvironments(fclient client.Interface, fr *FissionResources, delete bo
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -892,9 +903,10 @@ func applyHTTPTriggers(fclient client.Interface, fr *FissionResources, delete bo
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -975,9 +987,10 @@ func applyKubernetesWatchTriggers(fclient client.Interface, fr *FissionResources
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -1058,9 +1071,10 @@ func applyTimeTriggers(fclient client.Interface, fr *FissionResources, delete bo
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
@@ -1141,9 +1155,10 @@ func applyMessageQueueTriggers(fclient client.Interface, fr *FissionResources, d
 		existingObj, ok := existent[mapKey(&o.ObjectMeta)]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
+			if reflect.DeepEqual(existingObj.Spec, o.Spec) && isSpecManaged(&existingObj.ObjectMeta) {
 				// nothing to do on the server
 				metadataMap[mapKey(&o.ObjectMeta)] = existingObj.ObjectMeta
+				ras.Unchanged = append(ras.Unchanged, &existingObj.ObjectMeta)
 			} else {
 				// update
 				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
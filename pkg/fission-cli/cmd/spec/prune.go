@@ -0,0 +1,263 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/controller/client"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// SPEC_MANAGED_LABEL is set on every resource created or updated by
+// spec apply, so that --prune only considers resources managed by specs.
+const SPEC_MANAGED_LABEL = "fission.io/spec-managed"
+
+type pruneCandidate struct {
+	kind   string
+	meta   metav1.ObjectMeta
+	delete func(m *metav1.ObjectMeta) error
+}
+
+func isSpecManaged(m *metav1.ObjectMeta) bool {
+	return m.Labels[SPEC_MANAGED_LABEL] == "true"
+}
+
+func setSpecManaged(m *metav1.ObjectMeta) {
+	if m.Labels == nil {
+		m.Labels = make(map[string]string)
+	}
+	m.Labels[SPEC_MANAGED_LABEL] = "true"
+}
+
+// CopyUnmanagedMeta copies the labels and annotations of src to dst,
+// except the ones tying src to the spec deployment it was applied from,
+// so that the copy isn't deleted or pruned by spec apply.
+func CopyUnmanagedMeta(dst *metav1.ObjectMeta, src *metav1.ObjectMeta) {
+	for k, v := range src.Labels {
+		if k == SPEC_MANAGED_LABEL {
+			continue
+		}
+		if dst.Labels == nil {
+			dst.Labels = make(map[string]string)
+		}
+		dst.Labels[k] = v
+	}
+	for k, v := range src.Annotations {
+		if k == FISSION_DEPLOYMENT_NAME_KEY || k == FISSION_DEPLOYMENT_UID_KEY {
+			continue
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[k] = v
+	}
+}
+
+// specNamespaces returns the namespaces of the resources in the specs,
+// resources without namespace are in the default namespace.
+func specNamespaces(fr *FissionResources) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	add := func(m *metav1.ObjectMeta) {
+		ns := m.Namespace
+		if len(ns) == 0 {
+			ns = metav1.NamespaceDefault
+		}
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for i := range fr.Packages {
+		add(&fr.Packages[i].ObjectMeta)
+	}
+	for i := range fr.Functions {
+		add(&fr.Functions[i].ObjectMeta)
+	}
+	for i := range fr.Environments {
+		add(&fr.Environments[i].ObjectMeta)
+	}
+	for i := range fr.HttpTriggers {
+		add(&fr.HttpTriggers[i].ObjectMeta)
+	}
+	for i := range fr.KubernetesWatchTriggers {
+		add(&fr.KubernetesWatchTriggers[i].ObjectMeta)
+	}
+	for i := range fr.TimeTriggers {
+		add(&fr.TimeTriggers[i].ObjectMeta)
+	}
+	for i := range fr.MessageQueueTriggers {
+		add(&fr.MessageQueueTriggers[i].ObjectMeta)
+	}
+	return namespaces
+}
+
+// findPruneCandidates returns the resources applied from the same
+// deployment as the specs which are no longer in the specs, triggers
+// first so that nothing is left referencing a deleted resource. Only the
+// namespaces of the specs are searched.
+func findPruneCandidates(fclient client.Interface, fr *FissionResources) ([]pruneCandidate, error) {
+	var candidates []pruneCandidate
+	add := func(kind string, desired map[string]bool, m metav1.ObjectMeta, del func(m *metav1.ObjectMeta) error) {
+		if isSpecManaged(&m) && hasDeploymentConfig(&m, fr) && !desired[mapKey(&m)] {
+			candidates = append(candidates, pruneCandidate{kind: kind, meta: m, delete: del})
+		}
+	}
+	namespaces := specNamespaces(fr)
+
+	desired := make(map[string]bool)
+	for _, o := range fr.HttpTriggers {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		hts, err := fclient.V1().HTTPTrigger().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing HTTP triggers")
+		}
+		for _, o := range hts {
+			add("HTTPTrigger", desired, o.ObjectMeta, fclient.V1().HTTPTrigger().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.KubernetesWatchTriggers {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		kws, err := fclient.V1().KubeWatcher().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing kubernetes watch triggers")
+		}
+		for _, o := range kws {
+			add("KubernetesWatchTrigger", desired, o.ObjectMeta, fclient.V1().KubeWatcher().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.TimeTriggers {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		tts, err := fclient.V1().TimeTrigger().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing time triggers")
+		}
+		for _, o := range tts {
+			add("TimeTrigger", desired, o.ObjectMeta, fclient.V1().TimeTrigger().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.MessageQueueTriggers {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		mqts, err := fclient.V1().MessageQueueTrigger().List("", ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing message queue triggers")
+		}
+		for _, o := range mqts {
+			add("MessageQueueTrigger", desired, o.ObjectMeta, fclient.V1().MessageQueueTrigger().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.Functions {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		fns, err := fclient.V1().Function().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing functions")
+		}
+		for _, o := range fns {
+			add("function", desired, o.ObjectMeta, fclient.V1().Function().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.Packages {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		pkgs, err := fclient.V1().Package().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing packages")
+		}
+		for _, o := range pkgs {
+			add("package", desired, o.ObjectMeta, fclient.V1().Package().Delete)
+		}
+	}
+
+	desired = make(map[string]bool)
+	for _, o := range fr.Environments {
+		desired[mapKey(&o.ObjectMeta)] = true
+	}
+	for _, ns := range namespaces {
+		envs, err := fclient.V1().Environment().List(ns)
+		if err != nil {
+			return nil, errors.Wrap(err, "error listing environments")
+		}
+		for _, o := range envs {
+			add("environment", desired, o.ObjectMeta, fclient.V1().Environment().Delete)
+		}
+	}
+
+	return candidates, nil
+}
+
+// pruneResources deletes the spec managed resources that are no longer
+// in the specs and records them in applyStatus. Unless yes is set, the
+// user is asked to confirm the deletion first.
+func pruneResources(fclient client.Interface, fr *FissionResources, applyStatus map[string]ResourceApplyStatus, yes bool, in io.Reader) error {
+	candidates, err := findPruneCandidates(fclient, fr)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if !yes {
+		fmt.Println("The following resources are not in the specs and will be deleted:")
+		for _, c := range candidates {
+			fmt.Printf("  %v %v/%v\n", c.kind, c.meta.Namespace, c.meta.Name)
+		}
+		if !util.Confirm(in, fmt.Sprintf("Delete %v resources?", len(candidates))) {
+			fmt.Println("Skipped pruning.")
+			return nil
+		}
+	}
+
+	for i := range candidates {
+		c := candidates[i]
+		err := c.delete(&c.meta)
+		if err != nil {
+			return errors.Wrapf(err, "error deleting %v %v/%v", c.kind, c.meta.Namespace, c.meta.Name)
+		}
+		ras := applyStatus[c.kind]
+		ras.Deleted = append(ras.Deleted, &c.meta)
+		applyStatus[c.kind] = ras
+	}
+
+	return nil
+}
@@ -105,9 +105,10 @@ type (
 	}
 
 	ResourceApplyStatus struct {
-		Created []*metav1.ObjectMeta
-		Updated []*metav1.ObjectMeta
-		Deleted []*metav1.ObjectMeta
+		Created   []*metav1.ObjectMeta
+		Updated   []*metav1.ObjectMeta
+		Unchanged []*metav1.ObjectMeta
+		Deleted   []*metav1.ObjectMeta
 	}
 
 	Location struct {
@@ -205,6 +206,13 @@ func SpecDry(resource interface{}) error {
 	return nil
 }
 
+// SpecToYaml returns the YAML representation of a fission resource
+// with its TypeMeta populated.
+func SpecToYaml(resource interface{}) ([]byte, error) {
+	_, _, data, err := crdToYaml(resource)
+	return data, err
+}
+
 func crdToYaml(resource interface{}) (metav1.ObjectMeta, string, []byte, error) {
 	// make sure we're writing a known type
 	var meta metav1.ObjectMeta
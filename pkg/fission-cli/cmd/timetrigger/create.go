@@ -75,6 +75,11 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		return errors.New("Need a cron spec like '0 30 * * * *', '@every 1h30m', or '@hourly'; use --cron")
 	}
 
+	err := checkCronSpec(cronSpec)
+	if err != nil {
+		return err
+	}
+
 	if input.Bool(flagkey.SpecSave) {
 		specDir := util.GetSpecDir(input)
 		specIgnore := util.GetSpecIgnore(input)
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timetrigger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+
+	"github.com/fission/fission/pkg/fission-cli/console"
+)
+
+var weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// validateCronSpec checks the cron spec with the same parser the
+// timer uses. Note that fission cron specs start with a seconds field:
+// "second minute hour day-of-month month [day-of-week]".
+func validateCronSpec(cronSpec string) error {
+	_, err := cron.Parse(cronSpec)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cron spec '%v', expected 'second minute hour day-of-month month [day-of-week]' (e.g. '0 */5 * * * *'), '@every 1h30m' or '@hourly'", cronSpec)
+	}
+	return nil
+}
+
+// checkCronSpec validates the cron spec and prints how it is going to
+// be interpreted, so that a spec written for a scheduler without a
+// seconds field is noticed before the trigger is saved.
+func checkCronSpec(cronSpec string) error {
+	err := validateCronSpec(cronSpec)
+	if err != nil {
+		return err
+	}
+	if len(strings.Fields(cronSpec)) == 5 {
+		console.Warn(fmt.Sprintf("Cron spec '%v' has 5 fields; the first field is seconds, not minutes. Use 6 fields like '0 */5 * * * *' if you meant minutes.", cronSpec))
+	}
+	fmt.Printf("Cron spec '%v' %v\n", cronSpec, describeCronSpec(cronSpec))
+	return nil
+}
+
+// describeCronSpec returns a human readable interpretation of a valid
+// cron spec, e.g. "runs every 5 minutes".
+func describeCronSpec(cronSpec string) string {
+	spec := strings.TrimSpace(cronSpec)
+
+	switch spec {
+	case "@yearly", "@annually":
+		return "runs once a year, at midnight on January 1st"
+	case "@monthly":
+		return "runs once a month, at midnight on the first day of the month"
+	case "@weekly":
+		return "runs once a week, at midnight on Sunday"
+	case "@daily", "@midnight":
+		return "runs once a day, at midnight"
+	case "@hourly":
+		return "runs once an hour, at the beginning of the hour"
+	}
+	if strings.HasPrefix(spec, "@every ") {
+		return fmt.Sprintf("runs every %v", strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 5 {
+		fields = append(fields, "*")
+	}
+	if len(fields) != 6 {
+		return fmt.Sprintf("runs on schedule '%v'", spec)
+	}
+	sec, min, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+	anyDay := isWildcard(dom) && isWildcard(month) && isWildcard(dow)
+
+	if anyDay {
+		switch {
+		case isWildcard(sec) && isWildcard(min) && isWildcard(hour):
+			return "runs every second"
+		case isStep(sec) && isWildcard(min) && isWildcard(hour):
+			return fmt.Sprintf("runs every %v seconds", stepOf(sec))
+		case isNumber(sec) && isWildcard(min) && isWildcard(hour):
+			return fmt.Sprintf("runs every minute at second %v", sec)
+		case isNumber(sec) && isStep(min) && isWildcard(hour):
+			return fmt.Sprintf("runs every %v minutes", stepOf(min))
+		case isNumber(sec) && isNumber(min) && isWildcard(hour):
+			return fmt.Sprintf("runs every hour at minute %v", min)
+		case isNumber(sec) && isNumber(min) && isStep(hour):
+			return fmt.Sprintf("runs every %v hours at minute %v", stepOf(hour), min)
+		case isNumber(sec) && isNumber(min) && isNumber(hour):
+			return fmt.Sprintf("runs every day at %v", clock(hour, min, sec))
+		}
+	}
+
+	if isNumber(sec) && isNumber(min) && isNumber(hour) && isWildcard(month) {
+		switch {
+		case isWildcard(dom) && !isWildcard(dow):
+			return fmt.Sprintf("runs every %v at %v", describeWeekdays(dow), clock(hour, min, sec))
+		case isNumber(dom) && isWildcard(dow):
+			return fmt.Sprintf("runs on day %v of every month at %v", dom, clock(hour, min, sec))
+		}
+	}
+
+	return fmt.Sprintf("runs at second %v, minute %v, hour %v, day-of-month %v, month %v, day-of-week %v",
+		sec, min, hour, dom, month, dow)
+}
+
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+func isNumber(field string) bool {
+	_, err := strconv.Atoi(field)
+	return err == nil
+}
+
+func isStep(field string) bool {
+	return strings.HasPrefix(field, "*/") && isNumber(stepOf(field))
+}
+
+func stepOf(field string) string {
+	return strings.TrimPrefix(field, "*/")
+}
+
+func clock(hour, min, sec string) string {
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(min)
+	s, _ := strconv.Atoi(sec)
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
+
+// describeWeekdays turns a day-of-week field like "1-5" or "MON,WED"
+// into weekday names where possible.
+func describeWeekdays(dow string) string {
+	if dow == "1-5" || strings.EqualFold(dow, "MON-FRI") {
+		return "weekday (Monday to Friday)"
+	}
+	var names []string
+	for _, d := range strings.Split(dow, ",") {
+		if i, err := strconv.Atoi(d); err == nil && i >= 0 && i < len(weekdays) {
+			names = append(names, weekdays[i])
+			continue
+		}
+		found := false
+		for _, w := range weekdays {
+			if strings.EqualFold(d, w[:3]) {
+				names = append(names, w)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("day-of-week %v", dow)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timetrigger
+
+import "testing"
+
+func TestDescribeCronSpec(t *testing.T) {
+	for _, test := range []struct {
+		spec     string
+		valid    bool
+		expected string
+	}{
+		{"@every 5m", true, "runs every 5m"},
+		{"@hourly", true, "runs once an hour, at the beginning of the hour"},
+		{"0 */5 * * * *", true, "runs every 5 minutes"},
+		{"*/10 * * * * *", true, "runs every 10 seconds"},
+		{"0 30 * * * *", true, "runs every hour at minute 30"},
+		{"0 0 9 * * *", true, "runs every day at 09:00:00"},
+		{"0 0 9 * * 1-5", true, "runs every weekday (Monday to Friday) at 09:00:00"},
+		{"0 0 9 * * MON,WED", true, "runs every Monday, Wednesday at 09:00:00"},
+		{"0 0 6 1 * *", true, "runs on day 1 of every month at 06:00:00"},
+		{"0 0 0 * * * *", false, ""},
+		{"61 * * * * *", false, ""},
+		{"@sometimes", false, ""},
+	} {
+		err := validateCronSpec(test.spec)
+		if (err == nil) != test.valid {
+			t.Errorf("validateCronSpec(%q): expected valid %v, got error %v", test.spec, test.valid, err)
+			continue
+		}
+		if !test.valid {
+			continue
+		}
+		if got := describeCronSpec(test.spec); got != test.expected {
+			t.Errorf("describeCronSpec(%q) = %q, expected %q", test.spec, got, test.expected)
+		}
+	}
+}
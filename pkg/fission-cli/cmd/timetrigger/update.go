@@ -57,6 +57,10 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 	updated := false
 	newCron := input.String("cron")
 	if len(newCron) != 0 {
+		err = checkCronSpec(newCron)
+		if err != nil {
+			return err
+		}
 		tt.Spec.Cron = newCron
 		updated = true
 	}
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+// Commands returns commands working across all trigger types
+func Commands() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{},
+		Short:   "List the triggers of all types invoking a function",
+		Long:    "List the HTTP, time, message queue and kube watch triggers invoking a function",
+		RunE:    wrapper.Wrapper(List),
+	}
+	wrapper.SetFlags(listCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.TriggerFnName},
+		Optional: []flag.Flag{flag.NamespaceTrigger, flag.TriggerOutput},
+	})
+
+	command := &cobra.Command{
+		Use:   "trigger",
+		Short: "Inspect triggers of all types",
+	}
+
+	command.AddCommand(listCmd)
+
+	return command
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	typeHTTP      = "http"
+	typeTime      = "time"
+	typeMQ        = "mq"
+	typeKubeWatch = "watch"
+)
+
+// Summary is the type independent view of a trigger.
+type Summary struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Function  string `json:"function"`
+	Details   string `json:"details"`
+}
+
+type ListSubCommand struct {
+	cmd.CommandActioner
+	fnName    string
+	namespace string
+	output    *util.OutputFormatter
+}
+
+// List prints the triggers of all types that invoke a function.
+func List(input cli.Input) error {
+	return (&ListSubCommand{}).do(input)
+}
+
+func (opts *ListSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *ListSubCommand) complete(input cli.Input) (err error) {
+	opts.fnName = input.String(flagkey.TriggerFnName)
+	opts.namespace = input.String(flagkey.NamespaceTrigger)
+	opts.output, err = util.NewOutputFormatter(input.String(flagkey.TriggerOutput))
+	return err
+}
+
+func (opts *ListSubCommand) run(input cli.Input) error {
+	summaries := make([]Summary, 0)
+
+	hts, err := opts.Client().V1().HTTPTrigger().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing HTTP triggers")
+	}
+	for _, ht := range hts {
+		if !invokes(ht.Spec.FunctionReference, opts.fnName) {
+			continue
+		}
+		path := ht.Spec.RelativeURL
+		if ht.Spec.Prefix != nil && len(*ht.Spec.Prefix) > 0 {
+			path = *ht.Spec.Prefix
+		}
+		methods := ht.Spec.Methods
+		if len(methods) == 0 && len(ht.Spec.Method) > 0 {
+			methods = []string{ht.Spec.Method}
+		}
+		summaries = append(summaries, summary(typeHTTP, ht.ObjectMeta.Name, ht.ObjectMeta.Namespace,
+			ht.Spec.FunctionReference, fmt.Sprintf("%v %v", strings.Join(methods, ","), path)))
+	}
+
+	tts, err := opts.Client().V1().TimeTrigger().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing time triggers")
+	}
+	for _, tt := range tts {
+		if !invokes(tt.Spec.FunctionReference, opts.fnName) {
+			continue
+		}
+		summaries = append(summaries, summary(typeTime, tt.ObjectMeta.Name, tt.ObjectMeta.Namespace,
+			tt.Spec.FunctionReference, fmt.Sprintf("cron: %v", tt.Spec.Cron)))
+	}
+
+	// without a queue type the controller returns the triggers of all namespaces
+	mqts, err := opts.Client().V1().MessageQueueTrigger().List("", opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing message queue triggers")
+	}
+	for _, mqt := range mqts {
+		if mqt.ObjectMeta.Namespace != opts.namespace || !invokes(mqt.Spec.FunctionReference, opts.fnName) {
+			continue
+		}
+		summaries = append(summaries, summary(typeMQ, mqt.ObjectMeta.Name, mqt.ObjectMeta.Namespace,
+			mqt.Spec.FunctionReference, fmt.Sprintf("%v topic: %v", mqt.Spec.MessageQueueType, mqt.Spec.Topic)))
+	}
+
+	kws, err := opts.Client().V1().KubeWatcher().List(opts.namespace)
+	if err != nil {
+		return errors.Wrap(err, "error listing kube watchers")
+	}
+	for _, kw := range kws {
+		if !invokes(kw.Spec.FunctionReference, opts.fnName) {
+			continue
+		}
+		summaries = append(summaries, summary(typeKubeWatch, kw.ObjectMeta.Name, kw.ObjectMeta.Namespace,
+			kw.Spec.FunctionReference, fmt.Sprintf("%v in namespace %v", kw.Spec.Type, kw.Spec.Namespace)))
+	}
+
+	if opts.output.IsStructured() {
+		return opts.output.Print(summaries)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "TYPE", "NAME", "NAMESPACE", "FUNCTION(s)", "DETAILS")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", s.Type, s.Name, s.Namespace, s.Function, s.Details)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// invokes returns true if the function reference points at the
+// function, either by name or as one of the weighted functions of a
+// canary deployment.
+func invokes(ref fv1.FunctionReference, fnName string) bool {
+	if ref.Type == fv1.FunctionReferenceTypeFunctionWeights {
+		_, ok := ref.FunctionWeights[fnName]
+		return ok
+	}
+	return ref.Name == fnName
+}
+
+func summary(triggerType, name, namespace string, ref fv1.FunctionReference, details string) Summary {
+	function := ref.Name
+	if ref.Type == fv1.FunctionReferenceTypeFunctionWeights {
+		var weights []string
+		for fn, weight := range ref.FunctionWeights {
+			weights = append(weights, fmt.Sprintf("%v:%v", fn, weight))
+		}
+		sort.Strings(weights)
+		function = strings.Join(weights, " ")
+	}
+	return Summary{
+		Type:      triggerType,
+		Name:      name,
+		Namespace: namespace,
+		Function:  function,
+		Details:   details,
+	}
+}
@@ -71,6 +71,8 @@ const (
 var (
 	GlobalVerbosity = Flag{Type: Int, Name: flagkey.Verbosity, Short: "v", Usage: "CLI verbosity (0 is quiet, 1 is the default, 2 is verbose)", DefaultValue: 1}
 	GlobalServer    = Flag{Type: String, Name: flagkey.Server, Usage: "Server URL"}
+	GlobalRouterURL = Flag{Type: String, Name: flagkey.RouterURL, Usage: "External URL of the router used to show function URLs, saved in ~/.fission/config.yaml for later commands"}
+	GlobalNamespace = Flag{Type: String, Name: flagkey.Namespace, Short: "n", Usage: fmt.Sprintf("Namespace of the objects if no object specific namespace flag is given (overrides $%v, $FISSION_NAMESPACE is the namespace Fission is installed in)", util.ENV_DEFAULT_NAMESPACE)}
 
 	ClientOnly = Flag{Type: Bool, Name: flagkey.ClientOnly, Usage: "If set, the CLI won't connect to remote server"}
 
@@ -85,11 +87,13 @@ var (
 	NamespaceTrigger     = Flag{Type: String, Name: flagkey.NamespaceTrigger, Aliases: []string{"triggerns"}, Usage: "Namespace for trigger object", DefaultValue: metav1.NamespaceDefault}
 	NamespaceCanary      = Flag{Type: String, Name: flagkey.NamespaceCanary, Aliases: []string{"canaryns"}, Usage: "Namespace for canary config object", DefaultValue: metav1.NamespaceDefault}
 
-	RunTimeMinCPU    = Flag{Type: Int, Name: flagkey.RuntimeMincpu, Usage: "Minimum CPU to be assigned to pod (In millicore, minimum 1)"}
-	RunTimeMaxCPU    = Flag{Type: Int, Name: flagkey.RuntimeMaxcpu, Usage: "Maximum CPU to be assigned to pod (In millicore, minimum 1)"}
+	RunTimeMinCPU    = Flag{Type: Int, Name: flagkey.RuntimeMincpu, Usage: "Minimum CPU to be assigned to pod (In millicore, minimum 1)", Deprecated: true, Substitute: flagkey.RuntimeRequest}
+	RunTimeMaxCPU    = Flag{Type: Int, Name: flagkey.RuntimeMaxcpu, Usage: "Maximum CPU to be assigned to pod (In millicore, minimum 1)", Deprecated: true, Substitute: flagkey.RuntimeLimit}
 	RunTimeTargetCPU = Flag{Type: Int, Name: flagkey.RuntimeTargetcpu, Usage: "Target average CPU usage percentage across pods for scaling", DefaultValue: 80}
-	RunTimeMinMemory = Flag{Type: Int, Name: flagkey.RuntimeMinmemory, Usage: "Minimum memory to be assigned to pod (In megabyte)"}
-	RunTimeMaxMemory = Flag{Type: Int, Name: flagkey.RuntimeMaxmemory, Usage: "Maximum memory to be assigned to pod (In megabyte)"}
+	RunTimeMinMemory = Flag{Type: Int, Name: flagkey.RuntimeMinmemory, Usage: "Minimum memory to be assigned to pod (In megabyte)", Deprecated: true, Substitute: flagkey.RuntimeRequest}
+	RunTimeMaxMemory = Flag{Type: Int, Name: flagkey.RuntimeMaxmemory, Usage: "Maximum memory to be assigned to pod (In megabyte)", Deprecated: true, Substitute: flagkey.RuntimeLimit}
+	RunTimeRequest   = Flag{Type: String, Name: flagkey.RuntimeRequest, Aliases: []string{"request"}, Usage: "CPU and memory requested for the pod in Kubernetes resource syntax, ex: cpu=100m,memory=64Mi"}
+	RunTimeLimit     = Flag{Type: String, Name: flagkey.RuntimeLimit, Aliases: []string{"limit"}, Usage: "CPU and memory limit of the pod in Kubernetes resource syntax, ex: cpu=500m,memory=256Mi"}
 
 	ReplicasMin = Flag{Type: Int, Name: flagkey.ReplicasMinscale, Usage: "Minimum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
 	ReplicasMax = Flag{Type: Int, Name: flagkey.ReplicasMaxscale, Usage: "Maximum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
@@ -107,36 +111,114 @@ var (
 	FnSecret                = Flag{Type: StringSlice, Name: flagkey.FnSecret, Usage: "Function access to secret, should be present in the same namespace as the function. You can provide multiple secrets using multiple --secrets flags. In the case of fn update the secrets will be replaced by the provided list of secrets."}
 	FnCfgMap                = Flag{Type: StringSlice, Name: flagkey.FnCfgMap, Usage: "Function access to configmap, should be present in the same namespace as the function. You can provide multiple configmaps using multiple --configmap flags. In case of fn update the configmaps will be replaced by the provided list of configmaps."}
 	FnExecutorType          = Flag{Type: String, Name: flagkey.FnExecutorType, Usage: "Executor type for execution; one of 'poolmgr', 'newdeploy'", DefaultValue: string(fv1.ExecutorTypePoolmgr)}
-	FnExecutionTimeout      = Flag{Type: Int, Name: flagkey.FnExecutionTimeout, Aliases: []string{"ft"}, Usage: "Maximum time for a request to wait for the response from the function", DefaultValue: 60}
+	FnExecutionTimeout      = Flag{Type: Int, Name: flagkey.FnExecutionTimeout, Aliases: []string{"ft", "fn-timeout"}, Usage: "Maximum time in seconds for a request to wait for the response from the function, the router returns 504 Gateway Timeout once it expires", DefaultValue: 60}
 	FnLogPod                = Flag{Type: String, Name: flagkey.FnLogPod, Usage: "Function pod name (use the latest pod name if unspecified)"}
 	FnLogFollow             = Flag{Type: Bool, Name: flagkey.FnLogFollow, Short: "f", Usage: "Specify if the logs should be streamed"}
 	FnLogDetail             = Flag{Type: Bool, Name: flagkey.FnLogDetail, Short: "d", Usage: "Display detailed information"}
 	FnLogDBType             = Flag{Type: String, Name: flagkey.FnLogDBType, Usage: "Log database type, e.g. influxdb (currently only influxdb is supported)", DefaultValue: "influxdb"}
 	FnLogReverseQuery       = Flag{Type: Bool, Name: flagkey.FnLogReverseQuery, Short: "r", Usage: "Specify the log reverse query base on time, it will be invalid if the 'follow' flag is specified"}
 	FnLogCount              = Flag{Type: Int, Name: flagkey.FnLogCount, Usage: "Get N most recent log records", DefaultValue: 20}
+	FnLogInvocationID       = Flag{Type: String, Name: flagkey.FnLogInvocationID, Usage: "Only show log lines containing the invocation ID"}
+	FnLogLevel              = Flag{Type: String, Name: flagkey.FnLogLevel, Usage: "Only show log lines of this level or above, one of debug|info|warn|error"}
+	FnLogSince              = Flag{Type: String, Name: flagkey.FnLogSince, Usage: "Only show logs newer than a relative duration like 5m, or an RFC3339 timestamp"}
+	FnLogUntil              = Flag{Type: String, Name: flagkey.FnLogUntil, Usage: "Only show logs older than a relative duration like 1m, or an RFC3339 timestamp"}
 	FnTestBody              = Flag{Type: String, Name: flagkey.FnTestBody, Short: "b", Usage: "Request body"}
+	FnTestBodyFile          = Flag{Type: String, Name: flagkey.FnTestBodyFile, Usage: "File to read the request body from, the Content-Type is detected from the file extension"}
+	FnTestContentType       = Flag{Type: String, Name: flagkey.FnTestContentType, Usage: "Content-Type of the request body, overrides the type detected for --body-file"}
+	FnTestIgnoreError       = Flag{Type: Bool, Name: flagkey.FnTestIgnoreError, Usage: "Exit with status 0 even if the function returns an error; otherwise 4xx responses exit with 2, 5xx with 3 and other non-2xx with 1"}
 	FnTestTimeout           = Flag{Type: Duration, Name: flagkey.FnTestTimeout, Short: "t", Usage: "Length of time to wait for the response. If set to zero or negative number, no timeout is set", DefaultValue: 60 * time.Second}
-	FnTestHeader            = Flag{Type: StringSlice, Name: flagkey.FnTestHeader, Short: "H", Usage: "Request headers"}
+	FnTestHeader            = Flag{Type: StringSlice, Name: flagkey.FnTestHeader, Short: "H", Usage: "Request header of the form 'Key: value', like curl -H. Can be given multiple times, ex: -H 'Authorization: Bearer <token>' -H 'X-Request-Id: 42'"}
 	FnTestQuery             = Flag{Type: StringSlice, Name: flagkey.FnTestQuery, Short: "q", Usage: "Request query parameters: -q key1=value1 -q key2=value2"}
+	FnTestStream            = Flag{Type: Bool, Name: flagkey.FnTestStream, Usage: "Stream the response body to stdout as it arrives instead of waiting for the function to finish; the stream is closed when --timeout expires"}
+	FnTestWebSocket         = Flag{Type: Bool, Name: flagkey.FnTestWebSocket, Usage: "Connect to the function over WebSocket, send --body as the first message and print every message received with a timestamp until the connection is closed or --timeout expires"}
+	FnTestRouterNamespace   = Flag{Type: String, Name: flagkey.FnTestRouterNamespace, Usage: "Namespace of the Fission install whose router serves the function, for clusters with one install per tenant. Defaults to the FISSION_NAMESPACE environment variable"}
+	FnTestTiming            = Flag{Type: Bool, Name: flagkey.FnTestTiming, Usage: "Print the total time of the request to stderr, split into the cold start reported by the router and the execution time"}
 	FnIdleTimeout           = Flag{Type: Int, Name: flagkey.FnIdleTimeout, Usage: "The length of time (in seconds) that a function is idle before pod(s) are eligible for recycling", DefaultValue: 120}
 	FnConcurrency           = Flag{Type: Int, Name: flagkey.FnConcurrency, Aliases: []string{"con"}, Usage: "Maximum number of pods specialized concurrently to serve requests", DefaultValue: 500}
 	FnRequestsPerPod        = Flag{Type: Int, Name: flagkey.FnRequestsPerPod, Aliases: []string{"rpp"}, Usage: "Maximum number of concurrent requests that can be served by a specialized pod", DefaultValue: 1}
 	FnOnceOnly              = Flag{Type: Bool, Name: flagkey.FnOnceOnly, Aliases: []string{"yolo"}, Usage: "Specifies if specialized pod will serve exactly one request in its lifetime"}
 	FnSubPath               = Flag{Type: String, Name: flagkey.FnSubPath, Usage: "Sub Path to check if function internally supports routing"}
+	FnListOutput            = Flag{Type: String, Name: flagkey.FnListOutput, Short: "o", Usage: "Output format, one of: table|wide|json|yaml", DefaultValue: util.OutputFormatTable}
+	FnListLabelSelector     = Flag{Type: String, Name: flagkey.FnListLabelSelector, Short: "l", Usage: "Only list functions whose labels match the selector, e.g. 'team=payments,tier!=canary'"}
+	FnListSortBy            = Flag{Type: String, Name: flagkey.FnListSortBy, Usage: "Sort functions by one of: name|age|invocations. age lists the newest functions first, invocations the least called ones first"}
+	FnListReverse           = Flag{Type: Bool, Name: flagkey.FnListReverse, Usage: "Sort functions in descending order"}
+	FnListPrometheus        = Flag{Type: String, Name: flagkey.FnListPrometheus, Usage: "URL of the Prometheus server used to sort by invocations, defaults to the FISSION_PROMETHEUS_URL environment variable or the CLI config"}
+	FnExportOutput          = Flag{Type: String, Name: flagkey.FnExportOutput, Short: "o", Usage: "Directory to write the Helm chart to (defaults to <function name>-chart)"}
+	FnRevision              = Flag{Type: Int, Name: flagkey.FnRevision, Usage: "Revision to roll back to, see 'fission fn history'"}
+	FnRevisionHistoryLimit  = Flag{Type: Int, Name: flagkey.FnRevisionHistoryLimit, Usage: "Number of previous packages to keep as revisions of the function for rollback", DefaultValue: 5}
+	FnScaleMin              = Flag{Type: Int, Name: flagkey.FnScaleMin, Usage: "Minimum number of pods of the function"}
+	FnScaleMax              = Flag{Type: Int, Name: flagkey.FnScaleMax, Usage: "Maximum number of pods of the function"}
+	FnCanaryNewVersion      = Flag{Type: String, Name: flagkey.FnCanaryNewVersion, Usage: "Function to shift the traffic to"}
+	FnCanaryWeight          = Flag{Type: Int, Name: flagkey.FnCanaryWeight, Usage: "Percentage of the traffic sent to the new version", DefaultValue: 10}
+	FnCanaryIncrement       = Flag{Type: Int, Name: flagkey.FnCanaryIncrement, Usage: "Percentage added to the new version every interval, 0 keeps the weights fixed"}
+	FnCanaryInterval        = Flag{Type: String, Name: flagkey.FnCanaryInterval, Usage: "Interval between weight increments, string representation of time.Duration, ex : 1m, 2h", DefaultValue: "1m"}
+	FnCanaryMaxErrorRate    = Flag{Type: Int, Name: flagkey.FnCanaryMaxErrorRate, Usage: "Error rate in percentage of the new version beyond which the traffic is rolled back", DefaultValue: 10}
+	FnCanaryTrigger         = Flag{Type: String, Name: flagkey.FnCanaryTrigger, Usage: "HTTP trigger to split, required if the function is referenced by more than one HTTP trigger"}
+	FnSpecFile              = Flag{Type: String, Name: flagkey.FnSpecFile, Usage: "YAML file with the function spec, flags given on the command line take precedence (see 'fission fn spec-example')"}
+	FnCopyFrom              = Flag{Type: String, Name: flagkey.FnCopyFrom, Usage: "Function to copy"}
+	FnCopyTo                = Flag{Type: String, Name: flagkey.FnCopyTo, Usage: "Name of the new function"}
+	FnCopyTriggerFrom       = Flag{Type: String, Name: flagkey.FnCopyTriggerFrom, Usage: "Function whose triggers are copied"}
+	FnCopyTriggerTo         = Flag{Type: String, Name: flagkey.FnCopyTriggerTo, Usage: "Function the copied triggers invoke"}
+	FnCopyTriggerSwap       = Flag{Type: Bool, Name: flagkey.FnCopyTriggerSwap, Usage: "Delete the triggers of the source function after copying them, and point its HTTP triggers at the target function"}
+	FnBenchmarkRPS          = Flag{Type: Int, Name: flagkey.FnBenchmarkRPS, Usage: "Number of requests sent to the function per second", DefaultValue: 10}
+	FnBenchmarkDuration     = Flag{Type: Duration, Name: flagkey.FnBenchmarkDuration, Short: "d", Usage: "Length of time to send requests for, ex: 30s, 5m", DefaultValue: 30 * time.Second}
+	FnBenchmarkOutput       = Flag{Type: String, Name: flagkey.FnBenchmarkOutput, Short: "o", Usage: "Output format of the summary, one of: table|json|yaml", DefaultValue: util.OutputFormatTable}
+	FnTraceBackend          = Flag{Type: String, Name: flagkey.FnTraceBackend, Usage: "URL of the Jaeger or Tempo query API, defaults to the FISSION_TRACE_BACKEND environment variable"}
+	FnArchiveOutput         = Flag{Type: String, Name: flagkey.FnArchiveOutput, Short: "o", Usage: "File to save the archive to, or the directory to extract it into with --unzip (defaults to <function name>.zip or <function name>)"}
+	FnArchiveUnzip          = Flag{Type: Bool, Name: flagkey.FnArchiveUnzip, Usage: "Extract the archive into a directory"}
+	FnDiffLocal             = Flag{Type: String, Name: flagkey.FnDiffLocal, Usage: "Local file to compare with the file of the same name in the deployment archive"}
+	FnDiffExitCode          = Flag{Type: Bool, Name: flagkey.FnDiffExitCode, Usage: "Exit with status 1 if the files differ"}
+	FnColdStartSamples      = Flag{Type: Int, Name: flagkey.FnColdStartSamples, Usage: "Number of cold starts to measure", DefaultValue: 5}
+	FnRateLimitRPS          = Flag{Type: Int, Name: flagkey.FnRateLimitRPS, Usage: "Maximum number of requests per second each router replica forwards to the function, 0 removes the limit"}
+	FnWait                  = Flag{Type: Bool, Name: flagkey.FnWait, Usage: "Wait until a pod serving the function is running, print the pod events if it doesn't start in time"}
+	FnWaitTimeout           = Flag{Type: Duration, Name: flagkey.FnWaitTimeout, Usage: "Length of time to wait for the function with --wait, ex: 30s, 5m", DefaultValue: 2 * time.Minute}
+	FnAccessLogFormat       = Flag{Type: String, Name: flagkey.FnAccessLogFormat, Usage: "Output format of the access log entries, one of: text|json", DefaultValue: "text"}
+	FnEventFile             = Flag{Type: String, Name: flagkey.FnEventFile, Usage: "JSON file with a Kubernetes watch event of the form {\"type\": \"ADDED\", \"object\": {...}}"}
+	FnImportFile            = Flag{Type: String, Name: flagkey.FnImportFile, Short: "f", Usage: "Function manifest to import, ex: the output of 'kubectl get function <name> -o yaml'"}
+	FnImportPackageFile     = Flag{Type: String, Name: flagkey.FnImportPackageFile, Usage: "Deployment archive to create the package of the function from, if the package doesn't exist"}
+	FnAnnotationKey         = Flag{Type: String, Name: flagkey.FnAnnotationKey, Usage: "Annotation key, ex: team"}
+	FnAnnotationValue       = Flag{Type: String, Name: flagkey.FnAnnotationValue, Usage: "Annotation value, ex: backend"}
+	FnDeleteWithTriggers    = Flag{Type: Bool, Name: flagkey.FnDeleteWithTriggers, Usage: "Delete the HTTP, time, message queue and kubernetes watch triggers referencing the function too"}
+	FnEnvVarKey             = Flag{Type: String, Name: flagkey.FnEnvVarKey, Usage: "Name of the environment variable, ex: DB_URL"}
+	FnEnvVarValue           = Flag{Type: String, Name: flagkey.FnEnvVarValue, Usage: "Value of the environment variable"}
+	FnAsyncJob              = Flag{Type: String, Name: flagkey.FnAsyncJob, Usage: "ID of the job returned by 'fission fn invoke-async'"}
+	FnDebugPort             = Flag{Type: Int, Name: flagkey.FnDebugPort, Usage: "Port Delve listens on in the function pod, forwarded to the same local port", DefaultValue: 2345}
+	FnDebugExec             = Flag{Type: String, Name: flagkey.FnDebugExec, Usage: "Path of the binary to debug inside the image, defaults to the command of the function container"}
+	FnProfileDuration       = Flag{Type: Duration, Name: flagkey.FnProfileDuration, Short: "d", Usage: "Length of time to collect the CPU profile for, ex: 30s, 2m", DefaultValue: 30 * time.Second}
+	FnProfileOutput         = Flag{Type: String, Name: flagkey.FnProfileOutput, Short: "o", Usage: "File to save the profile to (defaults to <function name>-cpu.pprof)"}
+	FnProfileInteractive    = Flag{Type: Bool, Name: flagkey.FnProfileInteractive, Usage: "Open the profile with 'go tool pprof' once it is collected"}
+	FnWatchDir              = Flag{Type: String, Name: flagkey.FnWatchDir, Usage: "Directory to watch and deploy the contents of", DefaultValue: "."}
+	FnWatchFilter           = Flag{Type: StringSlice, Name: flagkey.FnWatchFilter, Usage: "Glob pattern of files to ignore, matched against the file name and its path in the directory, ex: --filter '*.test.js' --filter 'node_modules'"}
+	FnMaxConcurrency        = Flag{Type: Int, Name: flagkey.FnMaxConcurrency, Usage: "Maximum number of requests a specialized pod serves at the same time, further requests get a new pod (poolmgr functions only)"}
+	FnGrepPattern           = Flag{Type: String, Name: flagkey.FnGrepPattern, Short: "e", Usage: "Regular expression to search for, in Go regexp syntax"}
+	FnGrepEnv               = Flag{Type: String, Name: flagkey.FnGrepEnv, Usage: "Only search functions using this environment"}
+	FnConcurrencyModel      = Flag{Type: String, Name: flagkey.FnConcurrencyModel, Usage: "How the environment runs concurrent invocations, one of: goroutine, process; must be supported by the environment, empty uses the environment default"}
+	FnExportMetricsOutput   = Flag{Type: String, Name: flagkey.FnExportMetricsOutput, Short: "o", Usage: "Output format, only prometheus (a Prometheus recording rules file) is supported", DefaultValue: "prometheus"}
+	FnCloneTargetServer     = Flag{Type: String, Name: flagkey.FnCloneTargetServer, Usage: "URL of the Fission controller of the cluster to clone the function to"}
+	FnCloneWithTriggers     = Flag{Type: Bool, Name: flagkey.FnCloneWithTriggers, Usage: "Clone the HTTP, time, message queue and kubernetes watch triggers invoking the function too"}
+	FnUsageSince            = Flag{Type: String, Name: flagkey.FnUsageSince, Usage: "Length of the period to report, ex: 12h, 7d", DefaultValue: "7d"}
+	FnUsageCompare          = Flag{Type: String, Name: flagkey.FnUsageCompare, Usage: "Also report the period of the same length this long before, ex: --since 7d --compare 7d compares with the week before"}
+	FnUsagePrometheus       = Flag{Type: String, Name: flagkey.FnUsagePrometheus, Usage: "URL of the Prometheus server, defaults to the FISSION_PROMETHEUS_URL environment variable or the CLI config"}
+	FnLambdaRegion          = Flag{Type: String, Name: flagkey.FnLambdaRegion, Usage: "AWS region of the Lambda function, ex: us-east-1"}
+	FnLambdaFunctionName    = Flag{Type: String, Name: flagkey.FnLambdaFunctionName, Usage: "Name or ARN of the Lambda function to import"}
+	FnTopInterval           = Flag{Type: Duration, Name: flagkey.FnTopInterval, Usage: "Time between refreshes, ex: 2s, 1m", DefaultValue: 2 * time.Second}
 
 	HtName              = Flag{Type: String, Name: flagkey.HtName, Usage: "HTTP trigger name"}
-	HtMethod            = Flag{Type: StringSlice, Name: flagkey.HtMethod, Usage: "HTTP Methods: GET,POST,PUT,DELETE,HEAD. To mention single method: --method GET and for multiple methods --method GET --method POST. [DEPRECATED for 'fn create', use 'route create' instead]", DefaultValue: []string{http.MethodGet}}
+	HtMethod            = Flag{Type: StringSlice, Name: flagkey.HtMethod, Usage: "HTTP Methods: GET,POST,PUT,DELETE,HEAD. To mention single method: --method GET and for multiple methods --method GET --method POST or --method GET,POST. 'route create' creates one trigger named <name>-<method> per method, sharing one ingress. [DEPRECATED for 'fn create', use 'route create' instead]", DefaultValue: []string{http.MethodGet}}
 	HtUrl               = Flag{Type: String, Name: flagkey.HtUrl, Usage: "URL pattern (See gorilla/mux supported patterns) [DEPRECATED for 'fn create', use 'route create' instead]"}
 	HtHost              = Flag{Type: String, Name: flagkey.HtHost, Usage: "Use --ingressrule instead", Deprecated: true, Substitute: flagkey.HtIngressRule}
-	HtIngress           = Flag{Type: Bool, Name: flagkey.HtIngress, Usage: "Creates ingress with same URL"}
+	HtIngress           = Flag{Type: Bool, Name: flagkey.HtIngress, Aliases: []string{"ingress"}, Usage: "Creates ingress with same URL"}
 	HtIngressRule       = Flag{Type: String, Name: flagkey.HtIngressRule, Usage: "Host for Ingress rule: --ingressrule host=path (the format of host/path depends on what ingress controller you used)"}
 	HtIngressAnnotation = Flag{Type: StringSlice, Name: flagkey.HtIngressAnnotation, Usage: "Annotation for Ingress: --ingressannotation key=value (the format of annotation depends on what ingress controller you used)"}
-	HtIngressTLS        = Flag{Type: String, Name: flagkey.HtIngressTLS, Usage: "Name of the Secret contains TLS key and crt for Ingress (the usability of TLS features depends on what ingress controller you used)"}
+	HtIngressTLS        = Flag{Type: String, Name: flagkey.HtIngressTLS, Aliases: []string{"tls-secret"}, Usage: "Name of the Secret contains TLS key and crt for Ingress (the usability of TLS features depends on what ingress controller you used)"}
+	HtIngressClass      = Flag{Type: String, Name: flagkey.HtIngressClass, Usage: "Name of the IngressClass to create the Ingress with, use '-' to remove (the default class of the cluster is used if empty)"}
 	HtFnName            = Flag{Type: StringSlice, Name: flagkey.HtFnName, Usage: "Name(s) of the function for this trigger. (If 2 functions are supplied with this flag, traffic gets routed to them based on weights supplied with --weight flag.)"}
 	HtFnWeight          = Flag{Type: IntSlice, Name: flagkey.HtFnWeight, Usage: "Weight for each function supplied with --function flag, in the same order. Used for canary deployment"}
 	HtFnFilter          = Flag{Type: String, Name: flagkey.HtFilter, Usage: "Name of the function for trigger(s)"}
 	HtPrefix            = Flag{Type: String, Name: flagkey.HtPrefix, Usage: "Prefix with which functions are exposed. NOTE: Prefix takes precedence over URL/RelativeURL [DEPRECATED for 'fn create', use 'route create' instead]"}
 	HtKeepPrefix        = Flag{Type: Bool, Name: flagkey.HtKeepPrefix, Usage: "Keep the prefix in the URL while forwarding request to the function"}
+	HtListOutput        = Flag{Type: String, Name: flagkey.HtListOutput, Short: "o", Usage: "Output format, one of: table|json|yaml", DefaultValue: util.OutputFormatTable}
+	HtWeightFn          = Flag{Type: StringSlice, Name: flagkey.HtWeightFn, Usage: "Split the requests between functions by the hash of their X-Request-ID header for A/B testing, e.g. --weight-fn foo:70,bar:30; the weights must add up to 100, use '-' to remove"}
 
 	TtName   = Flag{Type: String, Name: flagkey.TtName, Usage: "Time Trigger name"}
 	TtCron   = Flag{Type: String, Name: flagkey.TtCron, Usage: "Time trigger cron spec with each asterisk representing respectively second, minute, hour, the day of the month, month and day of the week. Also supports readable formats like '@every 5m', '@hourly'"}
@@ -158,24 +240,39 @@ var (
 	MqtMetadata        = Flag{Type: StringSlice, Name: flagkey.MqtMetadata, Usage: "Metadata needed for connecting to source system in format: --metadata key1=value1 --metadata key2=value2"}
 	MqtSecret          = Flag{Type: String, Name: flagkey.MqtSecret, Usage: "Name of secret object", DefaultValue: ""}
 	MqtKind            = Flag{Type: String, Name: flagkey.MqtKind, Usage: "Kind of Message Queue Trigger, e.g. fission, keda", DefaultValue: "fission"}
+	MqtBrokers         = Flag{Type: StringSlice, Name: flagkey.MqtBrokers, Usage: "Kafka brokers to connect to, required for kafka, e.g. --brokers broker1:9092,broker2:9092"}
+	MqtForce           = Flag{Type: Bool, Name: flagkey.MqtForce, Short: "f", Usage: "Create the trigger even if a topic name looks like a mistake, e.g. an internal kafka topic"}
+	MqtConsumerGroup   = Flag{Type: String, Name: flagkey.MqtConsumerGroup, Usage: "Kafka consumer group of the trigger, required for kafka"}
 
 	EnvName                   = Flag{Type: String, Name: flagkey.EnvName, Usage: "Environment name"}
 	EnvPoolsize               = Flag{Type: Int, Name: flagkey.EnvPoolsize, Usage: "Size of the pool", DefaultValue: 3}
 	EnvImage                  = Flag{Type: String, Name: flagkey.EnvImage, Usage: "Environment image URL"}
-	EnvBuilderImage           = Flag{Type: String, Name: flagkey.EnvBuilderImage, Usage: "Environment builder image URL"}
-	EnvBuildCmd               = Flag{Type: String, Name: flagkey.EnvBuildcommand, Usage: "Build command for environment builder to build source package"}
+	EnvBuilderImage           = Flag{Type: String, Name: flagkey.EnvBuilderImage, Aliases: []string{"builder-image"}, Usage: "Environment builder image URL"}
+	EnvBuildCmd               = Flag{Type: String, Name: flagkey.EnvBuildcommand, Aliases: []string{"builder-command"}, Usage: "Build command for environment builder to build source package"}
 	EnvKeepArchive            = Flag{Type: Bool, Name: flagkey.EnvKeeparchive, Usage: "Keep the archive instead of extracting it into a directory (mainly for the JVM environment because .jar is one kind of zip archive)"}
+	EnvRetainArchive          = Flag{Type: Bool, Name: flagkey.EnvRetainArchive, Usage: "Keep a copy of the zip archive of the function under archive/ in the shared volume of the function container (/userfunc by default) after extracting it, e.g. to inspect the deployed code with kubectl exec"}
 	EnvExternalNetwork        = Flag{Type: Bool, Name: flagkey.EnvExternalNetwork, Usage: "Allow pod to access external network (only works when istio feature is enabled)"}
 	EnvTerminationGracePeriod = Flag{Type: Int64, Name: flagkey.EnvGracePeriod, Aliases: []string{"period"}, Usage: "Grace time (in seconds) for pod to perform connection draining before termination (default value will be used if 0 is given)", DefaultValue: 360}
 	EnvVersion                = Flag{Type: Int, Name: flagkey.EnvVersion, Usage: "Environment API version (1 means v1 interface)", DefaultValue: 1}
 	EnvImagePullSecret        = Flag{Type: String, Name: flagkey.EnvImagePullSecret, Usage: "Secret for Kubernetes to pull an image from a private registry"}
-	EnvExecutorType           = Flag{Type: String, Name: flagkey.EnvExecutorType, Usage: "Executor type of pod in environment; one of 'poolmgr', 'newdeploy', 'container'"}
+	EnvSkipImageCheck         = Flag{Type: Bool, Name: flagkey.EnvSkipImageCheck, Usage: "Skip checking that the runtime and builder images exist in the registry (e.g. for air-gapped clusters)"}
+	EnvExecutorType           = Flag{Type: String, Name: flagkey.EnvExecutorType, Aliases: []string{"executor-type"}, Usage: "Executor type of the functions using the environment; one of 'poolmgr', 'newdeploy', 'container'. For 'env create', a type other than 'poolmgr' suppresses the pool size warning"}
+	EnvListVerbose            = Flag{Type: Bool, Name: flagkey.EnvListVerbose, Usage: "Show the number of running, pending and failed pods of each environment"}
+	EnvListOutput             = Flag{Type: String, Name: flagkey.EnvListOutput, Short: "o", Usage: "Output format, one of: table|wide|json|yaml, wide adds the pod counts like --verbose", DefaultValue: util.OutputFormatTable}
+	EnvLogsTail               = Flag{Type: Int, Name: flagkey.EnvLogsTail, Usage: "Number of recent log lines to show, 0 shows all"}
+	EnvLogsFollow             = Flag{Type: Bool, Name: flagkey.EnvLogsFollow, Short: "f", Usage: "Specify if the logs should be streamed"}
+	EnvValidateAll            = Flag{Type: Bool, Name: flagkey.EnvValidateAll, Usage: "Check all environments in the namespace"}
+	EnvPruneYes               = Flag{Type: Bool, Name: flagkey.EnvPruneYes, Short: "y", Usage: "Don't ask for confirmation before deleting environments"}
+	EnvImageUpdateTimeout     = Flag{Type: Duration, Name: flagkey.EnvImageUpdateTimeout, Usage: "Maximum time to wait for the pool to roll out, ex: 5m, 1h", DefaultValue: 10 * time.Minute}
+	EnvConcurrencyModels      = Flag{Type: StringSlice, Name: flagkey.EnvConcurrencyModels, Usage: "Concurrency models the environment runtime supports, functions can choose one with --concurrency-model, ex: --concurrency-models goroutine,process"}
+	EnvForce                  = Flag{Type: Bool, Name: flagkey.EnvForce, Short: "f", Usage: "Force update an environment even if functions using it are running"}
 
-	KwName      = Flag{Type: String, Name: flagkey.KwName, Usage: "Watch name"}
-	KwFnName    = Flag{Type: String, Name: flagkey.KwFnName, Usage: "Function name"}
-	KwNamespace = Flag{Type: String, Name: flagkey.KwNamespace, Aliases: []string{"ns"}, Usage: "Namespace of resource to watch", DefaultValue: metav1.NamespaceDefault}
-	KwObjType   = Flag{Type: String, Name: flagkey.KwObjType, Usage: "Type of resource to watch (Pod, Service, etc.)", DefaultValue: "pod"}
-	KwLabels    = Flag{Type: String, Name: flagkey.KwLabels, Usage: "Label selector of the form a=b,c=d"}
+	KwName             = Flag{Type: String, Name: flagkey.KwName, Usage: "Watch name"}
+	KwFnName           = Flag{Type: String, Name: flagkey.KwFnName, Usage: "Function name"}
+	KwNamespace        = Flag{Type: String, Name: flagkey.KwNamespace, Aliases: []string{"ns"}, Usage: "Namespace of resource to watch, use --fns for the namespace of the watch and its function", DefaultValue: metav1.NamespaceDefault}
+	KwObjType          = Flag{Type: String, Name: flagkey.KwObjType, Usage: "Type of resource to watch (Pod, Service, etc.)", DefaultValue: "pod"}
+	KwLabels           = Flag{Type: String, Name: flagkey.KwLabels, Usage: "Label selector of the form a=b,c=d"}
+	KwWatchedNamespace = Flag{Type: String, Name: flagkey.KwWatchedNamespace, Usage: "Only list kube watchers watching resources in this namespace"}
 
 	PkgName           = Flag{Type: String, Name: flagkey.PkgName, Usage: "Package name"}
 	PkgForce          = Flag{Type: Bool, Name: flagkey.PkgForce, Short: "f", Usage: "Force update a package even if it is used by one or more functions"}
@@ -183,13 +280,23 @@ var (
 	PkgBuildCmd       = Flag{Type: String, Name: flagkey.PkgBuildCmd, Usage: "Build command for builder to run with"}
 	PkgOutput         = Flag{Type: String, Name: flagkey.PkgOutput, Short: "o", Usage: "Output filename to save archive content"}
 	PkgStatus         = Flag{Type: String, Name: flagkey.PkgStatus, Usage: `Filter packages by status`}
-	PkgOrphan         = Flag{Type: Bool, Name: flagkey.PkgOrphan, Usage: "Orphan packages that are not referenced by any function"}
-	PkgCode           = Flag{Type: String, Name: flagkey.PkgCode, Usage: "URL or local path for single file source code"}
+	PkgOrphan         = Flag{Type: Bool, Name: flagkey.PkgOrphan, Aliases: []string{"orphaned"}, Usage: "Orphan packages that are not referenced by any function"}
+	PkgOlderThan      = Flag{Type: String, Name: flagkey.PkgOlderThan, Usage: "Only prune packages created longer ago than this, ex: 30d, 12h, 0 for all", DefaultValue: "24h"}
+	PkgPruneYes       = Flag{Type: Bool, Name: flagkey.PkgPruneYes, Short: "y", Usage: "Don't ask for confirmation before deleting packages"}
+	PkgBuildLogTail   = Flag{Type: Int, Name: flagkey.PkgBuildLogTail, Usage: "Number of recent build log lines to show, 0 shows all"}
+	PkgCode           = Flag{Type: String, Name: flagkey.PkgCode, Usage: "URL or local path for single file source code, 'fn create' also reads it from stdin for -"}
 	PkgDeployArchive  = Flag{Type: StringSlice, Name: flagkey.PkgDeployArchive, Aliases: []string{"deploy"}, Usage: "URL or local paths for binary archive"}
 	PkgDeployChecksum = Flag{Type: String, Name: flagkey.PkgDeployChecksum, Usage: "SHA256 checksum of deploy archive when providing URL"}
 	PkgSrcArchive     = Flag{Type: StringSlice, Name: flagkey.PkgSrcArchive, Aliases: []string{"source", "src"}, Usage: "URL or local paths for source archive"}
 	PkgSrcChecksum    = Flag{Type: String, Name: flagkey.PkgSrcChecksum, Usage: "SHA256 checksum of source archive when providing URL"}
+	PkgSourceURL      = Flag{Type: String, Name: flagkey.PkgSourceURL, Usage: "Git repository URL with an optional @ref or #ref suffix (branch or tag) to use as source archive, e.g. https://github.com/org/repo.git@v1.0. Files matched by .fissionignore are excluded"}
+	PkgChunkSize      = Flag{Type: Int, Name: flagkey.PkgChunkSize, Usage: "Size in megabytes of the chunks large archives are uploaded in", DefaultValue: 10}
 	PkgInsecure       = Flag{Type: Bool, Name: flagkey.PkgInsecure, Usage: "Skip generating SHA256 checksum for file integrity validation"}
+	PkgURL            = Flag{Type: String, Name: flagkey.PkgURL, Usage: "HTTP(S) URL of a pre-built deploy archive, e.g. in S3 or GCS, referenced by the package instead of uploading it"}
+	PkgURLChecksum    = Flag{Type: String, Name: flagkey.PkgURLChecksum, Usage: "SHA256 checksum of the archive given with --url, verified when the archive is fetched; without it the archive is downloaded once to compute the checksum"}
+	PkgRebuild        = Flag{Type: Bool, Name: flagkey.PkgRebuild, Usage: "Rebuild the package from its source archive, e.g. after changing the environment image"}
+	PkgWait           = Flag{Type: Bool, Name: flagkey.PkgWait, Usage: "Wait for the package build to finish and stream the build log"}
+	PkgWaitTimeout    = Flag{Type: Duration, Name: flagkey.PkgWaitTimeout, Usage: "Length of time to wait for the package build with --wait, ex: 5m, 1h", DefaultValue: 10 * time.Minute}
 
 	SpecSave       = Flag{Type: Bool, Name: flagkey.SpecSave, Usage: "Save to the spec directory instead of creating on cluster"}
 	SpecDir        = Flag{Type: String, Name: flagkey.SpecDir, Usage: "Directory to store specs, defaults to ./specs"}
@@ -200,11 +307,30 @@ var (
 	SpecDelete     = Flag{Type: Bool, Name: flagkey.SpecDelete, Usage: "Allow apply to delete resources that no longer exist in the specification"}
 	SpecDry        = Flag{Type: Bool, Name: flagkey.SpecDry, Usage: "View the generated specs"}
 	SpecValidation = Flag{Type: String, Name: flagkey.SpecValidate, Usage: "Turns server side validations of Fission objects on/off"}
+	SpecPrune      = Flag{Type: Bool, Name: flagkey.SpecPrune, Usage: "Delete resources previously applied from the spec directory that are no longer in it, only the namespaces of the specs are searched"}
+	SpecYes        = Flag{Type: Bool, Name: flagkey.SpecYes, Short: "y", Usage: "Don't ask for confirmation before pruning resources, required to prune with --watch"}
 	SpecIgnore     = Flag{Type: String, Name: flagkey.SpecIgnore, Usage: fmt.Sprintf("File containing specs to be ingored inside --specdir, defaults to %v", util.SPEC_IGNORE_FILE)}
 
 	SupportOutput = Flag{Type: String, Name: flagkey.SupportOutput, Short: "o", Usage: "Output directory to save dump archive/files", DefaultValue: flagkey.DefaultSpecOutputDir}
 	SupportNoZip  = Flag{Type: Bool, Name: flagkey.SupportNoZip, Usage: "Save dump information into multiple files instead of single zip file"}
 
+	AuthUsername  = Flag{Type: String, Name: flagkey.AuthUsername, Short: "u", Usage: "Service account username"}
+	AuthPassword  = Flag{Type: String, Name: flagkey.AuthPassword, Usage: "Service account password, read from FISSION_AUTH_PASSWORD if not given"}
+	AuthFunction  = Flag{Type: StringSlice, Name: flagkey.AuthFunction, Short: "f", Usage: "Function the token may invoke, can be repeated; the token may invoke any function if neither --function nor --path is given"}
+	AuthPath      = Flag{Type: StringSlice, Name: flagkey.AuthPath, Usage: "Trigger path the token may invoke, can be repeated"}
+	AuthExpiresIn = Flag{Type: String, Name: flagkey.AuthExpiresIn, Usage: "Lifetime of the token, string representation of time.Duration, ex : 30m, 2h", DefaultValue: "1h"}
+
+	SecretName        = Flag{Type: String, Name: flagkey.SecretName, Usage: "Secret name"}
+	SecretFromLiteral = Flag{Type: StringSlice, Name: flagkey.SecretFromLiteral, Usage: "Key and literal value to add to the secret, ex: --from-literal password=hunter2, can be repeated"}
+	SecretFromFile    = Flag{Type: StringSlice, Name: flagkey.SecretFromFile, Usage: "File to add to the secret, with an optional key (defaults to the file name), ex: --from-file key=path/to/file, can be repeated"}
+
+	CfgMapName        = Flag{Type: String, Name: flagkey.CfgMapName, Usage: "Configmap name"}
+	CfgMapFromLiteral = Flag{Type: StringSlice, Name: flagkey.CfgMapFromLiteral, Usage: "Key and literal value to add to the configmap, ex: --from-literal log-level=debug, can be repeated"}
+	CfgMapFromFile    = Flag{Type: StringSlice, Name: flagkey.CfgMapFromFile, Usage: "File to add to the configmap, with an optional key (defaults to the file name), ex: --from-file key=path/to/file, can be repeated"}
+
+	TriggerFnName = Flag{Type: String, Name: flagkey.TriggerFnName, Short: "f", Usage: "Function the triggers invoke"}
+	TriggerOutput = Flag{Type: String, Name: flagkey.TriggerOutput, Short: "o", Usage: "Output format, one of: table|json|yaml", DefaultValue: util.OutputFormatTable}
+
 	CanaryName              = Flag{Type: String, Name: flagkey.CanaryName, Usage: "Name for the canary config"}
 	CanaryTriggerName       = Flag{Type: String, Name: flagkey.CanaryHTTPTriggerName, Usage: "Http trigger that this config references"}
 	CanaryNewFunc           = Flag{Type: String, Name: flagkey.CanaryNewFunc, Aliases: []string{"newfn"}, Usage: "New version of the function"}
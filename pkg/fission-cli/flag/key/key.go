@@ -21,6 +21,8 @@ const (
 	Server      = "server"
 	ClientOnly  = "client-only"
 	KubeContext = "kube-context"
+	Namespace   = "namespace"
+	RouterURL   = "router-url"
 
 	resourceName = "name"
 	force        = "force"
@@ -40,6 +42,8 @@ const (
 	RuntimeMinmemory = "minmemory"
 	RuntimeMaxmemory = "maxmemory"
 	RuntimeTargetcpu = "targetcpu"
+	RuntimeRequest   = "resource-request"
+	RuntimeLimit     = "resource-limit"
 
 	ReplicasMinscale = "minscale"
 	ReplicasMaxscale = "maxscale"
@@ -66,14 +70,89 @@ const (
 	FnLogDBType             = "dbtype"
 	FnLogReverseQuery       = "reverse"
 	FnLogCount              = "recordcount"
+	FnLogInvocationID       = "invocation-id"
+	FnLogLevel              = "level"
+	FnLogSince              = "since"
+	FnLogUntil              = "until"
 	FnTestBody              = "body"
+	FnTestBodyFile          = "body-file"
+	FnTestContentType       = "content-type"
+	FnTestIgnoreError       = "ignore-error"
 	FnTestHeader            = "header"
 	FnTestQuery             = "query"
+	FnTestStream            = "stream"
+	FnTestWebSocket         = "websocket"
+	FnTestRouterNamespace   = "router-namespace"
+	FnTestTiming            = "timing"
 	FnIdleTimeout           = "idletimeout"
 	FnConcurrency           = "concurrency"
 	FnRequestsPerPod        = "requestsperpod"
 	FnOnceOnly              = "onceonly"
 	FnSubPath               = "subpath"
+	FnListOutput            = Output
+	FnListLabelSelector     = "label-selector"
+	FnListSortBy            = "sort-by"
+	FnListReverse           = "reverse"
+	FnListPrometheus        = "prometheus-url"
+	FnExportOutput          = Output
+	FnRevision              = "revision"
+	FnRevisionHistoryLimit  = "revision-history-limit"
+	FnScaleMin              = "min"
+	FnScaleMax              = "max"
+	FnSpecFile              = "spec-file"
+	FnCanaryNewVersion      = "new-version"
+	FnCanaryWeight          = "weight"
+	FnCanaryIncrement       = "increment"
+	FnCanaryInterval        = "interval"
+	FnCanaryMaxErrorRate    = "max-error-rate"
+	FnCanaryTrigger         = "trigger"
+	FnCopyFrom              = "from"
+	FnCopyTo                = "to"
+	FnCopyTriggerFrom       = "from-fn"
+	FnCopyTriggerTo         = "to-fn"
+	FnCopyTriggerSwap       = "swap"
+	FnBenchmarkRPS          = "rps"
+	FnBenchmarkDuration     = "duration"
+	FnBenchmarkOutput       = Output
+	FnTraceBackend          = "trace-backend"
+	FnArchiveOutput         = Output
+	FnArchiveUnzip          = "unzip"
+	FnDiffLocal             = "local"
+	FnDiffExitCode          = "exit-code"
+	FnColdStartSamples      = "samples"
+	FnRateLimitRPS          = "rps"
+	FnWait                  = "wait"
+	FnWaitTimeout           = "timeout"
+	FnAccessLogFormat       = "format"
+	FnEventFile             = "event-file"
+	FnImportFile            = "file"
+	FnImportPackageFile     = "package-file"
+	FnAnnotationKey         = "key"
+	FnAnnotationValue       = "value"
+	FnDeleteWithTriggers    = "with-triggers"
+	FnEnvVarKey             = "key"
+	FnEnvVarValue           = "value"
+	FnAsyncJob              = "job"
+	FnDebugPort             = "debug-port"
+	FnDebugExec             = "exec"
+	FnProfileDuration       = "duration"
+	FnProfileOutput         = Output
+	FnProfileInteractive    = "interactive"
+	FnWatchDir              = "dir"
+	FnWatchFilter           = "filter"
+	FnMaxConcurrency        = "max-concurrency"
+	FnTopInterval           = "interval"
+	FnGrepPattern           = "pattern"
+	FnGrepEnv               = "env"
+	FnConcurrencyModel      = "concurrency-model"
+	FnExportMetricsOutput   = Output
+	FnCloneTargetServer     = "target-server"
+	FnCloneWithTriggers     = "with-triggers"
+	FnUsageSince            = "since"
+	FnUsageCompare          = "compare"
+	FnUsagePrometheus       = FnListPrometheus
+	FnLambdaRegion          = "region"
+	FnLambdaFunctionName    = "function-name"
 
 	HtName              = resourceName
 	HtMethod            = "method"
@@ -83,11 +162,14 @@ const (
 	HtIngressRule       = "ingressrule"
 	HtIngressAnnotation = "ingressannotation"
 	HtIngressTLS        = "ingresstls"
+	HtIngressClass      = "ingress-class"
 	HtFnName            = "function"
 	HtFnWeight          = "weight"
 	HtFilter            = HtFnName
 	HtPrefix            = "prefix"
 	HtKeepPrefix        = "keepprefix"
+	HtWeightFn          = "weight-fn"
+	HtListOutput        = Output
 
 	TtName   = resourceName
 	TtCron   = "cron"
@@ -109,24 +191,39 @@ const (
 	MqtMetadata        = "metadata"
 	MqtSecret          = "secret"
 	MqtKind            = "mqtkind"
+	MqtBrokers         = "brokers"
+	MqtConsumerGroup   = "consumer-group"
+	MqtForce           = force
 
-	EnvName            = resourceName
-	EnvPoolsize        = "poolsize"
-	EnvImage           = "image"
-	EnvBuilderImage    = "builder"
-	EnvBuildcommand    = "buildcmd"
-	EnvKeeparchive     = "keeparchive"
-	EnvExternalNetwork = "externalnetwork"
-	EnvGracePeriod     = "graceperiod"
-	EnvVersion         = "version"
-	EnvImagePullSecret = "imagepullsecret"
-	EnvExecutorType    = "executortype"
-
-	KwName      = resourceName
-	KwFnName    = "function"
-	KwNamespace = "namespace"
-	KwObjType   = "type"
-	KwLabels    = "labels"
+	EnvName               = resourceName
+	EnvPoolsize           = "poolsize"
+	EnvImage              = "image"
+	EnvBuilderImage       = "builder"
+	EnvBuildcommand       = "buildcmd"
+	EnvKeeparchive        = "keeparchive"
+	EnvRetainArchive      = "retain-archive"
+	EnvExternalNetwork    = "externalnetwork"
+	EnvGracePeriod        = "graceperiod"
+	EnvVersion            = "version"
+	EnvImagePullSecret    = "imagepullsecret"
+	EnvSkipImageCheck     = "skip-image-check"
+	EnvExecutorType       = "executortype"
+	EnvForce              = force
+	EnvListVerbose        = "verbose"
+	EnvListOutput         = Output
+	EnvLogsTail           = "tail"
+	EnvLogsFollow         = "follow"
+	EnvValidateAll        = "all"
+	EnvPruneYes           = "yes"
+	EnvImageUpdateTimeout = "timeout"
+	EnvConcurrencyModels  = "concurrency-models"
+
+	KwName             = resourceName
+	KwFnName           = "function"
+	KwNamespace        = "namespace"
+	KwObjType          = "type"
+	KwLabels           = "labels"
+	KwWatchedNamespace = "watched-namespace"
 
 	PkgName           = resourceName
 	PkgForce          = force
@@ -135,12 +232,22 @@ const (
 	PkgSrcArchive     = "sourcearchive"
 	PkgDeployArchive  = "deployarchive"
 	PkgSrcChecksum    = "srcchecksum"
+	PkgSourceURL      = "source-url"
+	PkgChunkSize      = "chunk-size"
 	PkgDeployChecksum = "deploychecksum"
 	PkgInsecure       = "insecure"
 	PkgBuildCmd       = "buildcmd"
 	PkgOutput         = Output
 	PkgStatus         = "status"
 	PkgOrphan         = "orphan"
+	PkgBuildLogTail   = "tail"
+	PkgRebuild        = "rebuild"
+	PkgWait           = "wait"
+	PkgWaitTimeout    = "timeout"
+	PkgOlderThan      = "older-than"
+	PkgPruneYes       = "yes"
+	PkgURL            = "url"
+	PkgURLChecksum    = "url-checksum"
 
 	SpecSave     = "spec"
 	SpecDir      = "specdir"
@@ -152,10 +259,29 @@ const (
 	SpecDry      = "dry"
 	SpecValidate = "validation"
 	SpecIgnore   = "specignore"
+	SpecPrune    = "prune"
+	SpecYes      = "yes"
 
 	SupportOutput = Output
 	SupportNoZip  = "nozip"
 
+	AuthUsername  = "username"
+	AuthPassword  = "password"
+	AuthFunction  = "function"
+	AuthPath      = "path"
+	AuthExpiresIn = "expires-in"
+
+	SecretName        = resourceName
+	SecretFromLiteral = "from-literal"
+	SecretFromFile    = "from-file"
+
+	CfgMapName        = resourceName
+	CfgMapFromLiteral = "from-literal"
+	CfgMapFromFile    = "from-file"
+
+	TriggerFnName = "function"
+	TriggerOutput = Output
+
 	CanaryName              = resourceName
 	CanaryHTTPTriggerName   = "httptrigger"
 	CanaryNewFunc           = "newfunction"
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -38,6 +39,8 @@ const (
 	INFLUXDB_URL      = "http://influxdb:8086/query"
 )
 
+var invocationIDRegex = regexp.MustCompile(`^[a-zA-Z0-9._:-]+$`)
+
 func NewInfluxDB(serverURL string) (InfluxDB, error) {
 	return InfluxDB{endpoint: serverURL}, nil
 }
@@ -71,13 +74,31 @@ func (influx InfluxDB) GetLogs(filter LogFilter) ([]LogEntry, error) {
 		orderCondition = " order by \"time\" desc"
 	}
 
+	extraConditions := ""
+	if !filter.Until.IsZero() {
+		extraConditions += " AND \"time\" < $until"
+		parameters["until"] = filter.Until.UnixNano()
+	}
+	if filter.InvocationID != "" {
+		// regular expressions can't be bound as parameters, the
+		// invocation ID is quoted and checked by invocationIDRegex.
+		if !invocationIDRegex.MatchString(filter.InvocationID) {
+			return nil, errors.Errorf("invalid invocation ID '%v'", filter.InvocationID)
+		}
+		extraConditions += " AND \"log\" =~ /" + regexp.QuoteMeta(filter.InvocationID) + "/"
+	}
+	if filter.Level != "" {
+		// filter in the query so that LIMIT counts matching lines only
+		extraConditions += levelCondition(filter.Level)
+	}
+
 	if filter.Pod != "" {
 		// wait for bug fix for fluent-bit influxdb plugin
-		queryCmd = "select * from /^log*/ where (\"funcuid\" = $funcuid OR \"kubernetes_labels_functionUid\" = $funcuid) AND \"pod\" = $pod AND \"time\" > $time " + orderCondition + " LIMIT " + strconv.Itoa(filter.RecordLimit)
+		queryCmd = "select * from /^log*/ where (\"funcuid\" = $funcuid OR \"kubernetes_labels_functionUid\" = $funcuid) AND \"pod\" = $pod AND \"time\" > $time" + extraConditions + orderCondition + " LIMIT " + strconv.Itoa(filter.RecordLimit)
 		parameters["pod"] = filter.Pod
 	} else {
 		// wait for bug fix for fluent-bit influxdb plugin
-		queryCmd = "select * from /^log*/ where (\"funcuid\" = $funcuid  OR \"kubernetes_labels_functionUid\" = $funcuid) AND \"time\" > $time " + orderCondition + " LIMIT " + strconv.Itoa(filter.RecordLimit)
+		queryCmd = "select * from /^log*/ where (\"funcuid\" = $funcuid  OR \"kubernetes_labels_functionUid\" = $funcuid) AND \"time\" > $time" + extraConditions + orderCondition + " LIMIT " + strconv.Itoa(filter.RecordLimit)
 	}
 
 	query := influxdbClient.NewQueryWithParameters(queryCmd, INFLUXDB_DATABASE, "", parameters)
@@ -136,6 +157,24 @@ func (influx InfluxDB) GetLogs(filter LogFilter) ([]LogEntry, error) {
 	return logEntries, nil
 }
 
+// levelCondition returns the WHERE condition keeping the log lines of the
+// given level or above. A line is of a level if it contains one of the
+// level keywords; lines without any keyword are considered as error if
+// written to stderr and as info otherwise.
+func levelCondition(level string) string {
+	if level == LevelDebug || !ValidLevel(level) {
+		return ""
+	}
+	keywordRegex := func(keywords []string) string {
+		return "/(?i)\\b(" + strings.Join(keywords, "|") + ")\\b/"
+	}
+	noKeyword := "\"log\" !~ " + keywordRegex(keywordsAtOrAbove(LevelDebug))
+	if level != LevelInfo {
+		noKeyword += " AND \"stream\" = 'stderr'"
+	}
+	return " AND (\"log\" =~ " + keywordRegex(keywordsAtOrAbove(level)) + " OR (" + noKeyword + "))"
+}
+
 func (influx InfluxDB) query(query influxdbClient.Query) (*influxdbClient.Response, error) {
 	queryURL, err := url.Parse(influx.endpoint)
 	if err != nil {
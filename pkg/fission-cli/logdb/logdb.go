@@ -25,6 +25,27 @@ const (
 	INFLUXDB = "influxdb"
 )
 
+// Log levels, in increasing order of severity.
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+var (
+	// levels lists the log levels in increasing order of severity.
+	levels = []string{LevelDebug, LevelInfo, LevelWarn, LevelError}
+
+	// levelKeywords are the words marking a log line as being of a level.
+	levelKeywords = map[string][]string{
+		LevelDebug: {"debug"},
+		LevelInfo:  {"info"},
+		LevelWarn:  {"warn", "warning"},
+		LevelError: {"error", "fatal", "panic"},
+	}
+)
+
 type LogDatabase interface {
 	GetLogs(LogFilter) ([]LogEntry, error)
 }
@@ -34,8 +55,13 @@ type LogFilter struct {
 	Function    string
 	FuncUid     string
 	Since       time.Time
+	Until       time.Time
 	Reverse     bool
 	RecordLimit int
+	// InvocationID only keeps log lines containing the given ID
+	InvocationID string
+	// Level only keeps log lines of the given level or above
+	Level string
 }
 
 type LogEntry struct {
@@ -69,6 +95,28 @@ func ByTimestamp(entries []LogEntry, desc bool) ByTimestampSort {
 	return ByTimestampSort{entries, desc}
 }
 
+// ValidLevel returns true if level is one of debug, info, warn or error.
+func ValidLevel(level string) bool {
+	_, ok := levelKeywords[level]
+	return ok
+}
+
+// keywordsAtOrAbove returns the keywords of the given level and of the
+// levels above it.
+func keywordsAtOrAbove(level string) []string {
+	keywords := []string{}
+	found := false
+	for _, l := range levels {
+		if l == level {
+			found = true
+		}
+		if found {
+			keywords = append(keywords, levelKeywords[l]...)
+		}
+	}
+	return keywords
+}
+
 func GetLogDB(dbType string, serverURL string) (LogDatabase, error) {
 	switch dbType {
 	case INFLUXDB:
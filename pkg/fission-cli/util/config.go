@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// ENV_CONFIG is the environment variable holding the path of an
+// alternate CLI config file.
+const ENV_CONFIG = "FISSION_CONFIG"
+
+// Config holds the CLI settings kept across invocations. Command line
+// flags and environment variables take precedence over it.
+type Config struct {
+	// Server is the URL of the controller, used when --server is not
+	// given.
+	Server string `json:"server,omitempty"`
+
+	// RouterURL is the external URL of the router, saved after it was
+	// first given with --router-url.
+	RouterURL string `json:"routerURL,omitempty"`
+
+	// Namespace is the default namespace of fission objects.
+	Namespace string `json:"namespace,omitempty"`
+
+	// AuthToken is sent as a Bearer token by 'fission fn test' when no
+	// token from 'fission auth token' is cached.
+	AuthToken string `json:"authToken,omitempty"`
+
+	// PrometheusURL is the URL of the Prometheus server scraping the
+	// router metrics.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+}
+
+// ConfigPath returns the file the CLI config is stored in, which is
+// $FISSION_CONFIG if set, or ~/.fission/config.yaml.
+func ConfigPath() (string, error) {
+	if path := os.Getenv(ENV_CONFIG); len(path) > 0 {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting home directory")
+	}
+	return filepath.Join(home, ".fission", "config.yaml"), nil
+}
+
+// LoadConfig returns the CLI config, or an empty config if there is
+// none yet.
+func LoadConfig() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "error reading config from '%v'", path)
+	}
+	err = yaml.Unmarshal(bs, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing config in '%v'", path)
+	}
+	return config, nil
+}
+
+// SaveConfig writes the CLI config, readable by the current user only.
+func SaveConfig(config *Config) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "error creating directory for '%v'", path)
+	}
+	bs, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, bs, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config to '%v'", path)
+	}
+	return nil
+}
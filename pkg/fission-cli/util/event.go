@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/info"
+)
+
+// RecordValidationFailure emits a Warning event in the namespace of the
+// object the CLI failed to create, so that administrators watching the
+// cluster events see failed invocations too. Events are best effort,
+// failing to emit one is only logged.
+func RecordValidationFailure(input cli.Input, kind string, objMeta metav1.ObjectMeta, cause error) {
+	// spec files are validated locally, nothing reaches the cluster
+	if input.Bool(flagkey.SpecSave) || input.Bool(flagkey.SpecDry) {
+		return
+	}
+	if len(objMeta.Namespace) == 0 {
+		objMeta.Namespace = GetDefaultNamespace()
+	}
+
+	_, kubeClient, err := GetKubernetesClient(input.String(flagkey.KubeContext))
+	if err != nil {
+		console.Verbose(2, "Error creating kubernetes client to record the failure: %v", err)
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "fission-cli-",
+			Namespace:    objMeta.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: fv1.CRD_VERSION,
+			Kind:       kind,
+			Name:       objMeta.Name,
+			Namespace:  objMeta.Namespace,
+		},
+		Reason:         "ValidationFailed",
+		Message:        fmt.Sprintf("fission CLI %v run by %v: %v", cliVersion(), cliUsername(), cause),
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "fission-cli"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	sink := &typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events(objMeta.Namespace)}
+	_, err = sink.Create(event)
+	if err != nil {
+		console.Verbose(2, "Error recording the failure as event: %v", err)
+	}
+}
+
+func cliVersion() string {
+	if len(info.Version) == 0 {
+		return "(unknown version)"
+	}
+	return info.Version
+}
+
+func cliUsername() string {
+	usr, err := user.Current()
+	if err == nil {
+		return usr.Username
+	}
+	if name := os.Getenv("USER"); len(name) > 0 {
+		return name
+	}
+	return "unknown user"
+}
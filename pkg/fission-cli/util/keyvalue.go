@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LABEL_MANAGED marks secrets and configmaps created through the
+// fission CLI.
+const LABEL_MANAGED = "fission.io/managed"
+
+// ReadKeyValueData collects the data of a secret or configmap from
+// key=value literals and files given as key=path or path, in which case
+// the file name is used as the key.
+func ReadKeyValueData(literals []string, files []string) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	for _, literal := range literals {
+		kv := strings.SplitN(literal, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 {
+			return nil, errors.Errorf("invalid literal '%v', must be of the form key=value", literal)
+		}
+		if _, ok := data[kv[0]]; ok {
+			return nil, errors.Errorf("duplicate key '%v'", kv[0])
+		}
+		data[kv[0]] = []byte(kv[1])
+	}
+	for _, file := range files {
+		key, path := filepath.Base(file), file
+		if kv := strings.SplitN(file, "=", 2); len(kv) == 2 {
+			key, path = kv[0], kv[1]
+		}
+		if _, ok := data[key]; ok {
+			return nil, errors.Errorf("duplicate key '%v'", key)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading file '%v'", path)
+		}
+		data[key] = contents
+	}
+	return data, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+// Output formats supported by the list commands.
+const (
+	OutputFormatTable = "table"
+	OutputFormatWide  = "wide"
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+)
+
+// OutputFormatter prints resources either as a human readable table
+// (table/wide) or serialized as JSON/YAML so that the output can be
+// piped into other tooling.
+type OutputFormatter struct {
+	Format string
+	Writer io.Writer
+}
+
+// GetOutputFormatter returns an OutputFormatter for the format given
+// with the --output flag. Table is used if the flag is not set.
+func GetOutputFormatter(input cli.Input) (*OutputFormatter, error) {
+	return NewOutputFormatter(input.String(flagkey.Output))
+}
+
+// NewOutputFormatter returns an OutputFormatter writing to stdout.
+func NewOutputFormatter(format string) (*OutputFormatter, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "":
+		format = OutputFormatTable
+	case OutputFormatTable, OutputFormatWide, OutputFormatJSON, OutputFormatYAML:
+	default:
+		return nil, errors.Errorf("unsupported output format '%v', must be one of: %v, %v, %v, %v",
+			format, OutputFormatTable, OutputFormatWide, OutputFormatJSON, OutputFormatYAML)
+	}
+	return &OutputFormatter{Format: format, Writer: os.Stdout}, nil
+}
+
+// IsStructured returns true if the resources should be serialized
+// instead of being printed as a table.
+func (f *OutputFormatter) IsStructured() bool {
+	return f.Format == OutputFormatJSON || f.Format == OutputFormatYAML
+}
+
+// IsWide returns true if the table should contain the extra columns.
+func (f *OutputFormatter) IsWide() bool {
+	return f.Format == OutputFormatWide
+}
+
+// Print serializes obj in JSON or YAML format.
+func (f *OutputFormatter) Print(obj interface{}) error {
+	var (
+		bs  []byte
+		err error
+	)
+	switch f.Format {
+	case OutputFormatJSON:
+		bs, err = json.MarshalIndent(obj, "", "  ")
+		bs = append(bs, '\n')
+	case OutputFormatYAML:
+		bs, err = yaml.Marshal(obj)
+	default:
+		return errors.Errorf("output format '%v' cannot be serialized", f.Format)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error formatting output as %v", f.Format)
+	}
+	_, err = fmt.Fprint(f.Writer, string(bs))
+	return err
+}
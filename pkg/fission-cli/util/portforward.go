@@ -29,6 +29,8 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 
@@ -183,19 +185,59 @@ func runPortForward(labelSelector string, localPort string, ns string, kubeConte
 	stopChannel := make(chan struct{}, 1)
 	readyChannel := make(chan struct{})
 
+	fw, err := newPortForwarder(config, clientset, podNameSpace, podName, localPort, targetPort, stopChannel, readyChannel)
+	if err != nil {
+		return err
+	}
+
+	console.Verbose(2, "Starting port forwarder")
+	return fw.ForwardPorts()
+}
+
+// PortForwardPod forwards localPort to remotePort of the given pod
+// until stopChannel is closed. It returns once the forward accepts
+// connections.
+func PortForwardPod(kubeContext, namespace, podName, localPort, remotePort string, stopChannel chan struct{}) error {
+	config, clientset, err := GetKubernetesClient(kubeContext)
+	if err != nil {
+		return err
+	}
+
+	readyChannel := make(chan struct{})
+	fw, err := newPortForwarder(config, clientset, namespace, podName, localPort, remotePort, stopChannel, readyChannel)
+	if err != nil {
+		return err
+	}
+
+	errChannel := make(chan error, 1)
+	go func() {
+		errChannel <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyChannel:
+		return nil
+	case err := <-errChannel:
+		return errors.Wrapf(err, "error forwarding local port %v to pod %v/%v", localPort, namespace, podName)
+	}
+}
+
+func newPortForwarder(config *restclient.Config, clientset kubernetes.Interface, namespace, podName, localPort, remotePort string,
+	stopChannel, readyChannel chan struct{}) (*portforward.PortForwarder, error) {
+
 	// create request URL
 	req := clientset.CoreV1().RESTClient().Post().Resource("pods").
-		Namespace(podNameSpace).Name(podName).SubResource("portforward")
+		Namespace(namespace).Name(podName).SubResource("portforward")
 	url := req.URL()
 
 	// create ports slice
-	portCombo := localPort + ":" + targetPort
+	portCombo := localPort + ":" + remotePort
 	ports := []string{portCombo}
 
 	// actually start the port-forwarding process here
 	transport, upgrader, err := spdy.RoundTripperFor(config)
 	if err != nil {
-		return errors.Errorf("Failed to connect to Fission service on Kubernetes")
+		return nil, errors.Errorf("Failed to connect to Fission service on Kubernetes")
 	}
 	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
 
@@ -205,9 +247,7 @@ func runPortForward(labelSelector string, localPort string, ns string, kubeConte
 	}
 	fw, err := portforward.New(dialer, ports, stopChannel, readyChannel, outStream, os.Stderr)
 	if err != nil {
-		return errors.Wrap(err, "error creating port forwarder")
+		return nil, errors.Wrap(err, "error creating port forwarder")
 	}
-
-	console.Verbose(2, "Starting port forwarder")
-	return fw.ForwardPorts()
+	return fw, nil
 }
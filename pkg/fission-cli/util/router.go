@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+// GetRouterURL returns the external URL of the router: the --router-url
+// flag, the URL saved in the CLI config, or the address of the router
+// load balancer, in that order. An empty string is returned if none of
+// them is available.
+func GetRouterURL(input cli.Input) (string, error) {
+	if routerURL := input.String(flagkey.RouterURL); len(routerURL) > 0 {
+		return strings.TrimSuffix(routerURL, "/"), nil
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if len(config.RouterURL) > 0 {
+		return config.RouterURL, nil
+	}
+
+	routerURL, err := routerLoadBalancerURL(input.String(flagkey.KubeContext))
+	if err != nil {
+		console.Verbose(2, "Unable to find the router load balancer: %v", err)
+		return "", nil
+	}
+	return routerURL, nil
+}
+
+// SaveRouterURL saves the --router-url flag in the CLI config, so that
+// later commands use it without the flag.
+func SaveRouterURL(input cli.Input) error {
+	if !input.IsSet(flagkey.RouterURL) {
+		return nil
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	routerURL := strings.TrimSuffix(input.String(flagkey.RouterURL), "/")
+	if config.RouterURL == routerURL {
+		return nil
+	}
+	config.RouterURL = routerURL
+	return SaveConfig(config)
+}
+
+// routerLoadBalancerURL returns the URL of the router service if it is
+// exposed through a load balancer.
+func routerLoadBalancerURL(kubeContext string) (string, error) {
+	_, kubeClient, err := GetKubernetesClient(kubeContext)
+	if err != nil {
+		return "", err
+	}
+
+	ns := GetFissionNamespace()
+	if len(ns) == 0 {
+		ns = metav1.NamespaceAll
+	}
+	svcs, err := kubeClient.CoreV1().Services(ns).List(context.Background(),
+		metav1.ListOptions{LabelSelector: "application=fission-router"})
+	if err != nil {
+		return "", err
+	}
+	if len(svcs.Items) != 1 {
+		return "", errors.Errorf("found %v router services, set FISSION_NAMESPACE to the namespace of the install", len(svcs.Items))
+	}
+	return loadBalancerURL(&svcs.Items[0]), nil
+}
+
+func loadBalancerURL(svc *apiv1.Service) string {
+	if svc.Spec.Type != apiv1.ServiceTypeLoadBalancer || len(svc.Status.LoadBalancer.Ingress) == 0 || len(svc.Spec.Ports) == 0 {
+		return ""
+	}
+	host := svc.Status.LoadBalancer.Ingress[0].IP
+	if len(host) == 0 {
+		host = svc.Status.LoadBalancer.Ingress[0].Hostname
+	}
+	if len(host) == 0 {
+		return ""
+	}
+	port := svc.Spec.Ports[0].Port
+	if port == 80 {
+		return "http://" + host
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
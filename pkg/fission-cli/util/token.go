@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/fission/fission/pkg/auth"
+)
+
+// AuthTokenPath returns the file the auth token is cached in.
+func AuthTokenPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting home directory")
+	}
+	return filepath.Join(home, ".fission", "token"), nil
+}
+
+// SaveAuthToken caches the token, readable by the current user only.
+func SaveAuthToken(token *auth.TokenResponse) error {
+	path, err := AuthTokenPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "error creating directory for '%v'", path)
+	}
+	bs, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, bs, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error writing auth token to '%v'", path)
+	}
+	return nil
+}
+
+// LoadAuthToken returns the cached token, or nil if there is none.
+func LoadAuthToken() (*auth.TokenResponse, error) {
+	path, err := AuthTokenPath()
+	if err != nil {
+		return nil, err
+	}
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "error reading auth token from '%v'", path)
+	}
+	token := &auth.TokenResponse{}
+	err = json.Unmarshal(bs, token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing auth token in '%v'", path)
+	}
+	return token, nil
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package util
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -47,6 +49,36 @@ import (
 	"github.com/fission/fission/pkg/utils"
 )
 
+// ENV_DEFAULT_NAMESPACE is the environment variable holding the default
+// namespace of fission objects. FISSION_NAMESPACE is already used for
+// the namespace fission is installed in.
+const ENV_DEFAULT_NAMESPACE = "FISSION_DEFAULT_NAMESPACE"
+
+// GetDefaultNamespace returns the namespace set in FISSION_DEFAULT_NAMESPACE
+// or the CLI config, or the kubernetes default namespace.
+func GetDefaultNamespace() string {
+	ns := GetConfiguredNamespace()
+	if len(ns) == 0 {
+		return metav1.NamespaceDefault
+	}
+	return ns
+}
+
+// GetConfiguredNamespace returns the namespace set in
+// FISSION_DEFAULT_NAMESPACE, or else in the CLI config, or an empty
+// string if neither sets one.
+func GetConfiguredNamespace() string {
+	if ns := os.Getenv(ENV_DEFAULT_NAMESPACE); len(ns) > 0 {
+		return ns
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		console.Verbose(2, "Ignoring CLI config: %v", err)
+		return ""
+	}
+	return config.Namespace
+}
+
 func GetFissionNamespace() string {
 	fissionNamespace := os.Getenv("FISSION_NAMESPACE")
 	return fissionNamespace
@@ -210,6 +242,16 @@ func GetServer(input cli.Input) (c client.Interface, err error) {
 func GetServerURL(input cli.Input) (serverUrl string, err error) {
 	serverUrl = input.GlobalString(flagkey.Server)
 	kubeContext := input.String(flagkey.KubeContext)
+	if len(serverUrl) == 0 {
+		serverUrl = os.Getenv("FISSION_URL")
+	}
+	if len(serverUrl) == 0 {
+		config, err := LoadConfig()
+		if err != nil {
+			return "", err
+		}
+		serverUrl = config.Server
+	}
 	if len(serverUrl) == 0 {
 		// starts local portforwarder etc.
 		serverUrl, err = GetApplicationUrl("application=fission-api", kubeContext)
@@ -228,6 +270,32 @@ func GetServerURL(input cli.Input) (serverUrl string, err error) {
 	return serverUrl, nil
 }
 
+// ParseResourceList parses CPU and memory quantities given in the
+// Kubernetes resource syntax, e.g. "cpu=100m,memory=64Mi".
+func ParseResourceList(s string) (v1.ResourceList, error) {
+	list := v1.ResourceList{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid resource '%v', must be of the form name=quantity", item)
+		}
+		name := v1.ResourceName(strings.TrimSpace(kv[0]))
+		if name != v1.ResourceCPU && name != v1.ResourceMemory {
+			return nil, errors.Errorf("unsupported resource '%v', must be one of: %v, %v", name, v1.ResourceCPU, v1.ResourceMemory)
+		}
+		quantity, err := resource.ParseQuantity(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity for resource '%v'", name)
+		}
+		list[name] = quantity
+	}
+	return list, nil
+}
+
 func GetResourceReqs(input cli.Input, resReqs *v1.ResourceRequirements) (*v1.ResourceRequirements, error) {
 	r := &v1.ResourceRequirements{}
 
@@ -282,6 +350,28 @@ func GetResourceReqs(input cli.Input, resReqs *v1.ResourceRequirements) (*v1.Res
 		r.Limits[v1.ResourceMemory] = memLimit
 	}
 
+	// the Kubernetes resource syntax takes precedence over the
+	// deprecated per resource flags
+	if input.IsSet(flagkey.RuntimeRequest) {
+		requests, err := ParseResourceList(input.String(flagkey.RuntimeRequest))
+		if err != nil {
+			e = multierror.Append(e, errors.Wrapf(err, "Failed to parse --%v", flagkey.RuntimeRequest))
+		}
+		for name, quantity := range requests {
+			r.Requests[name] = quantity
+		}
+	}
+
+	if input.IsSet(flagkey.RuntimeLimit) {
+		limits, err := ParseResourceList(input.String(flagkey.RuntimeLimit))
+		if err != nil {
+			e = multierror.Append(e, errors.Wrapf(err, "Failed to parse --%v", flagkey.RuntimeLimit))
+		}
+		for name, quantity := range limits {
+			r.Limits[name] = quantity
+		}
+	}
+
 	limitCPU := r.Limits[v1.ResourceCPU]
 	requestCPU := r.Requests[v1.ResourceCPU]
 
@@ -428,3 +518,14 @@ func ApplyLabelsAndAnnotations(input cli.Input, objectMeta *metav1.ObjectMeta) e
 	}
 	return nil
 }
+
+// Confirm asks a yes/no question and returns true if the answer is yes.
+func Confirm(in io.Reader, question string) bool {
+	fmt.Printf("%v [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && len(answer) == 0 {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -142,8 +142,20 @@ func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Sub
 		consumerConfig.Net.TLS.Config = tlsConfig
 	}
 
-	consumer, err := cluster.NewConsumer(kafka.brokers, string(trigger.ObjectMeta.UID), []string{trigger.Spec.Topic}, consumerConfig)
-	kafka.logger.Info("created a new consumer", zap.Strings("brokers", kafka.brokers),
+	// a trigger may point to brokers other than the ones the
+	// message queue trigger was configured with
+	brokers := kafka.brokers
+	if len(trigger.Spec.Brokers) > 0 {
+		brokers = trigger.Spec.Brokers
+	}
+	consumerGroup := string(trigger.ObjectMeta.UID)
+	if len(trigger.Spec.ConsumerGroup) > 0 {
+		consumerGroup = trigger.Spec.ConsumerGroup
+	}
+
+	consumer, err := cluster.NewConsumer(brokers, consumerGroup, []string{trigger.Spec.Topic}, consumerConfig)
+	kafka.logger.Info("created a new consumer", zap.Strings("brokers", brokers),
+		zap.String("consumer group", consumerGroup),
 		zap.String("input topic", trigger.Spec.Topic),
 		zap.String("output topic", trigger.Spec.ResponseTopic),
 		zap.String("error topic", trigger.Spec.ErrorTopic),
@@ -154,8 +166,8 @@ func (kafka Kafka) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Sub
 		return nil, err
 	}
 
-	producer, err := sarama.NewSyncProducer(kafka.brokers, producerConfig)
-	kafka.logger.Info("created a new producer", zap.Strings("brokers", kafka.brokers),
+	producer, err := sarama.NewSyncProducer(brokers, producerConfig)
+	kafka.logger.Info("created a new producer", zap.Strings("brokers", brokers),
 		zap.String("input topic", trigger.Spec.Topic),
 		zap.String("output topic", trigger.Spec.ResponseTopic),
 		zap.String("error topic", trigger.Spec.ErrorTopic),
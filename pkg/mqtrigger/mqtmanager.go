@@ -36,6 +36,9 @@ const (
 	GET_ALL_TRIGGERS
 )
 
+// pausedLogInterval is how often a paused trigger is logged.
+const pausedLogInterval = time.Minute
+
 type (
 	requestType int
 
@@ -46,6 +49,7 @@ type (
 		fissionClient    *crd.FissionClient
 		messageQueueType fv1.MessageQueueType
 		messageQueue     messageQueue.MessageQueue
+		pausedLogged     map[string]time.Time
 	}
 
 	triggerSubscription struct {
@@ -73,6 +77,7 @@ func MakeMessageQueueTriggerManager(logger *zap.Logger,
 		fissionClient:    fissionClient,
 		messageQueueType: mqType,
 		messageQueue:     messageQueue,
+		pausedLogged:     make(map[string]time.Time),
 	}
 	return &mqTriggerMgr
 }
@@ -154,9 +159,22 @@ func (mqt *MessageQueueTriggerManager) syncTriggers() {
 		newTriggerMap := make(map[string]*fv1.MessageQueueTrigger)
 		for index := range newTriggers.Items {
 			newTrigger := &newTriggers.Items[index]
-			if newTrigger.Spec.MessageQueueType == mqt.messageQueueType {
-				newTriggerMap[crd.CacheKey(&newTrigger.ObjectMeta)] = newTrigger
+			if newTrigger.Spec.MessageQueueType != mqt.messageQueueType {
+				continue
+			}
+			key := crd.CacheKey(&newTrigger.ObjectMeta)
+			if newTrigger.Spec.Paused {
+				// paused triggers are unsubscribed below, so the broker
+				// isn't polled until the trigger is resumed
+				if time.Since(mqt.pausedLogged[key]) >= pausedLogInterval {
+					mqt.logger.Info("message queue trigger is paused", zap.String("trigger_name", newTrigger.ObjectMeta.Name),
+						zap.String("trigger_namespace", newTrigger.ObjectMeta.Namespace))
+					mqt.pausedLogged[key] = time.Now()
+				}
+				continue
 			}
+			delete(mqt.pausedLogged, key)
+			newTriggerMap[key] = newTrigger
 		}
 
 		// get current set of triggers
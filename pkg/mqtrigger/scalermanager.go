@@ -50,6 +50,10 @@ var (
 	matchAllCap   = regexp.MustCompile("([a-z0-9])([A-Z])")
 )
 
+// pausedReplicasAnnotation pauses the autoscaling of a ScaledObject at
+// the given number of replicas, supported since KEDA 2.7
+const pausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+
 func getScaledObjectClient(namespace string) (dynamic.ResourceInterface, error) {
 	dynamicClient, err := crd.GetDynamicClient()
 	if err != nil {
@@ -292,6 +296,11 @@ func checkAndUpdateTriggerFields(mqt, newMqt *fv1.MessageQueueTrigger) bool {
 		updated = true
 	}
 
+	if newMqt.Spec.Paused != mqt.Spec.Paused {
+		mqt.Spec.Paused = newMqt.Spec.Paused
+		updated = true
+	}
+
 	if newMqt.Spec.MqtKind != mqt.Spec.MqtKind {
 		mqt.Spec.MqtKind = newMqt.Spec.MqtKind
 		updated = true
@@ -483,7 +492,7 @@ func deleteDeployment(name string, kubeClient *kubernetes.Clientset) error {
 }
 
 func getScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) *unstructured.Unstructured {
-	return &unstructured.Unstructured{
+	scaledObject := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"kind":       "ScaledObject",
 			"apiVersion": apiVersion,
@@ -520,6 +529,11 @@ func getScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) *un
 			},
 		},
 	}
+	if mqt.Spec.Paused {
+		// KEDA keeps the connector scaled to zero while the annotation is set
+		scaledObject.SetAnnotations(map[string]string{pausedReplicasAnnotation: "0"})
+	}
+	return scaledObject
 }
 
 func createScaledObject(mqt *fv1.MessageQueueTrigger, authenticationRef string) error {
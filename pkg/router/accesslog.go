@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// accessLogBufferSize is the number of entries buffered per subscriber.
+// Entries are dropped for subscribers that don't keep up.
+const accessLogBufferSize = 100
+
+type (
+	// accessLogEntry is a request the router forwarded to a function.
+	// Latency is the time until the response headers were received,
+	// Bytes is -1 if the function didn't set the content length.
+	accessLogEntry struct {
+		Timestamp time.Time `json:"timestamp"`
+		Function  string    `json:"function"`
+		Namespace string    `json:"namespace"`
+		Method    string    `json:"method"`
+		Path      string    `json:"path"`
+		Status    int       `json:"status"`
+		LatencyMs float64   `json:"latencyMs"`
+		Bytes     int64     `json:"bytes"`
+	}
+
+	// accessLogBroadcaster streams access log entries to the clients
+	// following the access log of a function.
+	accessLogBroadcaster struct {
+		lock        sync.Mutex
+		subscribers map[*accessLogSubscriber]struct{}
+	}
+
+	accessLogSubscriber struct {
+		function  string
+		namespace string
+		entries   chan accessLogEntry
+	}
+)
+
+var accessLog = makeAccessLogBroadcaster()
+
+func makeAccessLogBroadcaster() *accessLogBroadcaster {
+	return &accessLogBroadcaster{
+		subscribers: make(map[*accessLogSubscriber]struct{}),
+	}
+}
+
+func (b *accessLogBroadcaster) subscribe(function, namespace string) *accessLogSubscriber {
+	sub := &accessLogSubscriber{
+		function:  function,
+		namespace: namespace,
+		entries:   make(chan accessLogEntry, accessLogBufferSize),
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *accessLogBroadcaster) unsubscribe(sub *accessLogSubscriber) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.subscribers, sub)
+}
+
+func (b *accessLogBroadcaster) publish(entry accessLogEntry) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for sub := range b.subscribers {
+		if sub.function != entry.Function || sub.namespace != entry.Namespace {
+			continue
+		}
+		select {
+		case sub.entries <- entry:
+		default:
+		}
+	}
+}
+
+// handler streams the access log entries of the function given by the
+// "function" and "namespace" query parameters as JSON lines until the
+// client disconnects.
+func (b *accessLogBroadcaster) handler(w http.ResponseWriter, r *http.Request) {
+	function := r.URL.Query().Get("function")
+	if len(function) == 0 {
+		http.Error(w, "function query parameter is required", http.StatusBadRequest)
+		return
+	}
+	namespace := r.URL.Query().Get("namespace")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := b.subscribe(function, namespace)
+	defer b.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case entry := <-sub.entries:
+			if err := encoder.Encode(entry); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+)
+
+func TestAccessLogBroadcaster(t *testing.T) {
+	b := makeAccessLogBroadcaster()
+	sub := b.subscribe("foo", "default")
+
+	b.publish(accessLogEntry{Function: "bar", Namespace: "default", Status: 200})
+	b.publish(accessLogEntry{Function: "foo", Namespace: "other", Status: 200})
+	b.publish(accessLogEntry{Function: "foo", Namespace: "default", Status: 404})
+
+	select {
+	case entry := <-sub.entries:
+		if entry.Status != 404 {
+			t.Errorf("Expected the entry of function foo in namespace default, got %#v", entry)
+		}
+	default:
+		t.Fatalf("Expected an entry for the subscribed function")
+	}
+	if len(sub.entries) != 0 {
+		t.Errorf("Expected entries of other functions to be filtered, got %v more", len(sub.entries))
+	}
+
+	// entries are dropped instead of blocking the router
+	for i := 0; i < accessLogBufferSize+10; i++ {
+		b.publish(accessLogEntry{Function: "foo", Namespace: "default"})
+	}
+	if len(sub.entries) != accessLogBufferSize {
+		t.Errorf("Expected %v buffered entries, got %v", accessLogBufferSize, len(sub.entries))
+	}
+
+	b.unsubscribe(sub)
+	if len(b.subscribers) != 0 {
+		t.Errorf("Expected no subscribers after unsubscribe")
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/utils"
+)
+
+const (
+	asyncJobPending   = "pending"
+	asyncJobSucceeded = "succeeded"
+	asyncJobFailed    = "failed"
+
+	asyncJobKeyPrefix = "fission-async-job:"
+
+	// pending jobs are kept for at most this long, in case the router
+	// invoking the function goes away before saving the result
+	asyncJobPendingTTL = 24 * time.Hour
+
+	// asyncJobQueueSize is the number of jobs waiting for a worker,
+	// further invocations are rejected until a worker is free
+	asyncJobQueueSize = 1000
+)
+
+type (
+	// asyncJob is an asynchronous invocation of a function. Body is the
+	// response body of the function once the job completed.
+	asyncJob struct {
+		ID         string            `json:"id"`
+		Function   string            `json:"function"`
+		Namespace  string            `json:"namespace"`
+		Status     string            `json:"status"`
+		StatusCode int               `json:"statusCode,omitempty"`
+		Headers    map[string]string `json:"headers,omitempty"`
+		Body       []byte            `json:"body,omitempty"`
+		Error      string            `json:"error,omitempty"`
+		Created    time.Time         `json:"created"`
+		Completed  *time.Time        `json:"completed,omitempty"`
+	}
+
+	// asyncJobStore keeps the serialized jobs, so that the status can be
+	// polled from any router replica. get returns nil if the job doesn't
+	// exist or has expired.
+	asyncJobStore interface {
+		set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+		get(ctx context.Context, key string) ([]byte, error)
+	}
+
+	// asyncInvoker runs asynchronous invocations on a fixed number of
+	// workers. The function is invoked through the router itself, so async
+	// invocations are routed exactly like synchronous ones.
+	asyncInvoker struct {
+		logger    *zap.Logger
+		store     asyncJobStore
+		routerURL string
+		jobTTL    time.Duration
+		client    *http.Client
+		queue     chan asyncTask
+
+		// authenticator checks the token of invocations if set
+		authenticator *tokenAuthenticator
+
+		// fnTimeout returns the configured timeout of a function
+		fnTimeout func(namespace, name string) time.Duration
+	}
+
+	asyncTask struct {
+		job    *asyncJob
+		header http.Header
+		body   []byte
+	}
+
+	// memoryJobStore is used when no Redis is configured. The jobs are
+	// only visible on the router replica that accepted them.
+	memoryJobStore struct {
+		lock    sync.Mutex
+		entries map[string]memoryJobEntry
+	}
+
+	memoryJobEntry struct {
+		value   []byte
+		expires time.Time
+	}
+)
+
+func makeAsyncInvoker(ctx context.Context, logger *zap.Logger, store asyncJobStore, routerURL string, jobTTL time.Duration,
+	fnTimeout func(namespace, name string) time.Duration, workers int) *asyncInvoker {
+	ai := &asyncInvoker{
+		logger:    logger.Named("async_invoker"),
+		store:     store,
+		routerURL: routerURL,
+		jobTTL:    jobTTL,
+		// the client is shared by all jobs, so connections to the router are reused
+		client:    &http.Client{Transport: newDefaultTransport(false)},
+		queue:     make(chan asyncTask, asyncJobQueueSize),
+		fnTimeout: fnTimeout,
+	}
+	for i := 0; i < workers; i++ {
+		go ai.worker(ctx)
+	}
+	return ai
+}
+
+func (ai *asyncInvoker) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-ai.queue:
+			ai.run(ctx, task.job, task.header, task.body)
+		}
+	}
+}
+
+// invokeHandler accepts POST /async-invoke/{namespace}/{function}, starts
+// the invocation in the background and returns the job ID right away.
+func (ai *asyncInvoker) invokeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if ai.authenticator != nil && !ai.authenticator.authorize(w, r, r.URL.Path, []string{vars["function"]}) {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading request body", http.StatusBadRequest)
+		return
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		http.Error(w, "error generating job ID", http.StatusInternalServerError)
+		return
+	}
+	job := &asyncJob{
+		ID:        id.String(),
+		Function:  vars["function"],
+		Namespace: vars["namespace"],
+		Status:    asyncJobPending,
+		Created:   time.Now().UTC(),
+	}
+	err = ai.save(r.Context(), job, asyncJobPendingTTL)
+	if err != nil {
+		ai.logger.Error("error saving async job", zap.Error(err), zap.String("job", job.ID))
+		http.Error(w, "error saving job", http.StatusInternalServerError)
+		return
+	}
+
+	// the request context is canceled once the job ID is returned
+	select {
+	case ai.queue <- asyncTask{job: job, header: r.Header.Clone(), body: body}:
+	default:
+		job.Status = asyncJobFailed
+		job.Error = "too many pending asynchronous invocations"
+		err = ai.save(r.Context(), job, ai.jobTTL)
+		if err != nil {
+			ai.logger.Error("error saving async job", zap.Error(err), zap.String("job", job.ID))
+		}
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, job.Error, http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"jobID": job.ID}) //nolint: errcheck
+}
+
+// statusHandler serves GET /async-status/{id}.
+func (ai *asyncInvoker) statusHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := ai.store.get(r.Context(), asyncJobKeyPrefix+mux.Vars(r)["id"])
+	if err != nil {
+		ai.logger.Error("error loading async job", zap.Error(err))
+		http.Error(w, "error loading job", http.StatusInternalServerError)
+		return
+	}
+	if data == nil {
+		http.Error(w, "job not found or expired", http.StatusNotFound)
+		return
+	}
+	if ai.authenticator != nil {
+		job := &asyncJob{}
+		err = json.Unmarshal(data, job)
+		if err != nil {
+			ai.logger.Error("error parsing async job", zap.Error(err))
+			http.Error(w, "error loading job", http.StatusInternalServerError)
+			return
+		}
+		if !ai.authenticator.authorize(w, r, r.URL.Path, []string{job.Function}) {
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data) //nolint: errcheck
+}
+
+// run invokes the function of the job and saves the result. The
+// invocation is canceled after the timeout of the function, so that a
+// hung function never keeps the job pending.
+func (ai *asyncInvoker) run(ctx context.Context, job *asyncJob, header http.Header, body []byte) {
+	url := ai.routerURL + utils.UrlForFunction(job.Function, job.Namespace)
+
+	invokeCtx, cancel := context.WithTimeout(ctx, ai.fnTimeout(job.Namespace, job.Function))
+	err := ai.invoke(invokeCtx, job, url, header, body)
+	cancel()
+	if err != nil {
+		job.Status = asyncJobFailed
+		job.Error = err.Error()
+	}
+	completed := time.Now().UTC()
+	job.Completed = &completed
+
+	err = ai.save(ctx, job, ai.jobTTL)
+	if err != nil {
+		ai.logger.Error("error saving async job result", zap.Error(err), zap.String("job", job.ID))
+	}
+}
+
+func (ai *asyncInvoker) invoke(ctx context.Context, job *asyncJob, url string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req.Header = header
+
+	resp, err := ai.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error invoking function")
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "error reading function response")
+	}
+
+	job.StatusCode = resp.StatusCode
+	job.Body = respBody
+	job.Headers = make(map[string]string, len(resp.Header))
+	for k := range resp.Header {
+		job.Headers[k] = strings.Join(resp.Header.Values(k), ", ")
+	}
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("function returned status code %v", resp.StatusCode)
+	}
+	job.Status = asyncJobSucceeded
+	return nil
+}
+
+func (ai *asyncInvoker) save(ctx context.Context, job *asyncJob, ttl time.Duration) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return ai.store.set(ctx, asyncJobKeyPrefix+job.ID, data, ttl)
+}
+
+func makeMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{
+		entries: make(map[string]memoryJobEntry),
+	}
+}
+
+func (s *memoryJobStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	for k, e := range s.entries {
+		if now.After(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = memoryJobEntry{value: value, expires: now.Add(ttl)}
+	return nil
+}
+
+func (s *memoryJobStore) get(ctx context.Context, key string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, nil
+	}
+	return e.value, nil
+}
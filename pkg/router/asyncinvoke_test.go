@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func TestAsyncInvoker(t *testing.T) {
+	// stands in for the internal function route of the router
+	fnServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte("hello " + string(body))) //nolint: errcheck
+	}))
+	defer fnServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := makeAsyncInvokerServer(ctx, fnServer.URL, time.Minute)
+	defer server.Close()
+
+	job := invokeAsync(t, server.URL, "foo", "world")
+	if job.Status != asyncJobSucceeded || job.StatusCode != http.StatusOK || string(job.Body) != "hello world" {
+		t.Errorf("Expected a succeeded job, got %#v", job)
+	}
+
+	resp, err := http.Get(server.URL + "/async-status/unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown job, got %v", resp.StatusCode)
+	}
+}
+
+func TestAsyncInvokerTimeout(t *testing.T) {
+	done := make(chan struct{})
+	fnServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer fnServer.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := makeAsyncInvokerServer(ctx, fnServer.URL, 50*time.Millisecond)
+	defer server.Close()
+
+	job := invokeAsync(t, server.URL, "hung", "")
+	if job.Status != asyncJobFailed || !strings.Contains(job.Error, "deadline exceeded") {
+		t.Errorf("Expected the job to fail after the function timeout, got %#v", job)
+	}
+}
+
+func makeAsyncInvokerServer(ctx context.Context, routerURL string, fnTimeout time.Duration) *httptest.Server {
+	ai := makeAsyncInvoker(ctx, zap.NewNop(), makeMemoryJobStore(), routerURL, time.Minute,
+		func(namespace, name string) time.Duration { return fnTimeout }, 2)
+	r := mux.NewRouter()
+	r.HandleFunc("/async-invoke/{namespace}/{function}", ai.invokeHandler).Methods("POST")
+	r.HandleFunc("/async-status/{id}", ai.statusHandler).Methods("GET")
+	return httptest.NewServer(r)
+}
+
+// invokeAsync invokes the function asynchronously and polls the job
+// until it's completed.
+func invokeAsync(t *testing.T, serverURL string, fnName string, body string) asyncJob {
+	resp, err := http.Post(serverURL+"/async-invoke/default/"+fnName, "text/plain", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var accepted map[string]string
+	err = json.NewDecoder(resp.Body).Decode(&accepted)
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusAccepted || len(accepted["jobID"]) == 0 {
+		t.Fatalf("Expected a job ID, got status %v, %v, %v", resp.StatusCode, accepted, err)
+	}
+
+	var job asyncJob
+	for i := 0; i < 100; i++ {
+		resp, err = http.Get(serverURL + "/async-status/" + accepted["jobID"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&job)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status != asyncJobPending {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return job
+}
+
+func TestMemoryJobStoreExpiry(t *testing.T) {
+	s := makeMemoryJobStore()
+	ctx := context.Background()
+	s.set(ctx, "expired", []byte("a"), -time.Second) //nolint: errcheck
+	s.set(ctx, "live", []byte("b"), time.Minute)     //nolint: errcheck
+
+	if v, _ := s.get(ctx, "expired"); v != nil {
+		t.Errorf("Expected expired entry to be gone, got %q", v)
+	}
+	if v, _ := s.get(ctx, "live"); string(v) != "b" {
+		t.Errorf("Expected live entry, got %q", v)
+	}
+	if _, ok := s.entries["expired"]; ok {
+		t.Errorf("Expected expired entries to be cleaned up on set")
+	}
+}
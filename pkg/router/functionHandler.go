@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"math/rand"
 	"net"
@@ -52,6 +53,9 @@ const (
 
 	// X_FORWARDED_HOST represents the 'X_FORWARDED_HOST' request header
 	X_FORWARDED_HOST = "X-Forwarded-Host"
+
+	// X_REQUEST_ID represents the 'X-Request-ID' request header
+	X_REQUEST_ID = "X-Request-ID"
 )
 
 type (
@@ -69,6 +73,7 @@ type (
 		functionTimeoutMap       map[k8stypes.UID]int
 		unTapServiceTimeout      time.Duration
 		openTracingEnabled       bool
+		rateLimiters             *functionRateLimiterMap
 	}
 
 	tsRoundTripperParams struct {
@@ -100,6 +105,10 @@ type (
 		serviceURL       *url.URL
 		urlFromCache     bool
 		totalRetry       int
+
+		// coldStart is the time spent waiting for the executor to
+		// return a function pod, zero if the service came from cache.
+		coldStart time.Duration
 	}
 
 	// To keep the request body open during retries, we create an interface with Close operation being a no-op.
@@ -227,7 +236,11 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 				"function-name":      fnMeta.Name,
 				"function-namespace": fnMeta.Namespace})...)
 			// get function service url from cache or executor
+			lookupStart := time.Now()
 			roundTripper.serviceURL, roundTripper.urlFromCache, err = roundTripper.funcHandler.getServiceEntry(ctx)
+			if err == nil && !roundTripper.urlFromCache {
+				roundTripper.coldStart += time.Since(lookupStart)
+			}
 			if err != nil {
 				// We might want a specific error code or header for fission failures as opposed to
 				// user function bugs.
@@ -416,6 +429,10 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 // getDefaultTransport returns a pointer to new copy of http.Transport object to prevent
 // the value of http.DefaultTransport from being changed by goroutines.
 func (roundTripper RetryingRoundTripper) getDefaultTransport() *http.Transport {
+	return newDefaultTransport(roundTripper.funcHandler.tsRoundTripperParams.disableKeepAlive)
+}
+
+func newDefaultTransport(disableKeepAlive bool) *http.Transport {
 	// The transport setup here follows the configurations of http.DefaultTransport
 	// but without Dialer since we will change it later.
 	return &http.Transport{
@@ -428,7 +445,7 @@ func (roundTripper RetryingRoundTripper) getDefaultTransport() *http.Transport {
 		// https://github.com/fission/fission/issues/723#issuecomment-398781995
 		// You can change it by setting environment variable "ROUTER_ROUND_TRIP_DISABLE_KEEP_ALIVE"
 		// of router or helm variable "disableKeepAlive" before installation to false.
-		DisableKeepAlives: roundTripper.funcHandler.tsRoundTripperParams.disableKeepAlive,
+		DisableKeepAlives: disableKeepAlive,
 	}
 }
 
@@ -462,7 +479,19 @@ func (fh *functionHandler) tapService(fn *fv1.Function, serviceURL *url.URL) {
 }
 
 func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *http.Request) {
-	if fh.httpTrigger != nil && fh.httpTrigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
+	if fh.httpTrigger != nil && len(fh.httpTrigger.Spec.FunctionWeightDistribution) > 0 {
+		// A/B testing. the same request ID always goes to the same function
+		fn := getWeightedBackend(fh.functionMap, fh.fnWeightDistributionList, request.Header.Get(X_REQUEST_ID))
+		if fn == nil {
+			fh.logger.Error("could not get weighted backend",
+				zap.Any("fnMap", fh.functionMap),
+				zap.Any("distributionList", fh.fnWeightDistributionList))
+			http.Error(responseWriter, "no function to route the request to", http.StatusInternalServerError)
+			return
+		}
+		fh.function = fn
+		fh.logger.Debug("chosen function backend's metadata", zap.Any("metadata", fh.function))
+	} else if fh.httpTrigger != nil && fh.httpTrigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
 		// canary deployment. need to determine the function to send request to now
 		fn := getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
 		if fn == nil {
@@ -476,6 +505,14 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		fh.logger.Debug("chosen function backend's metadata", zap.Any("metadata", fh.function))
 	}
 
+	if limiter := fh.rateLimiters.get(fh.function); limiter != nil && !limiter.Allow() {
+		// limits are at least one request per second, a token is
+		// available again within a second
+		responseWriter.Header().Set("Retry-After", "1")
+		http.Error(responseWriter, "function rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
 	// url path
 	setPathInfoToHeader(request)
 
@@ -507,6 +544,7 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		Transport:    rrt,
 		ErrorHandler: fh.getProxyErrorHandler(start, rrt),
 		ModifyResponse: func(resp *http.Response) error {
+			setTimingHeaders(request, resp, rrt.coldStart)
 			go fh.collectFunctionMetric(start, rrt, request, resp)
 			return nil
 		},
@@ -529,6 +567,19 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 	proxy.ServeHTTP(responseWriter, request)
 }
 
+// setTimingHeaders echoes the invoke time sent by 'fission fn test' and
+// adds how long the request waited for a function pod, so that the
+// cold-start overhead can be told apart from the execution time.
+// Requests without the invoke time header are left untouched.
+func setTimingHeaders(req *http.Request, resp *http.Response, coldStart time.Duration) {
+	invokeTime := req.Header.Get(fv1.HeaderInvokeTime)
+	if len(invokeTime) == 0 {
+		return
+	}
+	resp.Header.Set(fv1.HeaderRouterTime, invokeTime)
+	resp.Header.Set(fv1.HeaderColdStartDuration, coldStart.String())
+}
+
 // findCeil picks a function from the functionWeightDistribution list based on the
 // random number generated. It uses the prefix calculated for the function weights.
 func findCeil(randomNumber int, wtDistrList []functionWeightDistribution) string {
@@ -561,6 +612,34 @@ func getCanaryBackend(fnMap map[string]*fv1.Function, fnWtDistributionList []fun
 	return fnMap[fnName]
 }
 
+// getWeightedBackend picks a function to route to by hashing the request ID
+// modulo the total weight, or at random if the request has no ID.
+func getWeightedBackend(fnMap map[string]*fv1.Function, fnWtDistributionList []functionWeightDistribution, requestID string) *fv1.Function {
+	if len(fnWtDistributionList) == 0 {
+		return nil
+	}
+	total := fnWtDistributionList[len(fnWtDistributionList)-1].sumPrefix
+	if total <= 0 {
+		return fnMap[fnWtDistributionList[0].name]
+	}
+
+	var n int
+	if len(requestID) > 0 {
+		h := fnv.New32a()
+		h.Write([]byte(requestID))
+		n = int(h.Sum32() % uint32(total))
+	} else {
+		n = rand.Intn(total)
+	}
+
+	for _, wt := range fnWtDistributionList {
+		if n < wt.sumPrefix {
+			return fnMap[wt.name]
+		}
+	}
+	return nil
+}
+
 // addForwardedHostHeader add "forwarded host" to request header
 func (roundTripper RetryingRoundTripper) addForwardedHostHeader(req *http.Request) {
 	// for more detailed information, please visit:
@@ -811,6 +890,17 @@ func (fh functionHandler) collectFunctionMetric(start time.Time, rrt *RetryingRo
 	functionCallCompleted(funcMetricLabels, httpMetricLabels,
 		duration, duration, resp.ContentLength)
 
+	accessLog.publish(accessLogEntry{
+		Timestamp: start,
+		Function:  fh.function.ObjectMeta.Name,
+		Namespace: fh.function.ObjectMeta.Namespace,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Status:    resp.StatusCode,
+		LatencyMs: float64(duration) / float64(time.Millisecond),
+		Bytes:     resp.ContentLength,
+	})
+
 	// tapService before invoking roundTrip for the serviceUrl
 	if rrt.urlFromCache {
 		fh.tapService(fh.function, rrt.serviceURL)
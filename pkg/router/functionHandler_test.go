@@ -19,6 +19,7 @@ package router
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -67,3 +68,43 @@ func TestProxyErrorHandler(t *testing.T) {
 	errHandler(respRecorder, req, errors.New("dummy"))
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
 }
+
+func TestGetWeightedBackend(t *testing.T) {
+	fnMap := map[string]*fv1.Function{
+		"foo": {ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		"bar": {ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+	}
+	list := []functionWeightDistribution{
+		{name: "foo", weight: 70, sumPrefix: 70},
+		{name: "bar", weight: 30, sumPrefix: 100},
+	}
+
+	// the same request ID is always routed to the same function
+	fn := getWeightedBackend(fnMap, list, "request-1")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, fn, getWeightedBackend(fnMap, list, "request-1"))
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		fn := getWeightedBackend(fnMap, list, fmt.Sprintf("request-%v", i))
+		counts[fn.ObjectMeta.Name]++
+	}
+	assert.InDelta(t, 700, counts["foo"], 100)
+	assert.InDelta(t, 300, counts["bar"], 100)
+
+	assert.Nil(t, getWeightedBackend(fnMap, nil, "request-1"))
+}
+
+func TestSetTimingHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := &http.Response{Header: make(http.Header)}
+	setTimingHeaders(req, resp, time.Second)
+	assert.Empty(t, resp.Header)
+
+	invokeTime := time.Now().Format(time.RFC3339Nano)
+	req.Header.Set(fv1.HeaderInvokeTime, invokeTime)
+	setTimingHeaders(req, resp, 1500*time.Millisecond)
+	assert.Equal(t, invokeTime, resp.Header.Get(fv1.HeaderRouterTime))
+	assert.Equal(t, "1.5s", resp.Header.Get(fv1.HeaderColdStartDuration))
+}
@@ -53,6 +53,9 @@ type (
 		resolveResultType
 		functionMap                map[string]*fv1.Function
 		functionWtDistributionList []functionWeightDistribution
+		// warning is set when the result differs from what the
+		// trigger asked for, e.g. weights which don't sum to 100
+		warning string
 	}
 
 	// namespacedTriggerReference is just a trigger reference plus a
@@ -67,6 +70,7 @@ type (
 const (
 	resolveResultSingleFunction = iota
 	resolveResultMultipleFunctions
+	resolveResultWeightDistribution
 )
 
 func makeFunctionReferenceResolver(funcInformer *k8sCache.SharedIndexInformer) *functionReferenceResolver {
@@ -95,14 +99,20 @@ func (frr *functionReferenceResolver) resolve(trigger fv1.HTTPTrigger) (*resolve
 	// resolve on cache miss
 	var rr *resolveResult
 
-	switch trigger.Spec.FunctionReference.Type {
-	case fv1.FunctionReferenceTypeFunctionName:
+	switch {
+	case len(trigger.Spec.FunctionWeightDistribution) > 0:
+		rr, err = frr.resolveByWeightDistribution(nfr.namespace, trigger.Spec.FunctionWeightDistribution)
+		if err != nil {
+			return nil, err
+		}
+
+	case trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionName:
 		rr, err = frr.resolveByName(nfr.namespace, trigger.Spec.FunctionReference.Name)
 		if err != nil {
 			return nil, err
 		}
 
-	case fv1.FunctionReferenceTypeFunctionWeights:
+	case trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights:
 		rr, err = frr.resolveByFunctionWeights(nfr.namespace, &trigger.Spec.FunctionReference)
 		if err != nil {
 			return nil, err
@@ -187,6 +197,53 @@ func (frr *functionReferenceResolver) resolveByFunctionWeights(namespace string,
 	return &rr, nil
 }
 
+// resolveByWeightDistribution looks up the functions of a weight
+// distribution, keeping their order. If the weights don't sum to 100,
+// all requests are routed to the first function.
+func (frr *functionReferenceResolver) resolveByWeightDistribution(namespace string, distribution []fv1.FunctionWeight) (*resolveResult, error) {
+	functionMap := make(map[string]*fv1.Function)
+	fnWtDistrList := make([]functionWeightDistribution, 0, len(distribution))
+	sumPrefix := 0
+
+	for _, fw := range distribution {
+		obj, isExist, err := (*frr.funcInformer).GetStore().Get(&fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      fw.Name,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !isExist {
+			return nil, errors.Errorf("function %v does not exist", fw.Name)
+		}
+
+		functionMap[fw.Name] = obj.(*fv1.Function)
+		sumPrefix = sumPrefix + fw.Weight
+		fnWtDistrList = append(fnWtDistrList, functionWeightDistribution{
+			name:      fw.Name,
+			weight:    fw.Weight,
+			sumPrefix: sumPrefix,
+		})
+	}
+
+	rr := resolveResult{
+		resolveResultType:          resolveResultWeightDistribution,
+		functionMap:                functionMap,
+		functionWtDistributionList: fnWtDistrList,
+	}
+
+	if sumPrefix != 100 {
+		rr.warning = fmt.Sprintf("function weights sum to %v instead of 100, routing all requests to function %v", sumPrefix, distribution[0].Name)
+		rr.functionWtDistributionList = []functionWeightDistribution{
+			{name: distribution[0].Name, weight: 100, sumPrefix: 100},
+		}
+	}
+
+	return &rr, nil
+}
+
 func (frr *functionReferenceResolver) delete(namespace string, triggerName, triggerRV string) error {
 	nfr := namespacedTriggerReference{
 		namespace:              namespace,
@@ -57,6 +57,25 @@ type HTTPTriggerSet struct {
 	isDebugEnv                 bool
 	svcAddrUpdateThrottler     *throttler.Throttler
 	unTapServiceTimeout        time.Duration
+	rateLimiters               *functionRateLimiterMap
+	asyncInvoker               *asyncInvoker
+	authenticator              *tokenAuthenticator
+	// resource version of the triggers whose resolve warning was logged,
+	// so the warning isn't repeated on every router rebuild
+	warnedTriggers map[types.UID]string
+}
+
+// functionTimeout returns the configured timeout of the function, or the
+// default timeout if the function doesn't set one or isn't known.
+func (ts *HTTPTriggerSet) functionTimeout(namespace, name string) time.Duration {
+	timeout := fv1.DEFAULT_FUNCTION_TIMEOUT
+	obj, ok, err := ts.funcInformer.GetStore().GetByKey(namespace + "/" + name)
+	if err == nil && ok {
+		if fn := obj.(*fv1.Function); fn.Spec.FunctionTimeout > 0 {
+			timeout = fn.Spec.FunctionTimeout
+		}
+	}
+	return time.Duration(timeout) * time.Second
 }
 
 func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionClient *crd.FissionClient,
@@ -74,6 +93,8 @@ func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionCli
 		isDebugEnv:                 isDebugEnv,
 		svcAddrUpdateThrottler:     actionThrottler,
 		unTapServiceTimeout:        unTapServiceTimeout,
+		rateLimiters:               makeFunctionRateLimiterMap(),
+		warnedTriggers:             make(map[types.UID]string),
 	}
 
 	informerFactory := genInformer.NewSharedInformerFactory(fissionClient, time.Minute*30)
@@ -117,6 +138,7 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 
 	// HTTP triggers setup by the user
 	homeHandled := false
+	warnedTriggers := make(map[types.UID]string)
 	for i := range ts.triggers {
 		trigger := ts.triggers[i]
 
@@ -131,10 +153,17 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			continue
 		}
 
-		if rr.resolveResultType != resolveResultSingleFunction && rr.resolveResultType != resolveResultMultipleFunctions {
+		if rr.resolveResultType != resolveResultSingleFunction && rr.resolveResultType != resolveResultMultipleFunctions &&
+			rr.resolveResultType != resolveResultWeightDistribution {
 			// not implemented yet
 			ts.logger.Panic("resolve result type not implemented", zap.Any("type", rr.resolveResultType))
 		}
+		if len(rr.warning) > 0 {
+			if ts.warnedTriggers[trigger.ObjectMeta.UID] != trigger.ObjectMeta.ResourceVersion {
+				ts.logger.Warn(rr.warning, zap.String("trigger", trigger.ObjectMeta.Name), zap.String("namespace", trigger.ObjectMeta.Namespace))
+			}
+			warnedTriggers[trigger.ObjectMeta.UID] = trigger.ObjectMeta.ResourceVersion
+		}
 
 		fh := &functionHandler{
 			logger:                   ts.logger.Named(trigger.ObjectMeta.Name),
@@ -149,6 +178,7 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			functionTimeoutMap:       fnTimeoutMap,
 			unTapServiceTimeout:      ts.unTapServiceTimeout,
 			openTracingEnabled:       openTracingEnabled,
+			rateLimiters:             ts.rateLimiters,
 		}
 
 		// The functionHandler for HTTP trigger with fn reference type "FunctionReferenceTypeFunctionName",
@@ -188,6 +218,17 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 				handler = otel.GetHandlerWithOTEL(http.HandlerFunc(fh.handler), trigger.Spec.RelativeURL)
 			}
 		}
+		if ts.authenticator != nil {
+			route := trigger.Spec.RelativeURL
+			if trigger.Spec.Prefix != nil && *trigger.Spec.Prefix != "" {
+				route = *trigger.Spec.Prefix
+			}
+			functions := make([]string, 0, len(rr.functionMap))
+			for name := range rr.functionMap {
+				functions = append(functions, name)
+			}
+			handler = ts.authenticator.wrap(handler, route, functions)
+		}
 
 		if trigger.Spec.Prefix != nil && *trigger.Spec.Prefix != "" {
 			prefix := *trigger.Spec.Prefix
@@ -249,6 +290,7 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			svcAddrUpdateThrottler: ts.svcAddrUpdateThrottler,
 			functionTimeoutMap:     fnTimeoutMap,
 			unTapServiceTimeout:    ts.unTapServiceTimeout,
+			rateLimiters:           ts.rateLimiters,
 		}
 
 		var handler http.Handler
@@ -264,9 +306,17 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 		ts.logger.Debug("add internal handler and prefix route for function", zap.String("router", internalRoute), zap.Any("function", fn))
 	}
 
+	// Asynchronous invocations, the function is invoked through the
+	// internal route above.
+	if ts.asyncInvoker != nil {
+		muxRouter.HandleFunc("/async-invoke/{namespace}/{function}", ts.asyncInvoker.invokeHandler).Methods("POST")
+		muxRouter.HandleFunc("/async-status/{id}", ts.asyncInvoker.statusHandler).Methods("GET")
+	}
+
 	// Healthz endpoint for the router.
 	muxRouter.HandleFunc("/router-healthz", routerHealthHandler).Methods("GET")
 
+	ts.warnedTriggers = warnedTriggers
 	return muxRouter
 }
 
@@ -365,6 +415,7 @@ func (ts *HTTPTriggerSet) updateRouter() {
 			functions = append(functions, *f.(*fv1.Function))
 		}
 		ts.functions = functions
+		ts.rateLimiters.prune(functions)
 
 		// make a new router and use it
 		ts.mutableRouter.updateRouter(ts.getRouter(functionTimeout))
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+type (
+	// functionRateLimiterMap holds the rate limiters of the functions
+	// with a rate limit. It outlives the function handlers, which are
+	// recreated every time a function or trigger changes.
+	functionRateLimiterMap struct {
+		lock     sync.Mutex
+		limiters map[types.UID]*functionRateLimiter
+	}
+
+	functionRateLimiter struct {
+		rps     int
+		limiter *rate.Limiter
+	}
+)
+
+func makeFunctionRateLimiterMap() *functionRateLimiterMap {
+	return &functionRateLimiterMap{
+		limiters: make(map[types.UID]*functionRateLimiter),
+	}
+}
+
+// get returns the rate limiter of the function, or nil if the function
+// has no rate limit. The limiter is replaced when the limit changes.
+func (m *functionRateLimiterMap) get(fn *fv1.Function) *rate.Limiter {
+	if m == nil || fn == nil || fn.Spec.RateLimit <= 0 {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	l, ok := m.limiters[fn.ObjectMeta.UID]
+	if !ok || l.rps != fn.Spec.RateLimit {
+		// allow bursts of up to one second worth of requests
+		l = &functionRateLimiter{
+			rps:     fn.Spec.RateLimit,
+			limiter: rate.NewLimiter(rate.Limit(fn.Spec.RateLimit), fn.Spec.RateLimit),
+		}
+		m.limiters[fn.ObjectMeta.UID] = l
+	}
+	return l.limiter
+}
+
+// prune removes the limiters of functions that no longer exist.
+func (m *functionRateLimiterMap) prune(fns []fv1.Function) {
+	existing := make(map[types.UID]struct{}, len(fns))
+	for _, fn := range fns {
+		existing[fn.ObjectMeta.UID] = struct{}{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for uid := range m.limiters {
+		if _, ok := existing[uid]; !ok {
+			delete(m.limiters, uid)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestFunctionRateLimiterMap(t *testing.T) {
+	m := makeFunctionRateLimiterMap()
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "foo-uid"},
+	}
+
+	if m.get(fn) != nil {
+		t.Errorf("Expected no limiter for function without rate limit")
+	}
+
+	fn.Spec.RateLimit = 2
+	l := m.get(fn)
+	if l == nil {
+		t.Fatalf("Expected limiter for function with rate limit")
+	}
+	if !l.Allow() || !l.Allow() {
+		t.Errorf("Expected a burst of 2 requests to be allowed")
+	}
+	if l.Allow() {
+		t.Errorf("Expected the third request to be rejected")
+	}
+	if m.get(fn) != l {
+		t.Errorf("Expected the same limiter while the rate limit is unchanged")
+	}
+
+	fn.Spec.RateLimit = 5
+	if m.get(fn) == l {
+		t.Errorf("Expected a new limiter after the rate limit changed")
+	}
+
+	m.prune(nil)
+	if len(m.limiters) != 0 {
+		t.Errorf("Expected limiters of deleted functions to be removed, got %v", len(m.limiters))
+	}
+}
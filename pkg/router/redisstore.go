@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// redisJobStore keeps async jobs in Redis, so that all router replicas
+// see the same jobs, jobs survive router restarts and Redis expires them.
+type redisJobStore struct {
+	client *redis.Client
+}
+
+// makeRedisJobStore parses a URL of the form
+// redis://[:password@]host[:port][/db].
+func makeRedisJobStore(redisURL string) (*redisJobStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing redis URL")
+	}
+	return &redisJobStore{client: redis.NewClient(opts)}, nil
+}
+
+func (s *redisJobStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (s *redisJobStore) get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return value, err
+}
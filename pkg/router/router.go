@@ -120,13 +120,16 @@ func serve(ctx context.Context, logger *zap.Logger, port int, tracingSamplingRat
 func serveMetric(logger *zap.Logger) {
 	// Expose the registered metrics via HTTP.
 	http.Handle("/metrics", promhttp.Handler())
+	// The access log is served on the metrics port, which is not exposed
+	// by the router service.
+	http.HandleFunc("/access-log", accessLog.handler)
 	err := http.ListenAndServe(metricAddr, nil)
 
 	logger.Fatal("done listening on metrics endpoint", zap.Error(err))
 }
 
 // Start starts a router
-func Start(ctx context.Context, logger *zap.Logger, port int, executorURL string, openTracingEnabled bool) {
+func Start(ctx context.Context, logger *zap.Logger, port int, executorURL string, asyncRedisURL string, openTracingEnabled bool) {
 	fmap := makeFunctionServiceMap(logger, time.Minute)
 
 	fissionClient, kubeClient, _, _, err := crd.MakeFissionClient()
@@ -253,6 +256,46 @@ func Start(ctx context.Context, logger *zap.Logger, port int, executorURL string
 		svcAddrRetryCount: svcAddrRetryCount,
 	}, isDebugEnv, unTapServiceTimeout, throttler.MakeThrottler(svcAddrUpdateTimeout))
 
+	// async invocation jobs are kept in Redis if configured, so that the
+	// status can be polled from any router replica
+	var jobStore asyncJobStore = makeMemoryJobStore()
+	if len(asyncRedisURL) > 0 {
+		jobStore, err = makeRedisJobStore(asyncRedisURL)
+		if err != nil {
+			logger.Fatal("error configuring async invocation job store", zap.Error(err))
+		}
+	} else {
+		logger.Info("no Redis configured for async invocation jobs, jobs are only visible on this router replica")
+	}
+	asyncJobTTLStr := os.Getenv("ROUTER_ASYNC_JOB_TTL")
+	asyncJobTTL, err := time.ParseDuration(asyncJobTTLStr)
+	if err != nil {
+		asyncJobTTL = time.Hour
+		logger.Info("failed to parse async job TTL from 'ROUTER_ASYNC_JOB_TTL' - set to the default value",
+			zap.Error(err),
+			zap.String("value", asyncJobTTLStr),
+			zap.Duration("default", asyncJobTTL))
+	}
+	asyncWorkers, err := strconv.Atoi(os.Getenv("ROUTER_ASYNC_WORKERS"))
+	if err != nil || asyncWorkers <= 0 {
+		asyncWorkers = 50
+		logger.Info("failed to parse async workers from 'ROUTER_ASYNC_WORKERS' - set to the default value",
+			zap.Int("default", asyncWorkers))
+	}
+	triggers.asyncInvoker = makeAsyncInvoker(ctx, logger, jobStore, fmt.Sprintf("http://127.0.0.1:%v", port),
+		asyncJobTTL, triggers.functionTimeout, asyncWorkers)
+
+	authEnabled, _ := strconv.ParseBool(os.Getenv("ROUTER_AUTH_ENABLED"))
+	if authEnabled {
+		signingKey := os.Getenv("JWT_SIGNING_KEY")
+		if len(signingKey) == 0 {
+			logger.Fatal("ROUTER_AUTH_ENABLED is set but JWT_SIGNING_KEY is empty")
+		}
+		triggers.authenticator = makeTokenAuthenticator(logger, []byte(signingKey))
+		triggers.asyncInvoker.authenticator = triggers.authenticator
+		logger.Info("token authentication enabled for HTTP triggers and async invocations")
+	}
+
 	go serveMetric(logger)
 
 	logger.Info("starting router", zap.Int("port", port))
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/auth"
+)
+
+// tokenAuthenticator checks the tokens issued by the controller
+// ('fission auth token') on the requests to HTTP triggers and to async
+// invocations. The internal function routes used by the other trigger
+// types aren't checked.
+type tokenAuthenticator struct {
+	logger *zap.Logger
+	key    []byte
+}
+
+func makeTokenAuthenticator(logger *zap.Logger, key []byte) *tokenAuthenticator {
+	return &tokenAuthenticator{
+		logger: logger.Named("token_authenticator"),
+		key:    key,
+	}
+}
+
+// authorize returns true if the request carries a valid Bearer token
+// allowing the trigger path or all functions of the trigger, otherwise
+// the request is rejected. The token isn't passed on to the function.
+func (ta *tokenAuthenticator) authorize(w http.ResponseWriter, r *http.Request, path string, functions []string) bool {
+	header := r.Header.Get("Authorization")
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return false
+	}
+	claims, err := auth.Verify(header[len("Bearer "):], ta.key)
+	if err != nil {
+		ta.logger.Debug("rejected request with invalid token", zap.Error(err), zap.String("path", path))
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return false
+	}
+	if !claims.Allows(path, functions) {
+		http.Error(w, "token doesn't allow invoking this function", http.StatusForbidden)
+		return false
+	}
+	r.Header.Del("Authorization")
+	return true
+}
+
+// wrap returns a handler serving the requests authorized for a trigger.
+func (ta *tokenAuthenticator) wrap(handler http.Handler, path string, functions []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ta.authorize(w, r, path, functions) {
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/fission/fission/pkg/auth"
+)
+
+func TestTokenAuthenticator(t *testing.T) {
+	key := []byte("secret")
+	now := time.Now()
+	sign := func(claims auth.Claims) string {
+		claims.IssuedAt = now.Unix()
+		claims.ExpiresAt = now.Add(time.Hour).Unix()
+		token, err := auth.Sign(&claims, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	var forwardedAuth string
+	handler := makeTokenAuthenticator(zap.NewNop(), key).wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardedAuth = r.Header.Get("Authorization")
+	}), "/hello", []string{"hello"})
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "not bearer", header: "Basic abc", want: http.StatusUnauthorized},
+		{name: "invalid token", header: "Bearer abc", want: http.StatusUnauthorized},
+		{name: "other function", header: "Bearer " + sign(auth.Claims{Functions: []string{"bye"}}), want: http.StatusForbidden},
+		{name: "function", header: "Bearer " + sign(auth.Claims{Functions: []string{"hello"}}), want: http.StatusOK},
+		{name: "path", header: "bearer " + sign(auth.Claims{Paths: []string{"/hello"}}), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwardedAuth = ""
+			req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+			if len(tt.header) > 0 {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %v, want %v", w.Code, tt.want)
+			}
+			if len(forwardedAuth) > 0 {
+				t.Errorf("token passed on to the function")
+			}
+		})
+	}
+}
@@ -55,6 +55,11 @@ func GetIngressSpec(namespace string, trigger *fv1.HTTPTrigger) *v1.Ingress {
 		}
 	}
 
+	var ingClass *string
+	if len(trigger.Spec.IngressConfig.IngressClass) > 0 {
+		ingClass = &trigger.Spec.IngressConfig.IngressClass
+	}
+
 	var pathType v1.PathType = v1.PathTypeImplementationSpecific
 	ing := &v1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -67,7 +72,8 @@ func GetIngressSpec(namespace string, trigger *fv1.HTTPTrigger) *v1.Ingress {
 			Annotations: trigger.Spec.IngressConfig.Annotations,
 		},
 		Spec: v1.IngressSpec{
-			TLS: ingTLS,
+			IngressClassName: ingClass,
+			TLS:              ingTLS,
 			Rules: []v1.IngressRule{
 				{
 					Host: host,
@@ -99,9 +105,10 @@ func GetIngressSpec(namespace string, trigger *fv1.HTTPTrigger) *v1.Ingress {
 func GetDeployLabels(trigger *fv1.HTTPTrigger) map[string]string {
 	// TODO: support function weight
 	return map[string]string{
-		"triggerName":      trigger.ObjectMeta.Name,
-		"functionName":     trigger.Spec.FunctionReference.Name,
-		"triggerNamespace": trigger.ObjectMeta.Namespace,
+		"triggerName":        trigger.ObjectMeta.Name,
+		"functionName":       trigger.Spec.FunctionReference.Name,
+		"triggerNamespace":   trigger.ObjectMeta.Namespace,
+		"fission.io/managed": "true",
 	}
 }
 
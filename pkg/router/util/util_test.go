@@ -32,6 +32,7 @@ func TestGetIngressSpec(t *testing.T) {
 		trigger   *fv1.HTTPTrigger
 	}
 	var pathType v1.PathType = v1.PathTypeImplementationSpecific
+	ingressClass := "nginx"
 	tests := []struct {
 		name string
 		args args
@@ -61,9 +62,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -121,9 +123,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:      "foo",
 					Namespace: "foobarNS",
@@ -183,9 +186,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -243,9 +247,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -303,9 +308,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -364,9 +370,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -424,9 +431,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:        "foo",
 					Namespace:   "foobarNS",
@@ -486,9 +494,10 @@ func TestGetIngressSpec(t *testing.T) {
 			want: &v1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"triggerName":      "foo",
-						"functionName":     "foofunc",
-						"triggerNamespace": "bar",
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
 					},
 					Name:      "foo",
 					Namespace: "foobarNS",
@@ -531,6 +540,67 @@ func TestGetIngressSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ingress-class",
+			args: args{
+				ingressNS: "foobarNS",
+				trigger: &fv1.HTTPTrigger{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "bar",
+					},
+					Spec: fv1.HTTPTriggerSpec{
+						RelativeURL: "/foo/bar",
+						FunctionReference: fv1.FunctionReference{
+							Name: "foofunc",
+						},
+						IngressConfig: fv1.IngressConfig{
+							Host:         "test.com",
+							Path:         "/foo/bar",
+							IngressClass: "nginx",
+						},
+					},
+				},
+			},
+			want: &v1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"triggerName":        "foo",
+						"functionName":       "foofunc",
+						"triggerNamespace":   "bar",
+						"fission.io/managed": "true",
+					},
+					Name:      "foo",
+					Namespace: "foobarNS",
+				},
+				Spec: v1.IngressSpec{
+					IngressClassName: &ingressClass,
+					Rules: []v1.IngressRule{
+						{
+							Host: "test.com",
+							IngressRuleValue: v1.IngressRuleValue{
+								HTTP: &v1.HTTPIngressRuleValue{
+									Paths: []v1.HTTPIngressPath{
+										{
+											Backend: v1.IngressBackend{
+												Service: &v1.IngressServiceBackend{
+													Name: "router",
+													Port: v1.ServiceBackendPort{
+														Number: 80,
+													},
+												},
+											},
+											Path:     "/foo/bar",
+											PathType: &pathType,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -569,9 +639,10 @@ func TestGetDeployLabels(t *testing.T) {
 				},
 			},
 			want: map[string]string{
-				"triggerName":      "foo",
-				"functionName":     "foobar",
-				"triggerNamespace": "bar",
+				"triggerName":        "foo",
+				"functionName":       "foobar",
+				"triggerNamespace":   "bar",
+				"fission.io/managed": "true",
 			},
 		},
 	}
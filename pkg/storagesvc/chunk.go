@@ -0,0 +1,369 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// HeaderUploadID identifies the chunks belonging to one upload
+	HeaderUploadID = "X-Fission-Upload-ID"
+	// HeaderChunkIndex is the zero based index of the uploaded chunk
+	HeaderChunkIndex = "X-Fission-Chunk-Index"
+	// HeaderChunkCount is the number of chunks to assemble on commit
+	HeaderChunkCount = "X-Fission-Chunk-Count"
+	// HeaderFileChecksum is the SHA-256 of the whole file, verified on commit
+	HeaderFileChecksum = "X-Fission-File-Checksum"
+
+	// chunkUploadTTL is how long the chunks of an upload are kept
+	// after the last chunk was received, if it's never committed.
+	chunkUploadTTL = 24 * time.Hour
+	// chunkPruneInterval is how often expired uploads are removed
+	chunkPruneInterval = time.Hour
+)
+
+type (
+	// ChunkStatusResponse lists the chunks already received for an
+	// upload, so that a client can resume an interrupted upload.
+	ChunkStatusResponse struct {
+		Chunks []int `json:"chunks"`
+	}
+
+	// uploadLocks serializes the requests of one upload, so that a
+	// commit or the pruner never removes chunks while they are written.
+	uploadLocks struct {
+		mu    sync.Mutex
+		locks map[string]*uploadLock
+	}
+
+	uploadLock struct {
+		sync.Mutex
+		refs int
+	}
+)
+
+// lock locks the upload and returns the function to unlock it.
+func (l *uploadLocks) lock(uploadID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*uploadLock)
+	}
+	ul, ok := l.locks[uploadID]
+	if !ok {
+		ul = &uploadLock{}
+		l.locks[uploadID] = ul
+	}
+	ul.refs++
+	l.mu.Unlock()
+
+	ul.Lock()
+	return func() {
+		ul.Unlock()
+		l.mu.Lock()
+		ul.refs--
+		if ul.refs == 0 {
+			delete(l.locks, uploadID)
+		}
+		l.mu.Unlock()
+	}
+}
+
+var uploadIDRegex = regexp.MustCompile(`^[a-zA-Z0-9-]{1,128}$`)
+
+func (ss *StorageService) getUploadID(r *http.Request) (string, error) {
+	uploadID := r.Header.Get(HeaderUploadID)
+	if len(uploadID) == 0 {
+		uploadID = r.URL.Query().Get("uploadid")
+	}
+	if !uploadIDRegex.MatchString(uploadID) {
+		return "", errors.Errorf("missing or invalid %v", HeaderUploadID)
+	}
+	return uploadID, nil
+}
+
+func (ss *StorageService) uploadDir(uploadID string) string {
+	return filepath.Join(ss.chunkDir, uploadID)
+}
+
+func chunkFileName(dir string, index int) string {
+	return filepath.Join(dir, strconv.Itoa(index))
+}
+
+// Handle a single chunk of a chunked upload. Chunks are kept on the
+// local disk until the upload is committed.
+func (ss *StorageService) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
+	uploadID, err := ss.getUploadID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	index, err := strconv.Atoi(r.Header.Get(HeaderChunkIndex))
+	if err != nil || index < 0 {
+		http.Error(w, fmt.Sprintf("missing or bad %v header", HeaderChunkIndex), http.StatusBadRequest)
+		return
+	}
+
+	err = r.ParseMultipartForm(0)
+	if err != nil {
+		http.Error(w, "failed to parse request", http.StatusBadRequest)
+		return
+	}
+	file, _, err := r.FormFile("uploadfile")
+	if err != nil {
+		http.Error(w, "missing upload file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	unlock := ss.uploadLocks.lock(uploadID)
+	defer unlock()
+
+	dir := ss.uploadDir(uploadID)
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		ss.logger.Error("error creating chunk directory", zap.Error(err), zap.String("upload_id", uploadID))
+		http.Error(w, "Error saving chunk", http.StatusInternalServerError)
+		return
+	}
+
+	// write to a temporary file first so that a broken connection
+	// never leaves a partial chunk behind.
+	tmp, err := os.CreateTemp(dir, "partial-")
+	if err != nil {
+		ss.logger.Error("error creating chunk file", zap.Error(err), zap.String("upload_id", uploadID))
+		http.Error(w, "Error saving chunk", http.StatusInternalServerError)
+		return
+	}
+	_, err = io.Copy(tmp, file)
+	tmp.Close()
+	if err == nil {
+		err = os.Rename(tmp.Name(), chunkFileName(dir, index))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		ss.logger.Error("error writing chunk", zap.Error(err), zap.String("upload_id", uploadID), zap.Int("chunk", index))
+		http.Error(w, "Error saving chunk", http.StatusInternalServerError)
+		return
+	}
+
+	ss.logger.Debug("received chunk", zap.String("upload_id", uploadID), zap.Int("chunk", index))
+	w.WriteHeader(http.StatusOK)
+}
+
+// Return the indexes of the chunks already received for an upload.
+func (ss *StorageService) chunkStatusHandler(w http.ResponseWriter, r *http.Request) {
+	uploadID, err := ss.getUploadID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	unlock := ss.uploadLocks.lock(uploadID)
+	chunks, err := receivedChunks(ss.uploadDir(uploadID))
+	unlock()
+	if err != nil {
+		ss.logger.Error("error listing chunks", zap.Error(err), zap.String("upload_id", uploadID))
+		http.Error(w, "Error listing chunks", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := json.Marshal(&ChunkStatusResponse{Chunks: chunks})
+	if err != nil {
+		http.Error(w, "Error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		ss.logger.Error("error writing HTTP response", zap.Error(err))
+	}
+}
+
+// Assemble the chunks of an upload in order and save the result to
+// the storage backend. If the assembled file doesn't match the size or
+// checksum given by the client, the chunks are removed so that the
+// upload can start over.
+func (ss *StorageService) chunkCommitHandler(w http.ResponseWriter, r *http.Request) {
+	uploadID, err := ss.getUploadID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	count, err := strconv.Atoi(r.Header.Get(HeaderChunkCount))
+	if err != nil || count <= 0 {
+		http.Error(w, fmt.Sprintf("missing or bad %v header", HeaderChunkCount), http.StatusBadRequest)
+		return
+	}
+
+	unlock := ss.uploadLocks.lock(uploadID)
+	defer unlock()
+
+	dir := ss.uploadDir(uploadID)
+	removeChunks := func() {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			ss.logger.Warn("error removing chunks", zap.Error(err), zap.String("upload_id", uploadID))
+		}
+	}
+
+	size, checksum, err := checkChunks(dir, count)
+	if err != nil {
+		// chunks may have been consumed by a concurrent commit of
+		// the same file, the client can upload them again
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	fileSize, err := strconv.ParseInt(r.Header.Get("X-File-Size"), 10, 64)
+	if err == nil && fileSize != size {
+		removeChunks()
+		http.Error(w, fmt.Sprintf("size of the chunks (%v) doesn't match X-File-Size (%v)", size, fileSize), http.StatusBadRequest)
+		return
+	}
+	if sum := r.Header.Get(HeaderFileChecksum); len(sum) > 0 && sum != checksum {
+		removeChunks()
+		http.Error(w, fmt.Sprintf("checksum of the chunks (%v) doesn't match %v (%v)", checksum, HeaderFileChecksum, sum), http.StatusBadRequest)
+		return
+	}
+
+	var readers []io.Reader
+	for i := 0; i < count; i++ {
+		f, err := os.Open(chunkFileName(dir, i))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading chunk %v", i), http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	id, err := ss.storageClient.putFile(io.MultiReader(readers...), size)
+	if err != nil {
+		ss.logger.Error("error saving assembled file", zap.Error(err), zap.String("upload_id", uploadID))
+		http.Error(w, "Error saving uploaded file", http.StatusInternalServerError)
+		return
+	}
+	removeChunks()
+
+	resp, err := json.Marshal(&UploadResponse{ID: id})
+	if err != nil {
+		http.Error(w, "Error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		ss.logger.Error("error writing HTTP response", zap.Error(err))
+	}
+}
+
+// checkChunks returns the total size and the SHA-256 of the first count
+// chunks in dir, or an error if one of them is missing.
+func checkChunks(dir string, count int) (int64, string, error) {
+	hasher := sha256.New()
+	var size int64
+	for i := 0; i < count; i++ {
+		f, err := os.Open(chunkFileName(dir, i))
+		if err != nil {
+			return 0, "", errors.Errorf("missing chunk %v", i)
+		}
+		n, err := io.Copy(hasher, f)
+		f.Close()
+		if err != nil {
+			return 0, "", errors.Wrapf(err, "error reading chunk %v", i)
+		}
+		size += n
+	}
+	return size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// pruneChunks periodically removes the chunks of uploads which haven't
+// received a chunk within the TTL, e.g. uploads abandoned by the client.
+func (ss *StorageService) pruneChunks(ctx context.Context, ttl time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ss.removeExpiredUploads(ttl)
+		}
+	}
+}
+
+func (ss *StorageService) removeExpiredUploads(ttl time.Duration) {
+	entries, err := os.ReadDir(ss.chunkDir)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		ss.logger.Error("error listing chunk uploads", zap.Error(err))
+		return
+	}
+	for _, e := range entries {
+		uploadID := e.Name()
+		unlock := ss.uploadLocks.lock(uploadID)
+		// the directory is modified whenever a chunk is added to it
+		fi, err := os.Stat(ss.uploadDir(uploadID))
+		if err == nil && time.Since(fi.ModTime()) > ttl {
+			ss.logger.Info("removing expired chunk upload", zap.String("upload_id", uploadID))
+			err = os.RemoveAll(ss.uploadDir(uploadID))
+			if err != nil {
+				ss.logger.Error("error removing expired chunk upload", zap.Error(err), zap.String("upload_id", uploadID))
+			}
+		}
+		unlock()
+	}
+}
+
+// receivedChunks returns the sorted indexes of the chunks in dir.
+func receivedChunks(dir string) ([]int, error) {
+	chunks := []int{}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return chunks, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		index, err := strconv.Atoi(e.Name())
+		if err != nil {
+			// partial chunk
+			continue
+		}
+		chunks = append(chunks, index)
+	}
+	sort.Ints(chunks)
+	return chunks, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func makeTestStorageService(t *testing.T, dir string) *StorageService {
+	storageClient, err := MakeStowClient(zap.NewNop(), NewLocalStorage(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss := MakeStorageService(zap.NewNop(), storageClient, 0)
+	ss.chunkDir = filepath.Join(dir, "chunks")
+	return ss
+}
+
+func putChunk(ss *StorageService, uploadID string, index int, data []byte) int {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, _ := w.CreateFormFile("uploadfile", "chunk")
+	fw.Write(data) //nolint: errcheck
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/archive/chunk", buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set(HeaderUploadID, uploadID)
+	req.Header.Set(HeaderChunkIndex, fmt.Sprintf("%v", index))
+	rr := httptest.NewRecorder()
+	ss.chunkUploadHandler(rr, req)
+	return rr.Code
+}
+
+func receivedChunkIndexes(t *testing.T, ss *StorageService, uploadID string) []int {
+	req := httptest.NewRequest(http.MethodGet, "/v1/archive/chunk?uploadid="+uploadID, nil)
+	rr := httptest.NewRecorder()
+	ss.chunkStatusHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var status ChunkStatusResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &status))
+	return status.Chunks
+}
+
+func commitChunks(ss *StorageService, uploadID string, count int, size int, checksum string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/archive/commit", nil)
+	req.Header.Set(HeaderUploadID, uploadID)
+	req.Header.Set(HeaderChunkCount, fmt.Sprintf("%v", count))
+	req.Header.Set(HeaderFileChecksum, checksum)
+	req.Header.Set("X-File-Size", fmt.Sprintf("%v", size))
+	rr := httptest.NewRecorder()
+	ss.chunkCommitHandler(rr, req)
+	return rr
+}
+
+func TestChunkedUpload(t *testing.T) {
+	dir, err := os.MkdirTemp("", "storagesvc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ss := makeTestStorageService(t, dir)
+
+	chunks := [][]byte{[]byte("hello "), []byte("chunked "), []byte("world")}
+	data := bytes.Join(chunks, nil)
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	cases := []struct {
+		name     string
+		size     int
+		checksum string
+		status   int
+	}{
+		{"size mismatch", len(data) + 1, checksum, http.StatusBadRequest},
+		{"checksum mismatch", len(data), checksum[1:] + "0", http.StatusBadRequest},
+		{"committed", len(data), checksum, http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uploadID := checksum + "-6"
+
+			// an interrupted upload is resumed from the missing chunk
+			assert.Equal(t, http.StatusOK, putChunk(ss, uploadID, 0, chunks[0]))
+			assert.Equal(t, http.StatusOK, putChunk(ss, uploadID, 2, chunks[2]))
+			assert.Equal(t, []int{0, 2}, receivedChunkIndexes(t, ss, uploadID))
+			assert.Equal(t, http.StatusConflict, commitChunks(ss, uploadID, len(chunks), len(data), checksum).Code)
+			assert.Equal(t, http.StatusOK, putChunk(ss, uploadID, 1, chunks[1]))
+
+			rr := commitChunks(ss, uploadID, len(chunks), c.size, c.checksum)
+			assert.Equal(t, c.status, rr.Code)
+			// chunks are removed on commit and on a mismatch, so that
+			// the upload can start over
+			assert.Empty(t, receivedChunkIndexes(t, ss, uploadID))
+			if c.status != http.StatusOK {
+				return
+			}
+
+			var ur UploadResponse
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ur))
+			stored := &bytes.Buffer{}
+			assert.NoError(t, ss.storageClient.copyFileToStream(ur.ID, stored))
+			assert.Equal(t, data, stored.Bytes())
+		})
+	}
+}
+
+func TestRemoveExpiredUploads(t *testing.T) {
+	dir, err := os.MkdirTemp("", "storagesvc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ss := makeTestStorageService(t, dir)
+
+	assert.Equal(t, http.StatusOK, putChunk(ss, "expired", 0, []byte("a")))
+	assert.Equal(t, http.StatusOK, putChunk(ss, "active", 0, []byte("b")))
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(ss.uploadDir("expired"), old, old))
+
+	ss.removeExpiredUploads(time.Hour)
+	assert.Empty(t, receivedChunkIndexes(t, ss, "expired"))
+	assert.Equal(t, []int{0}, receivedChunkIndexes(t, ss, "active"))
+}
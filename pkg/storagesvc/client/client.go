@@ -19,6 +19,8 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -118,6 +120,160 @@ func (c *Client) Upload(ctx context.Context, filePath string, metadata *map[stri
 	return ur.ID, nil
 }
 
+// UploadChunked sends the local file to the storage service in chunks
+// of chunkSize bytes, so that large archives never have to be held in
+// memory at once. The upload ID is derived from the file checksum and
+// the chunk size; if an upload is interrupted, running it again with the
+// same chunk size only sends the chunks the storage service hasn't
+// received yet. It returns a file ID that can be used to retrieve the file.
+func (c *Client) UploadChunked(ctx context.Context, filePath string, chunkSize int64) (string, error) {
+	if chunkSize <= 0 {
+		return "", errors.Errorf("invalid chunk size %v", chunkSize)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	fileSize := fi.Size()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, f)
+	if err != nil {
+		return "", err
+	}
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	uploadID := fmt.Sprintf("%v-%v", checksum, chunkSize)
+	chunkCount := int((fileSize + chunkSize - 1) / chunkSize)
+
+	// A concurrent upload of the same file may commit the chunks
+	// first, in which case they are sent again.
+	for attempt := 0; attempt < 2; attempt++ {
+		received, err := c.getReceivedChunks(ctx, uploadID)
+		if err != nil {
+			return "", err
+		}
+
+		for i := 0; i < chunkCount; i++ {
+			if received[i] {
+				continue
+			}
+			chunk := io.NewSectionReader(f, int64(i)*chunkSize, chunkSize)
+			err = c.uploadChunk(ctx, uploadID, i, chunk)
+			if err != nil {
+				return "", errors.Wrapf(err, "error uploading chunk %v/%v", i+1, chunkCount)
+			}
+		}
+
+		id, retry, err := c.commitChunks(ctx, uploadID, chunkCount, fileSize, checksum)
+		if !retry {
+			return id, err
+		}
+	}
+	return "", errors.New("Upload commit error: chunks were removed by a concurrent upload")
+}
+
+// commitChunks asks the storage service to assemble the chunks of the
+// upload. retry is true if chunks are missing on the storage service.
+func (c *Client) commitChunks(ctx context.Context, uploadID string, chunkCount int, fileSize int64, checksum string) (id string, retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, c.url+"/archive/commit", nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set(storagesvc.HeaderUploadID, uploadID)
+	req.Header.Set(storagesvc.HeaderChunkCount, fmt.Sprintf("%v", chunkCount))
+	req.Header.Set(storagesvc.HeaderFileChecksum, checksum)
+	req.Header.Set("X-File-Size", fmt.Sprintf("%v", fileSize))
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return "", true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, errors.Errorf("Upload commit error %v: %v", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var ur storagesvc.UploadResponse
+	err = json.Unmarshal(body, &ur)
+	if err != nil {
+		return "", false, err
+	}
+
+	return ur.ID, false, nil
+}
+
+func (c *Client) getReceivedChunks(ctx context.Context, uploadID string) (map[int]bool, error) {
+	received := make(map[int]bool)
+
+	resp, err := ctxhttp.Get(ctx, c.httpClient, fmt.Sprintf("%v/archive/chunk?uploadid=%v", c.url, url.QueryEscape(uploadID)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("error getting upload status: %v", resp.Status)
+	}
+
+	var status storagesvc.ChunkStatusResponse
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range status.Chunks {
+		received[i] = true
+	}
+	return received, nil
+}
+
+func (c *Client) uploadChunk(ctx context.Context, uploadID string, index int, chunk io.Reader) error {
+	buf := &bytes.Buffer{}
+	bodyWriter := multipart.NewWriter(buf)
+	fileWriter, err := bodyWriter.CreateFormFile("uploadfile", fmt.Sprintf("%v-%v", uploadID, index))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fileWriter, chunk)
+	if err != nil {
+		return err
+	}
+	contentType := bodyWriter.FormDataContentType()
+	bodyWriter.Close()
+
+	req, err := http.NewRequest(http.MethodPost, c.url+"/archive/chunk", buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(storagesvc.HeaderUploadID, uploadID)
+	req.Header.Set(storagesvc.HeaderChunkIndex, fmt.Sprintf("%v", index))
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Upload error %v", resp.Status)
+	}
+	return nil
+}
+
 // GetUrl returns an HTTP URL that can be used to download the file pointed to by ID
 func (c *Client) GetUrl(id string) string {
 	return fmt.Sprintf("%v/archive?id=%v", c.url, url.PathEscape(id))
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -49,6 +50,8 @@ type (
 		logger        *zap.Logger
 		storageClient *StowClient
 		port          int
+		chunkDir      string
+		uploadLocks   uploadLocks
 	}
 
 	UploadResponse struct {
@@ -198,6 +201,7 @@ func MakeStorageService(logger *zap.Logger, storageClient *StowClient, port int)
 		logger:        logger.Named("storage_service"),
 		storageClient: storageClient,
 		port:          port,
+		chunkDir:      filepath.Join(os.TempDir(), "fission-upload-chunks"),
 	}
 }
 
@@ -206,6 +210,9 @@ func (ss *StorageService) Start(port int, openTracingEnabled bool) {
 	r.HandleFunc("/v1/archive", ss.uploadHandler).Methods("POST")
 	r.HandleFunc("/v1/archive", ss.downloadHandler).Methods("GET")
 	r.HandleFunc("/v1/archive", ss.deleteHandler).Methods("DELETE")
+	r.HandleFunc("/v1/archive/chunk", ss.chunkUploadHandler).Methods("POST")
+	r.HandleFunc("/v1/archive/chunk", ss.chunkStatusHandler).Methods("GET")
+	r.HandleFunc("/v1/archive/commit", ss.chunkCommitHandler).Methods("POST")
 	r.HandleFunc("/healthz", ss.healthHandler).Methods("GET")
 
 	address := fmt.Sprintf(":%v", port)
@@ -233,6 +240,7 @@ func Start(ctx context.Context, logger *zap.Logger, storage Storage, port int, o
 	// create http handlers
 	storageService := MakeStorageService(logger, storageClient, port)
 	go storageService.Start(port, openTracingEnabled)
+	go storageService.pruneChunks(ctx, chunkUploadTTL, chunkPruneInterval)
 
 	// enablePruner prevents storagesvc unit test from needing to talk to kubernetes
 	if enablePruner {
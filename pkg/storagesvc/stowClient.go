@@ -19,7 +19,6 @@ package storagesvc
 import (
 	"fmt"
 	"io"
-	"mime/multipart"
 	"os"
 	"strings"
 	"time"
@@ -132,7 +131,7 @@ func MakeStowClient(logger *zap.Logger, storage Storage) (*StowClient, error) {
 }
 
 // putFile writes the file on the storage
-func (client *StowClient) putFile(file multipart.File, fileSize int64) (string, error) {
+func (client *StowClient) putFile(file io.Reader, fileSize int64) (string, error) {
 	uploadName, err := client.config.storage.getUploadFileName()
 	if err != nil {
 		return "", err
@@ -529,6 +529,7 @@ run_all_tests() {
         $ROOT/test/tests/test_node_hello_http.sh \
         $ROOT/test/tests/test_package_command.sh \
         $ROOT/test/tests/test_package_checksum.sh \
+        $ROOT/test/tests/test_package_namespace.sh \
         $ROOT/test/tests/test_pass.sh \
         $ROOT/test/tests/test_specs/test_spec.sh \
         $ROOT/test/tests/test_specs/test_spec_multifile.sh \
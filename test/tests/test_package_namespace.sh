@@ -0,0 +1,70 @@
+#!/bin/bash
+
+set -euo pipefail
+source $(dirname $0)/../utils.sh
+
+# Create a package in a non-default namespace with the global --namespace
+# flag and check that all package commands find it there.
+
+TEST_ID=$(generate_test_id)
+echo "TEST_ID = $TEST_ID"
+
+tmp_dir="/tmp/test-$TEST_ID"
+mkdir -p $tmp_dir
+
+ns=pkgns-$TEST_ID
+env=python-$TEST_ID
+pkg=pkg-$TEST_ID
+fn=fn-$TEST_ID
+
+cleanup() {
+    log "Cleaning up..."
+    fission fn delete --name $fn --namespace $ns || true
+    fission pkg delete --name $pkg --namespace $ns || true
+    fission env delete --name $env --namespace $ns || true
+    kubectl delete namespace $ns --wait=false || true
+    rm -rf $tmp_dir
+}
+
+if [ -z "${TEST_NOCLEANUP:-}" ]; then
+    trap cleanup EXIT
+else
+    log "TEST_NOCLEANUP is set; not cleaning up test artifacts afterwards."
+fi
+
+kubectl create namespace $ns
+
+log "Creating env in namespace $ns"
+fission env create --name $env --image $PYTHON_RUNTIME_IMAGE --namespace $ns
+
+log "Creating package in namespace $ns"
+printf 'def main():\n    return "Hello, world!"' > $tmp_dir/hello.py
+fission pkg create --name $pkg --deploy $tmp_dir/hello.py --env $env --namespace $ns
+
+log "Checking the package was created in namespace $ns only"
+kubectl get packages $pkg --namespace $ns
+if kubectl get packages $pkg --namespace default; then
+    log "Package $pkg found in the default namespace"
+    exit 1
+fi
+timeout 60s bash -c "waitBuild $pkg $ns"
+
+log "Checking package commands with --namespace"
+fission pkg list --namespace $ns | grep $pkg
+fission pkg info --name $pkg --namespace $ns | grep $pkg
+fission pkg getdeploy --name $pkg --namespace $ns --output $tmp_dir/deploy.zip
+test -s $tmp_dir/deploy.zip
+
+log "Creating function with the package"
+fission fn create --name $fn --pkg $pkg --entrypoint "hello.main" --namespace $ns
+fission fn test --name $fn --namespace $ns | grep "Hello, world!"
+
+log "Deleting package"
+fission fn delete --name $fn --namespace $ns
+fission pkg delete --name $pkg --namespace $ns
+if kubectl get packages $pkg --namespace $ns; then
+    log "Package $pkg was not deleted"
+    exit 1
+fi
+
+log "Test PASSED"
@@ -139,12 +139,14 @@ wait_for_builder() {
 }
 export -f wait_for_builder
 
+# waitBuild waits for the build of package $1 in namespace $2, or in
+# the default namespace if $2 is not given.
 waitBuild() {
     log "Waiting for builder manager to finish the build"
     echo "Waiting for builder manager"
     set +e
     while true; do
-      kubectl --namespace default get packages $1 -o jsonpath='{.status.buildstatus}'|grep succeeded
+      kubectl --namespace ${2:-default} get packages $1 -o jsonpath='{.status.buildstatus}'|grep succeeded
       if [[ $? -eq 0 ]]; then
           break
       fi